@@ -0,0 +1,344 @@
+// Package abi implements the Solidity contract ABI: parsing JSON ABI definitions, computing function selectors and
+// event topics, and encoding and decoding values as described in https://docs.soliditylang.org/en/latest/abi-spec.html
+package abi
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/sha3"
+
+	"github.com/INFURA/go-ethlibs/eth"
+)
+
+// ABI is a parsed contract ABI.  Functions, events and errors are kept in the order they were defined in, and may
+// be overloaded.
+type ABI struct {
+	Constructor *Method
+	Fallback    *Method
+	Receive     *Method
+	Methods     []Method
+	Events      []Event
+	Errors      []Error
+}
+
+// Method is a contract function, or the constructor, fallback or receive function.
+type Method struct {
+	Type            string
+	Name            string
+	Inputs          Arguments
+	Outputs         Arguments
+	StateMutability string
+}
+
+// Event is a contract event.
+type Event struct {
+	Name      string
+	Inputs    Arguments
+	Anonymous bool
+}
+
+// Error is a contract custom error.
+type Error struct {
+	Name   string
+	Inputs Arguments
+}
+
+type entryJSON struct {
+	Type            string    `json:"type"`
+	Name            string    `json:"name"`
+	Inputs          Arguments `json:"inputs"`
+	Outputs         Arguments `json:"outputs"`
+	StateMutability string    `json:"stateMutability"`
+	Anonymous       bool      `json:"anonymous"`
+
+	// Legacy fields replaced by stateMutability
+	Constant bool `json:"constant"`
+	Payable  bool `json:"payable"`
+}
+
+// Parse parses a JSON ABI definition, which is either the array of functions, events and errors emitted by solc,
+// or an object with an "abi" field containing that array, as found in hardhat and truffle build artifacts.
+func Parse(data []byte) (*ABI, error) {
+	a := ABI{}
+	if err := json.Unmarshal(data, &a); err != nil {
+		return nil, err
+	}
+
+	return &a, nil
+}
+
+// MustParse is like Parse but panics if the ABI can't be parsed.
+func MustParse(data string) *ABI {
+	a, err := Parse([]byte(data))
+	if err != nil {
+		panic(err)
+	}
+
+	return a
+}
+
+func (a *ABI) UnmarshalJSON(data []byte) error {
+	trimmed := strings.TrimSpace(string(data))
+	if strings.HasPrefix(trimmed, "{") {
+		artifact := struct {
+			ABI json.RawMessage `json:"abi"`
+		}{}
+
+		if err := json.Unmarshal(data, &artifact); err != nil {
+			return err
+		}
+
+		if artifact.ABI == nil {
+			return errors.New("object has no abi field")
+		}
+
+		data = artifact.ABI
+	}
+
+	var entries []entryJSON
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return errors.Wrap(err, "could not parse ABI")
+	}
+
+	parsed := ABI{}
+	for i := range entries {
+		e := &entries[i]
+		switch e.Type {
+		case "function", "":
+			// the type defaults to function when omitted
+			m := e.method("function")
+			parsed.Methods = append(parsed.Methods, *m)
+		case "constructor":
+			parsed.Constructor = e.method(e.Type)
+		case "fallback":
+			parsed.Fallback = e.method(e.Type)
+		case "receive":
+			parsed.Receive = e.method(e.Type)
+		case "event":
+			parsed.Events = append(parsed.Events, Event{Name: e.Name, Inputs: e.Inputs, Anonymous: e.Anonymous})
+		case "error":
+			parsed.Errors = append(parsed.Errors, Error{Name: e.Name, Inputs: e.Inputs})
+		default:
+			return errors.Errorf("unknown ABI entry type %s", e.Type)
+		}
+	}
+
+	*a = parsed
+	return nil
+}
+
+func (e *entryJSON) method(typ string) *Method {
+	mutability := e.StateMutability
+	if mutability == "" {
+		switch {
+		case e.Constant:
+			mutability = "view"
+		case e.Payable:
+			mutability = "payable"
+		default:
+			mutability = "nonpayable"
+		}
+	}
+
+	return &Method{
+		Type:            typ,
+		Name:            e.Name,
+		Inputs:          e.Inputs,
+		Outputs:         e.Outputs,
+		StateMutability: mutability,
+	}
+}
+
+// Method returns the function matching name, which is either a plain function name or, to select between
+// overloaded functions, a signature such as transfer(address,uint256).
+func (a *ABI) Method(name string) (*Method, error) {
+	var found *Method
+	for i := range a.Methods {
+		m := &a.Methods[i]
+		if m.Signature() == name {
+			return m, nil
+		}
+
+		if m.Name == name {
+			if found != nil {
+				return nil, errors.Errorf("function %s is overloaded, use its signature instead", name)
+			}
+			found = m
+		}
+	}
+
+	if found == nil {
+		return nil, errors.Errorf("function %s not found", name)
+	}
+
+	return found, nil
+}
+
+// MethodBySelector returns the function whose selector matches.
+func (a *ABI) MethodBySelector(selector eth.Data4) (*Method, error) {
+	for i := range a.Methods {
+		if strings.EqualFold(a.Methods[i].Selector().String(), selector.String()) {
+			return &a.Methods[i], nil
+		}
+	}
+
+	return nil, errors.Errorf("function with selector %s not found", selector)
+}
+
+// Event returns the event matching name, which is either a plain event name or, to select between overloaded events,
+// a signature such as Transfer(address,address,uint256).
+func (a *ABI) Event(name string) (*Event, error) {
+	var found *Event
+	for i := range a.Events {
+		e := &a.Events[i]
+		if e.Signature() == name {
+			return e, nil
+		}
+
+		if e.Name == name {
+			if found != nil {
+				return nil, errors.Errorf("event %s is overloaded, use its signature instead", name)
+			}
+			found = e
+		}
+	}
+
+	if found == nil {
+		return nil, errors.Errorf("event %s not found", name)
+	}
+
+	return found, nil
+}
+
+// EventByTopic returns the non-anonymous event whose topic, the hash of its signature, matches.
+func (a *ABI) EventByTopic(topic eth.Topic) (*Event, error) {
+	for i := range a.Events {
+		e := &a.Events[i]
+		if !e.Anonymous && strings.EqualFold(e.Topic().String(), topic.String()) {
+			return e, nil
+		}
+	}
+
+	return nil, errors.Errorf("event with topic %s not found", topic)
+}
+
+// Error returns the custom error matching name, which is either a plain error name or, to select between overloaded
+// errors, a signature such as InsufficientBalance(uint256,uint256).
+func (a *ABI) Error(name string) (*Error, error) {
+	var found *Error
+	for i := range a.Errors {
+		e := &a.Errors[i]
+		if e.Signature() == name {
+			return e, nil
+		}
+
+		if e.Name == name {
+			if found != nil {
+				return nil, errors.Errorf("error %s is overloaded, use its signature instead", name)
+			}
+			found = e
+		}
+	}
+
+	if found == nil {
+		return nil, errors.Errorf("error %s not found", name)
+	}
+
+	return found, nil
+}
+
+// ErrorBySelector returns the custom error whose selector matches.
+func (a *ABI) ErrorBySelector(selector eth.Data4) (*Error, error) {
+	for i := range a.Errors {
+		if strings.EqualFold(a.Errors[i].Selector().String(), selector.String()) {
+			return &a.Errors[i], nil
+		}
+	}
+
+	return nil, errors.Errorf("error with selector %s not found", selector)
+}
+
+// Signature returns the canonical signature of the function, e.g. transfer(address,uint256).
+func (m *Method) Signature() string {
+	return m.Name + "(" + m.Inputs.Types() + ")"
+}
+
+// Selector returns the function selector, the first 4 bytes of the keccak256 hash of its signature.
+func (m *Method) Selector() eth.Data4 {
+	return selector(m.Signature())
+}
+
+// IsConstant returns true for view and pure functions, which don't modify state and can be used with eth_call.
+func (m *Method) IsConstant() bool {
+	return m.StateMutability == "view" || m.StateMutability == "pure"
+}
+
+// EncodeCall returns the call data of the function, which is its selector followed by the ABI encoded arguments.
+func (m *Method) EncodeCall(args ...interface{}) (*eth.Input, error) {
+	encoded, err := m.Inputs.Pack(args...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not encode arguments of %s", m.Signature())
+	}
+
+	return eth.NewInput(m.Selector().String() + hex.EncodeToString(encoded))
+}
+
+// DecodeOutput decodes the data returned by a call of the function, e.g. the result of eth_call.
+func (m *Method) DecodeOutput(data []byte) ([]interface{}, error) {
+	values, err := m.Outputs.Unpack(data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not decode output of %s", m.Signature())
+	}
+
+	return values, nil
+}
+
+// EncodeDeploy returns the input of a contract creation transaction, which is the contract bytecode followed by the
+// ABI encoded arguments of the constructor.  The ABI may omit the constructor if it doesn't take any arguments.
+func (a *ABI) EncodeDeploy(bytecode eth.Data, args ...interface{}) (*eth.Input, error) {
+	inputs := Arguments{}
+	if a.Constructor != nil {
+		inputs = a.Constructor.Inputs
+	}
+
+	encoded, err := inputs.Pack(args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not encode constructor arguments")
+	}
+
+	return eth.NewInput(bytecode.String() + hex.EncodeToString(encoded))
+}
+
+// Signature returns the canonical signature of the event, e.g. Transfer(address,address,uint256).
+func (e *Event) Signature() string {
+	return e.Name + "(" + e.Inputs.Types() + ")"
+}
+
+// Topic returns the keccak256 hash of the event signature, which non-anonymous events use as their first topic.
+func (e *Event) Topic() eth.Topic {
+	return eth.Topic("0x" + hex.EncodeToString(keccak256([]byte(e.Signature()))))
+}
+
+// Signature returns the canonical signature of the error, e.g. InsufficientBalance(uint256,uint256).
+func (e *Error) Signature() string {
+	return e.Name + "(" + e.Inputs.Types() + ")"
+}
+
+// Selector returns the error selector, the first 4 bytes of the keccak256 hash of its signature.
+func (e *Error) Selector() eth.Data4 {
+	return selector(e.Signature())
+}
+
+// selector returns the first 4 bytes of the keccak256 hash of a signature.
+func selector(signature string) eth.Data4 {
+	return eth.Data4("0x" + hex.EncodeToString(keccak256([]byte(signature))[:4]))
+}
+
+func keccak256(b []byte) []byte {
+	hash := sha3.NewLegacyKeccak256()
+	hash.Write(b)
+	return hash.Sum(nil)
+}
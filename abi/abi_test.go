@@ -0,0 +1,138 @@
+package abi_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/INFURA/go-ethlibs/abi"
+	"github.com/INFURA/go-ethlibs/eth"
+)
+
+const erc20 = `[
+  {"type":"constructor","inputs":[{"name":"name_","type":"string"},{"name":"symbol_","type":"string"}],"stateMutability":"nonpayable"},
+  {"type":"function","name":"balanceOf","inputs":[{"name":"account","type":"address"}],"outputs":[{"name":"","type":"uint256"}],"stateMutability":"view"},
+  {"type":"function","name":"transfer","inputs":[{"name":"to","type":"address"},{"name":"amount","type":"uint256"}],"outputs":[{"name":"","type":"bool"}],"stateMutability":"nonpayable"},
+  {"type":"function","name":"safeTransferFrom","inputs":[{"name":"from","type":"address"},{"name":"to","type":"address"},{"name":"tokenId","type":"uint256"}],"outputs":[],"stateMutability":"nonpayable"},
+  {"type":"function","name":"safeTransferFrom","inputs":[{"name":"from","type":"address"},{"name":"to","type":"address"},{"name":"tokenId","type":"uint256"},{"name":"data","type":"bytes"}],"outputs":[],"stateMutability":"nonpayable"},
+  {"constant":true,"inputs":[],"name":"decimals","outputs":[{"name":"","type":"uint8"}],"payable":false,"type":"function"},
+  {"type":"event","name":"Transfer","inputs":[{"name":"from","type":"address","indexed":true},{"name":"to","type":"address","indexed":true},{"name":"value","type":"uint256","indexed":false}],"anonymous":false},
+  {"type":"error","name":"InsufficientBalance","inputs":[{"name":"available","type":"uint256"},{"name":"required","type":"uint256"}]},
+  {"type":"function","name":"submit","inputs":[{"name":"order","type":"tuple","internalType":"struct Order","components":[{"name":"maker","type":"address"},{"name":"amounts","type":"uint256[]"}]}],"outputs":[],"stateMutability":"payable"},
+  {"type":"receive","stateMutability":"payable"}
+]`
+
+func TestParse(t *testing.T) {
+	a, err := abi.Parse([]byte(erc20))
+	require.NoError(t, err)
+
+	require.NotNil(t, a.Constructor)
+	require.Len(t, a.Constructor.Inputs, 2)
+	require.NotNil(t, a.Receive)
+	require.Nil(t, a.Fallback)
+	require.Len(t, a.Methods, 6)
+	require.Len(t, a.Events, 1)
+	require.Len(t, a.Errors, 1)
+
+	transfer, err := a.Method("transfer")
+	require.NoError(t, err)
+	require.Equal(t, "transfer(address,uint256)", transfer.Signature())
+	require.Equal(t, eth.Data4("0xa9059cbb"), transfer.Selector())
+	require.False(t, transfer.IsConstant())
+
+	byName, err := a.MethodBySelector(*eth.MustData4("0xA9059CBB"))
+	require.NoError(t, err)
+	require.Equal(t, transfer, byName)
+
+	decimals, err := a.Method("decimals")
+	require.NoError(t, err)
+	require.Equal(t, "view", decimals.StateMutability)
+	require.True(t, decimals.IsConstant())
+
+	_, err = a.Method("safeTransferFrom")
+	require.Error(t, err, "overloaded functions must be selected by signature")
+
+	overload, err := a.Method("safeTransferFrom(address,address,uint256,bytes)")
+	require.NoError(t, err)
+	require.Equal(t, eth.Data4("0xb88d4fde"), overload.Selector())
+
+	submit, err := a.Method("submit")
+	require.NoError(t, err)
+	require.Equal(t, "submit((address,uint256[]))", submit.Signature())
+
+	_, err = a.Method("missing")
+	require.Error(t, err)
+
+	_, err = a.MethodBySelector(eth.Data4("0x00000000"))
+	require.Error(t, err)
+
+	event, err := a.Event("Transfer")
+	require.NoError(t, err)
+	require.Equal(t, eth.Topic("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"), event.Topic())
+	require.Len(t, event.Inputs.Indexed(), 2)
+	require.Len(t, event.Inputs.NonIndexed(), 1)
+
+	byTopic, err := a.EventByTopic(*eth.MustTopic("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"))
+	require.NoError(t, err)
+	require.Equal(t, event, byTopic)
+
+	e, err := a.Error("InsufficientBalance")
+	require.NoError(t, err)
+	require.Equal(t, "InsufficientBalance(uint256,uint256)", e.Signature())
+
+	byErrorSelector, err := a.ErrorBySelector(e.Selector())
+	require.NoError(t, err)
+	require.Equal(t, e, byErrorSelector)
+}
+
+func TestParse_Artifact(t *testing.T) {
+	artifact := `{"contractName":"Token","abi":` + erc20 + `,"bytecode":"0x6080"}`
+	a, err := abi.Parse([]byte(artifact))
+	require.NoError(t, err)
+	require.Len(t, a.Methods, 6)
+
+	_, err = abi.Parse([]byte(`{"contractName":"Token"}`))
+	require.Error(t, err)
+
+	_, err = abi.Parse([]byte(`[{"type":"function","name":"f","inputs":[{"name":"x","type":"uint7"}]}]`))
+	require.Error(t, err)
+
+	_, err = abi.Parse([]byte(`[{"type":"unknown"}]`))
+	require.Error(t, err)
+}
+
+func TestArgument_MarshalJSON(t *testing.T) {
+	raw := `[{"name":"orders","type":"tuple[2][]","internalType":"struct Order[2][]","components":[{"name":"maker","type":"address"},{"name":"amounts","type":"uint256[]"}]},{"name":"value","type":"uint256","indexed":true}]`
+
+	args := abi.Arguments{}
+	err := json.Unmarshal([]byte(raw), &args)
+	require.NoError(t, err)
+	require.Equal(t, "(address,uint256[])[2][],uint256", args.Types())
+
+	b, err := json.Marshal(&args)
+	require.NoError(t, err)
+	require.JSONEq(t, raw, string(b))
+}
+
+func TestABI_EncodeDeploy(t *testing.T) {
+	a := abi.MustParse(`[{"type":"constructor","inputs":[{"name":"name","type":"string"},{"name":"supply","type":"uint256"}]}]`)
+	input, err := a.EncodeDeploy(eth.Data("0x6080"), "abc", 100)
+	require.NoError(t, err)
+	require.Equal(t, "0x6080"+
+		"0000000000000000000000000000000000000000000000000000000000000040"+
+		"0000000000000000000000000000000000000000000000000000000000000064"+
+		"0000000000000000000000000000000000000000000000000000000000000003"+
+		"6162630000000000000000000000000000000000000000000000000000000000", input.String())
+
+	_, err = a.EncodeDeploy(eth.Data("0x6080"), "abc")
+	require.Error(t, err)
+
+	// without a constructor no arguments are accepted
+	input, err = abi.MustParse(events).EncodeDeploy(eth.Data("0x6080"))
+	require.NoError(t, err)
+	require.Equal(t, "0x6080", input.String())
+
+	_, err = abi.MustParse(events).EncodeDeploy(eth.Data("0x6080"), 1)
+	require.Error(t, err)
+}
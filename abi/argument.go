@@ -0,0 +1,157 @@
+package abi
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Argument is a named input or output of a function, event or error, or a component of a tuple.
+type Argument struct {
+	Name         string
+	Type         Type
+	InternalType string
+
+	// Indexed is only relevant for event inputs, and marks the arguments that are stored in the log topics.
+	Indexed bool
+}
+
+type argumentJSON struct {
+	Name         string     `json:"name"`
+	Type         string     `json:"type"`
+	InternalType string     `json:"internalType,omitempty"`
+	Components   []Argument `json:"components,omitempty"`
+	Indexed      bool       `json:"indexed,omitempty"`
+}
+
+func (a *Argument) UnmarshalJSON(data []byte) error {
+	aj := argumentJSON{}
+	if err := json.Unmarshal(data, &aj); err != nil {
+		return err
+	}
+
+	typ, err := NewType(aj.Type, aj.Components)
+	if err != nil {
+		return errors.Wrapf(err, "invalid argument %s", aj.Name)
+	}
+
+	*a = Argument{
+		Name:         aj.Name,
+		Type:         *typ,
+		InternalType: aj.InternalType,
+		Indexed:      aj.Indexed,
+	}
+	return nil
+}
+
+func (a Argument) MarshalJSON() ([]byte, error) {
+	aj := argumentJSON{
+		Name:         a.Name,
+		InternalType: a.InternalType,
+		Indexed:      a.Indexed,
+	}
+
+	// Tuples are described as "tuple" with the components of the innermost tuple listed separately
+	t := &a.Type
+	suffix := ""
+	for t.Kind == KindArray || t.Kind == KindSlice {
+		if t.Kind == KindArray {
+			suffix = "[" + strconv.Itoa(t.Size) + "]" + suffix
+		} else {
+			suffix = "[]" + suffix
+		}
+		t = t.Elem
+	}
+
+	if t.Kind == KindTuple {
+		aj.Type = "tuple" + suffix
+		aj.Components = t.Components
+	} else {
+		aj.Type = a.Type.String()
+	}
+
+	return json.Marshal(&aj)
+}
+
+// Arguments is an ordered list of arguments, such as the inputs of a function, which are encoded as a tuple.
+type Arguments []Argument
+
+// Types returns the canonical type of each argument joined by commas, as used in signatures.
+func (args Arguments) Types() string {
+	types := make([]string, len(args))
+	for i := range args {
+		types[i] = args[i].Type.String()
+	}
+
+	return strings.Join(types, ",")
+}
+
+// Indexed returns the arguments that are marked as indexed.
+func (args Arguments) Indexed() Arguments {
+	indexed := make(Arguments, 0, len(args))
+	for i := range args {
+		if args[i].Indexed {
+			indexed = append(indexed, args[i])
+		}
+	}
+
+	return indexed
+}
+
+// NonIndexed returns the arguments that are not marked as indexed.
+func (args Arguments) NonIndexed() Arguments {
+	nonIndexed := make(Arguments, 0, len(args))
+	for i := range args {
+		if !args[i].Indexed {
+			nonIndexed = append(nonIndexed, args[i])
+		}
+	}
+
+	return nonIndexed
+}
+
+// Pack ABI encodes the values as a tuple of the arguments, see Encode for the accepted Go types.
+func (args Arguments) Pack(values ...interface{}) ([]byte, error) {
+	if len(values) != len(args) {
+		return nil, errors.Errorf("expected %d values but received %d", len(args), len(values))
+	}
+
+	return encodeTuple(args, values)
+}
+
+// Unpack decodes ABI encoded data as a tuple of the arguments, see Decode for the returned Go types.
+func (args Arguments) Unpack(data []byte) ([]interface{}, error) {
+	return decodeTuple(args, data, 0)
+}
+
+// UnpackIntoMap is like Unpack but returns the values keyed by the argument names, unnamed arguments are keyed by
+// their position instead.
+func (args Arguments) UnpackIntoMap(data []byte) (map[string]interface{}, error) {
+	values, err := args.Unpack(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return args.toMap(values), nil
+}
+
+// toMap returns the values keyed by the names of the arguments, see UnpackIntoMap.
+func (args Arguments) toMap(values []interface{}) map[string]interface{} {
+	m := make(map[string]interface{}, len(values))
+	for i := range args {
+		m[args[i].key(i)] = values[i]
+	}
+
+	return m
+}
+
+// key returns the name of the argument, or its position for unnamed arguments.
+func (a *Argument) key(i int) string {
+	if a.Name != "" {
+		return a.Name
+	}
+
+	return "arg" + strconv.Itoa(i)
+}
@@ -0,0 +1,291 @@
+package abi
+
+import (
+	"encoding/hex"
+	"math/big"
+	"reflect"
+
+	"github.com/pkg/errors"
+
+	"github.com/INFURA/go-ethlibs/eth"
+)
+
+var (
+	addressType = reflect.TypeOf(eth.Address(""))
+	stringType  = reflect.TypeOf("")
+
+	// fixedDataSizes are the byte lengths of the fixed size hexadecimal eth types
+	fixedDataSizes = map[reflect.Type]int{
+		reflect.TypeOf(eth.Data4("")):   4,
+		reflect.TypeOf(eth.Data8("")):   8,
+		reflect.TypeOf(eth.Data20("")):  20,
+		reflect.TypeOf(eth.Data32("")):  32,
+		reflect.TypeOf(eth.Data256("")): 256,
+		addressType:                     20,
+	}
+)
+
+// Copy assigns values, as returned by Unpack, to v which must be a pointer to a struct or a slice.  Struct fields
+// are matched to the arguments by an `abi:"name"` tag or case-insensitively by name, and arguments without a
+// matching field are skipped.  A slice receives the values in order.
+//
+// Besides the types returned by Decode, values can be assigned to fields of the other Go types accepted by Encode,
+// e.g. a uint64 field for a uint64 argument or an eth.Hash field for a bytes32 argument.
+func (args Arguments) Copy(v interface{}, values []interface{}) error {
+	if len(values) != len(args) {
+		return errors.Errorf("expected %d values but received %d", len(args), len(values))
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return errors.Errorf("cannot copy values into non-pointer %T", v)
+	}
+
+	rv = rv.Elem()
+	switch rv.Kind() {
+	case reflect.Struct:
+		for i := range args {
+			field, ok := structField(rv, args[i].key(i))
+			if !ok {
+				continue
+			}
+
+			if err := assign(&args[i].Type, field, values[i]); err != nil {
+				return errors.Wrapf(err, "could not copy %s", args[i].key(i))
+			}
+		}
+	case reflect.Slice:
+		tuple := Type{Kind: KindTuple, Components: args}
+		return assign(&tuple, rv, values)
+	default:
+		return errors.Errorf("cannot copy values into %T", v)
+	}
+
+	return nil
+}
+
+// UnpackInto decodes ABI encoded data as a tuple of the arguments and assigns the values to v, see Copy.
+func (args Arguments) UnpackInto(data []byte, v interface{}) error {
+	values, err := args.Unpack(data)
+	if err != nil {
+		return err
+	}
+
+	return args.Copy(v, values)
+}
+
+// Assign sets the value pointed to by dst to a decoded value of type t, converting it as described by Copy.
+func Assign(t *Type, dst interface{}, value interface{}) error {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return errors.Errorf("cannot assign to non-pointer %T", dst)
+	}
+
+	return assign(t, rv.Elem(), value)
+}
+
+// assign sets dst to the decoded value of type t, converting it to the type of dst when necessary.
+func assign(t *Type, dst reflect.Value, value interface{}) error {
+	src := reflect.ValueOf(value)
+	if !src.IsValid() {
+		return errors.New("cannot assign nil")
+	}
+
+	if src.Type().AssignableTo(dst.Type()) {
+		dst.Set(src)
+		return nil
+	}
+
+	switch dst.Kind() {
+	case reflect.Ptr:
+		if dst.IsNil() {
+			dst.Set(reflect.New(dst.Type().Elem()))
+		}
+		return assign(t, dst.Elem(), value)
+	case reflect.Interface:
+		if dst.NumMethod() == 0 {
+			dst.Set(src)
+			return nil
+		}
+	}
+
+	src = indirect(src)
+	switch t.Kind {
+	case KindUint, KindInt:
+		i, err := toBig(src)
+		if err != nil {
+			return err
+		}
+		return assignInteger(dst, i)
+	case KindAddress:
+		b, err := toAddress(src)
+		if err != nil {
+			return err
+		}
+		a := eth.Address(eth.ToChecksumAddress("0x" + hex.EncodeToString(b)))
+		switch {
+		case dst.Type() == addressType:
+			dst.Set(reflect.ValueOf(a))
+			return nil
+		case dst.Kind() == reflect.String:
+			dst.SetString(a.String())
+			return nil
+		}
+		return assignBytes(dst, b)
+	case KindBool:
+		if dst.Kind() == reflect.Bool && src.Kind() == reflect.Bool {
+			dst.SetBool(src.Bool())
+			return nil
+		}
+	case KindString:
+		if dst.Kind() == reflect.String && src.Kind() == reflect.String {
+			dst.SetString(src.String())
+			return nil
+		}
+		if dst.Kind() == reflect.Slice && dst.Type().Elem().Kind() == reflect.Uint8 && src.Kind() == reflect.String {
+			dst.SetBytes([]byte(src.String()))
+			return nil
+		}
+	case KindFixedBytes, KindFunction, KindBytes:
+		b, err := toBytes(src)
+		if err != nil {
+			return err
+		}
+		return assignBytes(dst, b)
+	case KindArray, KindSlice:
+		elems, ok := value.([]interface{})
+		if !ok {
+			break
+		}
+		return assignElements(t.Elem, dst, elems)
+	case KindTuple:
+		elems, ok := value.([]interface{})
+		if !ok || len(elems) != len(t.Components) {
+			break
+		}
+		switch dst.Kind() {
+		case reflect.Struct:
+			for i := range t.Components {
+				field, ok := structField(dst, t.Components[i].key(i))
+				if !ok {
+					continue
+				}
+				if err := assign(&t.Components[i].Type, field, elems[i]); err != nil {
+					return errors.Wrapf(err, "could not assign tuple component %s", t.Components[i].key(i))
+				}
+			}
+			return nil
+		case reflect.Map:
+			if dst.Type().Key() != stringType {
+				break
+			}
+			if dst.IsNil() {
+				dst.Set(reflect.MakeMap(dst.Type()))
+			}
+			for i := range t.Components {
+				elem := reflect.New(dst.Type().Elem()).Elem()
+				if err := assign(&t.Components[i].Type, elem, elems[i]); err != nil {
+					return errors.Wrapf(err, "could not assign tuple component %s", t.Components[i].key(i))
+				}
+				dst.SetMapIndex(reflect.ValueOf(t.Components[i].key(i)), elem)
+			}
+			return nil
+		case reflect.Slice, reflect.Array:
+			if dst.Kind() == reflect.Slice {
+				dst.Set(reflect.MakeSlice(dst.Type(), len(elems), len(elems)))
+			} else if dst.Len() != len(elems) {
+				break
+			}
+			for i := range t.Components {
+				if err := assign(&t.Components[i].Type, dst.Index(i), elems[i]); err != nil {
+					return errors.Wrapf(err, "could not assign tuple component %s", t.Components[i].key(i))
+				}
+			}
+			return nil
+		}
+	}
+
+	return errors.Errorf("cannot assign %s value to %s", t, dst.Type())
+}
+
+// assignElements sets the elements of an array or slice.
+func assignElements(elem *Type, dst reflect.Value, elems []interface{}) error {
+	switch dst.Kind() {
+	case reflect.Slice:
+		dst.Set(reflect.MakeSlice(dst.Type(), len(elems), len(elems)))
+	case reflect.Array:
+		if dst.Len() != len(elems) {
+			return errors.Errorf("cannot assign %d elements to %s", len(elems), dst.Type())
+		}
+	default:
+		return errors.Errorf("cannot assign %s[] value to %s", elem, dst.Type())
+	}
+
+	for i := range elems {
+		if err := assign(elem, dst.Index(i), elems[i]); err != nil {
+			return errors.Wrapf(err, "could not assign element %d", i)
+		}
+	}
+
+	return nil
+}
+
+// assignInteger sets dst to i, checking that it fits when dst is a Go integer.
+func assignInteger(dst reflect.Value, i *big.Int) error {
+	switch dst.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if !i.IsInt64() || dst.OverflowInt(i.Int64()) {
+			return errors.Errorf("value %s overflows %s", i, dst.Type())
+		}
+		dst.SetInt(i.Int64())
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if !i.IsUint64() || dst.OverflowUint(i.Uint64()) {
+			return errors.Errorf("value %s overflows %s", i, dst.Type())
+		}
+		dst.SetUint(i.Uint64())
+		return nil
+	}
+
+	switch dst.Type() {
+	case bigIntType:
+		dst.Set(reflect.ValueOf(*new(big.Int).Set(i)))
+		return nil
+	case quantityTyp:
+		if i.Sign() < 0 {
+			return errors.Errorf("cannot assign negative value %s to %s", i, dst.Type())
+		}
+		dst.Set(reflect.ValueOf(eth.QuantityFromBigInt(i)))
+		return nil
+	}
+
+	return errors.Errorf("cannot assign integer to %s", dst.Type())
+}
+
+// assignBytes sets dst to b, which may be a byte slice or array, or one of the hexadecimal eth.Data types.
+func assignBytes(dst reflect.Value, b []byte) error {
+	switch {
+	case dst.Kind() == reflect.Slice && dst.Type().Elem().Kind() == reflect.Uint8:
+		dst.SetBytes(append([]byte{}, b...))
+		return nil
+	case dst.Kind() == reflect.Array && dst.Type().Elem().Kind() == reflect.Uint8:
+		if dst.Len() != len(b) {
+			return errors.Errorf("cannot assign %d bytes to %s", len(b), dst.Type())
+		}
+		reflect.Copy(dst, reflect.ValueOf(b))
+		return nil
+	case dst.Kind() == reflect.String:
+		if size, ok := fixedDataSizes[dst.Type()]; ok && size != len(b) {
+			return errors.Errorf("cannot assign %d bytes to %s", len(b), dst.Type())
+		}
+
+		s := "0x" + hex.EncodeToString(b)
+		if dst.Type() == addressType {
+			s = eth.ToChecksumAddress(s)
+		}
+		dst.SetString(s)
+		return nil
+	}
+
+	return errors.Errorf("cannot assign bytes to %s", dst.Type())
+}
@@ -0,0 +1,505 @@
+// Package bind generates typed Go bindings for contracts from their ABI, see cmd/abigen.
+package bind
+
+import (
+	"bytes"
+	"encoding/json"
+	"go/format"
+	"go/token"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/pkg/errors"
+
+	"github.com/INFURA/go-ethlibs/abi"
+	"github.com/INFURA/go-ethlibs/eth"
+)
+
+// Options describes the binding to generate.
+type Options struct {
+	// Package is the name of the Go package of the generated file.
+	Package string
+
+	// Type is the Go type name of the contract binding, e.g. Token.
+	Type string
+
+	// ABI is the JSON ABI of the contract.
+	ABI string
+
+	// Bytecode is the optional hexadecimal creation bytecode of the contract, when supplied a function returning the
+	// contract creation transaction is generated.
+	Bytecode string
+}
+
+// locals are the identifiers used by the generated functions, which parameters can't be named after.
+var locals = map[string]bool{
+	"abi": true, "big": true, "context": true, "errors": true, "eth": true, "node": true,
+	"ctx": true, "err": true, "m": true, "values": true, "out": true, "input": true,
+	"e": true, "i": true, "topics": true, "event": true, "log": true, "_c": true, "to": true,
+}
+
+// reserved are the names used by the generated binding type which functions can't be named after.
+var reserved = map[string]bool{
+	"Address": true,
+	"Client":  true,
+	"From":    true,
+	"Block":   true,
+}
+
+type (
+	contract struct {
+		Package   string
+		Type      string
+		ABIVar    string
+		ABI       string
+		Bytecode  string
+		Deploy    *method
+		Calls     []method
+		Transacts []method
+		Events    []event
+		Structs   []*goStruct
+	}
+
+	param struct {
+		Name string
+		Type string
+	}
+
+	field struct {
+		Name string
+		Type string
+		Tag  string
+	}
+
+	method struct {
+		Name       string
+		Signature  string
+		Mutability string
+		Params     []param
+
+		// Output is the Go type of a single output, or OutputStruct the name of the type holding multiple outputs.
+		Output       string
+		OutputStruct *goStruct
+	}
+
+	event struct {
+		Name      string
+		Signature string
+		Struct    *goStruct
+		Raw       string
+		Indexed   []param
+	}
+
+	goStruct struct {
+		Name   string
+		Fields []field
+		key    string
+	}
+)
+
+// scope is the set of identifiers declared in the same Go scope, such as the methods of the binding, the fields of a
+// struct or the parameters of a function.
+type scope map[string]bool
+
+// unique returns name, or name followed by a number if it's already declared in the scope, and declares it.
+func (s scope) unique(name string) string {
+	candidate := name
+	for i := 1; s[candidate]; i++ {
+		candidate = name + strconv.Itoa(i)
+	}
+
+	s[candidate] = true
+	return candidate
+}
+
+// generator keeps track of the Go types generated for tuples and the identifiers in use.
+type generator struct {
+	contract *contract
+	structs  map[string]*goStruct
+	names    map[string]bool
+}
+
+// Generate returns the formatted Go source code of the binding.
+func Generate(opts Options) ([]byte, error) {
+	if !token.IsIdentifier(opts.Package) {
+		return nil, errors.Errorf("invalid package name %q", opts.Package)
+	}
+
+	if !token.IsIdentifier(opts.Type) || !token.IsExported(opts.Type) {
+		return nil, errors.Errorf("invalid type name %q, it must be an exported identifier", opts.Type)
+	}
+
+	parsed, err := abi.Parse([]byte(opts.ABI))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not parse ABI")
+	}
+
+	// embed the compact JSON array, even when reading it from a build artifact
+	raw, err := json.Marshal(abiJSON(parsed))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not encode ABI")
+	}
+
+	c := contract{
+		Package: opts.Package,
+		Type:    opts.Type,
+		ABIVar:  "parsed" + opts.Type + "ABI",
+		ABI:     strconv.Quote(string(raw)),
+	}
+
+	g := generator{
+		contract: &c,
+		structs:  map[string]*goStruct{},
+		names:    map[string]bool{opts.Type: true, "New" + opts.Type: true, c.ABIVar: true, opts.Type + "ABI": true},
+	}
+
+	if opts.Bytecode != "" {
+		bytecode := strings.TrimSpace(opts.Bytecode)
+		if !strings.HasPrefix(bytecode, "0x") {
+			bytecode = "0x" + bytecode
+		}
+
+		d, err := eth.NewData(bytecode)
+		if err != nil || len(d.String())%2 != 0 {
+			return nil, errors.New("invalid bytecode, it must be a hexadecimal string")
+		}
+
+		c.Bytecode = strconv.Quote(d.String())
+		g.names["Deploy"+opts.Type] = true
+		g.names[opts.Type+"Bin"] = true
+
+		deploy := method{Name: "Deploy" + opts.Type}
+		if parsed.Constructor != nil {
+			deploy.Params = g.params(parsed.Constructor.Inputs)
+		}
+		c.Deploy = &deploy
+	}
+
+	// the functions and the Filter and Parse functions of events are all methods of the binding type
+	methods := scope{}
+	for i := range parsed.Methods {
+		m := &parsed.Methods[i]
+		gm := method{
+			Name:       methods.unique(methodName(m.Name, "Method")),
+			Signature:  m.Signature(),
+			Mutability: m.StateMutability,
+			Params:     g.params(m.Inputs),
+		}
+
+		if !m.IsConstant() {
+			c.Transacts = append(c.Transacts, gm)
+			continue
+		}
+
+		switch len(m.Outputs) {
+		case 0:
+		case 1:
+			gm.Output = g.goType(&m.Outputs[0].Type, m.Outputs[0].InternalType)
+		default:
+			gm.OutputStruct = &goStruct{
+				Name:   g.unique(opts.Type + gm.Name + "Output"),
+				Fields: g.fields(m.Outputs),
+			}
+		}
+		c.Calls = append(c.Calls, gm)
+	}
+
+	for i := range parsed.Events {
+		e := &parsed.Events[i]
+		name := eventName(methods, methodName(e.Name, "Event"))
+		ge := event{
+			Name:      name,
+			Signature: e.Signature(),
+			Struct:    &goStruct{Name: g.unique(opts.Type + name)},
+		}
+
+		fields, indexed := scope{}, scope{}
+		for j := range e.Inputs {
+			in := &e.Inputs[j]
+			f := field{Name: fields.unique(fieldName(in.Name, j)), Tag: tag(in.Name, j)}
+			if in.Indexed && hashed(&in.Type) {
+				f.Type = "eth.Hash"
+			} else {
+				f.Type = g.goType(&in.Type, in.InternalType)
+			}
+			ge.Struct.Fields = append(ge.Struct.Fields, f)
+
+			if in.Indexed {
+				ge.Indexed = append(ge.Indexed, param{Name: paramName(indexed, in.Name, j), Type: g.goType(&in.Type, in.InternalType)})
+			}
+		}
+		ge.Raw = fields.unique("Raw")
+		ge.Struct.Fields = append(ge.Struct.Fields, field{Name: ge.Raw, Type: "eth.Log", Tag: `abi:"-"`})
+
+		c.Events = append(c.Events, ge)
+	}
+
+	buf := bytes.Buffer{}
+	if err := bindingTemplate.Execute(&buf, &c); err != nil {
+		return nil, errors.Wrap(err, "could not generate binding")
+	}
+
+	formatted, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, errors.Wrap(err, "could not format binding")
+	}
+
+	return formatted, nil
+}
+
+// abiJSON returns the entries of the ABI in the same form as the JSON ABI emitted by solc.
+func abiJSON(a *abi.ABI) []map[string]interface{} {
+	var entries []map[string]interface{}
+	method := func(m *abi.Method) map[string]interface{} {
+		entry := map[string]interface{}{"type": m.Type, "stateMutability": m.StateMutability}
+		if m.Type == "function" {
+			entry["name"] = m.Name
+			entry["outputs"] = nonNil(m.Outputs)
+		}
+		if m.Type != "fallback" && m.Type != "receive" {
+			entry["inputs"] = nonNil(m.Inputs)
+		}
+		return entry
+	}
+
+	if a.Constructor != nil {
+		entries = append(entries, method(a.Constructor))
+	}
+	if a.Fallback != nil {
+		entries = append(entries, method(a.Fallback))
+	}
+	if a.Receive != nil {
+		entries = append(entries, method(a.Receive))
+	}
+	for i := range a.Methods {
+		entries = append(entries, method(&a.Methods[i]))
+	}
+	for i := range a.Events {
+		e := &a.Events[i]
+		entries = append(entries, map[string]interface{}{"type": "event", "name": e.Name, "inputs": nonNil(e.Inputs), "anonymous": e.Anonymous})
+	}
+	for i := range a.Errors {
+		e := &a.Errors[i]
+		entries = append(entries, map[string]interface{}{"type": "error", "name": e.Name, "inputs": nonNil(e.Inputs)})
+	}
+
+	if entries == nil {
+		return []map[string]interface{}{}
+	}
+
+	return entries
+}
+
+func nonNil(args abi.Arguments) abi.Arguments {
+	if args == nil {
+		return abi.Arguments{}
+	}
+
+	return args
+}
+
+// params returns the Go parameters of the arguments.
+func (g *generator) params(args abi.Arguments) []param {
+	names := scope{}
+	params := make([]param, len(args))
+	for i := range args {
+		params[i] = param{Name: paramName(names, args[i].Name, i), Type: g.goType(&args[i].Type, args[i].InternalType)}
+	}
+
+	return params
+}
+
+// fields returns the Go struct fields of the arguments, tagged to match the argument names.
+func (g *generator) fields(args abi.Arguments) []field {
+	names := scope{}
+	fields := make([]field, len(args))
+	for i := range args {
+		fields[i] = field{
+			Name: names.unique(fieldName(args[i].Name, i)),
+			Type: g.goType(&args[i].Type, args[i].InternalType),
+			Tag:  tag(args[i].Name, i),
+		}
+	}
+
+	return fields
+}
+
+// goType returns the Go type used for an ABI type, generating struct types for tuples.
+func (g *generator) goType(t *abi.Type, internalType string) string {
+	switch t.Kind {
+	case abi.KindUint, abi.KindInt:
+		prefix := "uint"
+		if t.Kind == abi.KindInt {
+			prefix = "int"
+		}
+		switch t.Size {
+		case 8, 16, 32, 64:
+			return prefix + strconv.Itoa(t.Size)
+		}
+		return "*big.Int"
+	case abi.KindAddress:
+		return "eth.Address"
+	case abi.KindBool:
+		return "bool"
+	case abi.KindString:
+		return "string"
+	case abi.KindBytes:
+		return "[]byte"
+	case abi.KindFixedBytes, abi.KindFunction:
+		return "[" + strconv.Itoa(t.Size) + "]byte"
+	case abi.KindSlice:
+		return "[]" + g.goType(t.Elem, trimArraySuffix(internalType))
+	case abi.KindArray:
+		return "[" + strconv.Itoa(t.Size) + "]" + g.goType(t.Elem, trimArraySuffix(internalType))
+	case abi.KindTuple:
+		return g.tupleStruct(t, internalType).Name
+	default:
+		return "interface{}"
+	}
+}
+
+// tupleStruct returns the struct generated for a tuple, named after the Solidity struct when the ABI includes the
+// internal types.  Tuples with the same name and components share a struct.
+func (g *generator) tupleStruct(t *abi.Type, internalType string) *goStruct {
+	name := ""
+	if strings.HasPrefix(internalType, "struct ") {
+		name = strings.TrimPrefix(internalType, "struct ")
+		if dot := strings.LastIndex(name, "."); dot != -1 {
+			name = name[dot+1:]
+		}
+		name = exported(name, "")
+	}
+	if name == "" {
+		name = g.contract.Type + "Tuple"
+	}
+
+	key := name + ":"
+	for i := range t.Components {
+		key += t.Components[i].Name + " " + t.Components[i].Type.String() + ","
+	}
+
+	if s, ok := g.structs[key]; ok {
+		return s
+	}
+
+	s := &goStruct{Name: g.unique(name), key: key}
+	g.structs[key] = s
+	s.Fields = g.fields(t.Components)
+	g.contract.Structs = append(g.contract.Structs, s)
+	return s
+}
+
+// unique returns name, or name followed by a number if it's already in use.
+func (g *generator) unique(name string) string {
+	candidate := name
+	for i := 0; g.names[candidate]; i++ {
+		candidate = name + strconv.Itoa(i)
+	}
+
+	g.names[candidate] = true
+	return candidate
+}
+
+// eventName returns name, or name followed by a number if the Filter or Parse methods of the event are already declared
+// in methods, and declares them.
+func eventName(methods scope, name string) string {
+	candidate := name
+	for i := 1; methods["Filter"+candidate] || methods["Parse"+candidate]; i++ {
+		candidate = name + strconv.Itoa(i)
+	}
+
+	methods["Filter"+candidate] = true
+	methods["Parse"+candidate] = true
+	return candidate
+}
+
+// hashed returns true for the types of indexed event arguments that are stored as a hash.
+func hashed(t *abi.Type) bool {
+	return t.IsDynamic() || t.Kind == abi.KindArray || t.Kind == abi.KindTuple
+}
+
+// exported converts a Solidity identifier into an exported Go identifier.
+func exported(name string, fallback string) string {
+	trimmed := strings.TrimLeft(name, "_")
+	if trimmed == "" {
+		return fallback
+	}
+
+	runes := []rune(trimmed)
+	runes[0] = unicode.ToUpper(runes[0])
+	return string(runes)
+}
+
+// methodName returns the exported name of a function or event, which can't be one of the binding's fields.
+func methodName(name string, fallback string) string {
+	exported := exported(name, fallback)
+	if reserved[exported] {
+		return exported + "_"
+	}
+
+	return exported
+}
+
+// fieldName returns the struct field name of an argument.
+func fieldName(name string, i int) string {
+	return exported(name, "Arg"+strconv.Itoa(i))
+}
+
+// paramName returns the parameter name of an argument, avoiding Go keywords, the names used by the generated code and
+// the other parameters declared in params.
+func paramName(params scope, name string, i int) string {
+	if name == "" {
+		name = "arg" + strconv.Itoa(i)
+	} else if token.IsKeyword(name) || locals[name] || strings.HasSuffix(name, "Values") {
+		name += "_"
+	}
+
+	return params.unique(name)
+}
+
+// tag returns the struct tag matching a field to its argument.
+func tag(name string, i int) string {
+	if name == "" {
+		name = "arg" + strconv.Itoa(i)
+	}
+
+	return `abi:"` + name + `"`
+}
+
+// trimArraySuffix removes the last array suffix from an internal type, e.g. struct Order[] becomes struct Order.
+func trimArraySuffix(internalType string) string {
+	if open := strings.LastIndex(internalType, "["); open != -1 && strings.HasSuffix(internalType, "]") {
+		return internalType[:open]
+	}
+
+	return internalType
+}
+
+var bindingTemplate = template.Must(template.New("binding").Funcs(template.FuncMap{
+	"args": func(params []param) string {
+		names := make([]string, len(params))
+		for i := range params {
+			names[i] = params[i].Name
+		}
+		return strings.Join(names, ", ")
+	},
+	"decl": func(params []param) string {
+		decls := make([]string, len(params))
+		for i := range params {
+			decls[i] = params[i].Name + " " + params[i].Type
+		}
+		return strings.Join(decls, ", ")
+	},
+	"filterDecl": func(params []param) string {
+		decls := make([]string, len(params))
+		for i := range params {
+			decls[i] = params[i].Name + " []" + params[i].Type
+		}
+		return strings.Join(decls, ", ")
+	},
+	"backquote": func(tag string) string {
+		return "`" + tag + "`"
+	},
+}).Parse(templateSource))
@@ -0,0 +1,108 @@
+package bind_test
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/INFURA/go-ethlibs/abi/bind"
+)
+
+func TestGenerate(t *testing.T) {
+	abiJSON, err := ioutil.ReadFile("internal/token/Token.abi")
+	require.NoError(t, err)
+
+	bytecode, err := ioutil.ReadFile("internal/token/Token.bin")
+	require.NoError(t, err)
+
+	expected, err := ioutil.ReadFile("internal/token/token.go")
+	require.NoError(t, err)
+
+	code, err := bind.Generate(bind.Options{
+		Package:  "token",
+		Type:     "Token",
+		ABI:      string(abiJSON),
+		Bytecode: string(bytecode),
+	})
+	require.NoError(t, err)
+	require.Equal(t, string(expected), string(code), "run go generate ./abi/bind/... to update the example binding")
+}
+
+func TestGenerate_Collisions(t *testing.T) {
+	// names that collide once converted to Go identifiers are numbered within their scope, and the example binding
+	// checks that the generated code compiles
+	abiJSON, err := ioutil.ReadFile("internal/names/Names.abi")
+	require.NoError(t, err)
+
+	expected, err := ioutil.ReadFile("internal/names/names.go")
+	require.NoError(t, err)
+
+	code, err := bind.Generate(bind.Options{Package: "names", Type: "Names", ABI: string(abiJSON)})
+	require.NoError(t, err)
+	require.Equal(t, string(expected), string(code), "run go generate ./abi/bind/... to update the example binding")
+
+	src := string(code)
+	// a function named after the Filter method of an event
+	require.Contains(t, src, "func (_c *Names) FilterTransfer() (*eth.Transaction, error)")
+	require.Contains(t, src, "func (_c *Names) FilterTransfer2(from []eth.Address, _from []eth.Address) (*eth.LogFilter, error)")
+	require.Contains(t, src, "func (_c *Names) ParseTransfer2(log eth.Log) (*NamesTransfer2, error)")
+	// event arguments differing by leading underscores
+	require.Contains(t, src, "From1 eth.Address `abi:\"_from\"`")
+	// outputs differing by case
+	require.Contains(t, src, "A1 *big.Int `abi:\"A\"`")
+	// a parameter named after the renamed form of another
+	require.Contains(t, src, "func (_c *Names) Send(to_ eth.Address, to_1 eth.Address) (*eth.Transaction, error)")
+}
+
+func TestGenerate_Names(t *testing.T) {
+	code, err := bind.Generate(bind.Options{
+		Package: "registry",
+		Type:    "Registry",
+		ABI: `[
+			{"type":"function","name":"address","stateMutability":"view","inputs":[{"name":"type","type":"string"}],"outputs":[{"name":"","type":"address"}]},
+			{"type":"function","name":"set","stateMutability":"nonpayable","inputs":[{"name":"","type":"bytes32"},{"name":"_value","type":"int24"}],"outputs":[]},
+			{"type":"function","name":"setOwner","stateMutability":"nonpayable","inputs":[{"name":"_c","type":"address"},{"name":"to","type":"address"}],"outputs":[]},
+			{"type":"function","name":"pair","stateMutability":"pure","inputs":[],"outputs":[{"name":"","type":"tuple","components":[{"name":"a","type":"uint256"},{"name":"b","type":"bytes"}]}]},
+			{"type":"event","name":"Set","anonymous":false,"inputs":[{"name":"raw","type":"bytes32","indexed":true}]}
+		]`,
+	})
+	require.NoError(t, err)
+
+	src := string(code)
+	require.NotContains(t, src, "RegistryBin")
+	require.NotContains(t, src, "func DeployRegistry")
+	require.Contains(t, src, "func (_c *Registry) Address_(ctx context.Context, type_ string) (eth.Address, error)")
+	require.Contains(t, src, "func (_c *Registry) Set(arg0 [32]byte, _value *big.Int) (*eth.Transaction, error)")
+	require.Contains(t, src, "func (_c *Registry) SetOwner(_c_ eth.Address, to_ eth.Address) (*eth.Transaction, error)")
+	require.Contains(t, src, "func (_c *Registry) Pair(ctx context.Context) (RegistryTuple, error)")
+	require.Contains(t, src, "event.Raw1 = log")
+	require.True(t, strings.HasPrefix(src, "// Code generated by abigen. DO NOT EDIT.\n"))
+}
+
+func TestGenerate_Invalid(t *testing.T) {
+	valid := bind.Options{Package: "token", Type: "Token", ABI: `[]`}
+	_, err := bind.Generate(valid)
+	require.NoError(t, err)
+
+	invalid := valid
+	invalid.Package = "to-ken"
+	_, err = bind.Generate(invalid)
+	require.Error(t, err)
+
+	invalid = valid
+	invalid.Type = "token"
+	_, err = bind.Generate(invalid)
+	require.Error(t, err)
+
+	invalid = valid
+	invalid.ABI = `[{"type":"function","name":"f","inputs":[{"name":"x","type":"uint7"}]}]`
+	_, err = bind.Generate(invalid)
+	require.Error(t, err)
+
+	invalid = valid
+	invalid.Bytecode = "0x60zz"
+	_, err = bind.Generate(invalid)
+	require.Error(t, err)
+}
@@ -0,0 +1,5 @@
+// Package names is an example binding generated by abigen from an ABI whose names collide once converted to Go
+// identifiers, which is used to test the generator.
+package names
+
+//go:generate go run ../../../../cmd/abigen -abi Names.abi -pkg names -type Names -out names.go
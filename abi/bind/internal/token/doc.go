@@ -0,0 +1,4 @@
+// Package token is an example binding generated by abigen, which is used to test the generator.
+package token
+
+//go:generate go run ../../../../cmd/abigen -abi Token.abi -bin Token.bin -pkg token -type Token -out token.go
@@ -0,0 +1,192 @@
+package token_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/INFURA/go-ethlibs/abi"
+	"github.com/INFURA/go-ethlibs/abi/bind/internal/token"
+	"github.com/INFURA/go-ethlibs/eth"
+	"github.com/INFURA/go-ethlibs/node"
+	mock "github.com/INFURA/go-ethlibs/node/mocks"
+)
+
+var (
+	contract = *eth.MustAddress("0x21aB6c9fAC80C59D401b37cB43F81ea9DDe7Fe34")
+	owner    = *eth.MustAddress("0x9e44B7D42125b7bB4E809406ED5E1079FF500969")
+	parsed   = abi.MustParse(token.TokenABI)
+)
+
+// output returns the encoded output of a function.
+func output(t *testing.T, signature string, values ...interface{}) *eth.Data {
+	m, err := parsed.Method(signature)
+	require.NoError(t, err)
+
+	encoded, err := m.Outputs.Pack(values...)
+	require.NoError(t, err)
+
+	return eth.MustData(fmt.Sprintf("0x%x", encoded))
+}
+
+// input returns the encoded call of a function.
+func input(t *testing.T, signature string, args ...interface{}) eth.Input {
+	m, err := parsed.Method(signature)
+	require.NoError(t, err)
+
+	in, err := m.EncodeCall(args...)
+	require.NoError(t, err)
+
+	return *in
+}
+
+func TestToken_Calls(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	client := mock.NewMockClient(ctrl)
+	tok := token.NewToken(contract, client)
+	tok.From = owner
+
+	to := contract
+	latest := *eth.MustBlockNumberOrTag("latest")
+
+	client.EXPECT().
+		Call(ctx, eth.Transaction{From: owner, To: &to, Input: input(t, "name()")}, latest).
+		Return(output(t, "name()", "Token"), nil)
+	name, err := tok.Name(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "Token", name)
+
+	client.EXPECT().
+		Call(ctx, eth.Transaction{From: owner, To: &to, Input: input(t, "decimals()")}, latest).
+		Return(output(t, "decimals()", 18), nil)
+	decimals, err := tok.Decimals(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint8(18), decimals)
+
+	client.EXPECT().
+		Call(ctx, eth.Transaction{From: owner, To: &to, Input: input(t, "balanceOf(address)", owner)}, latest).
+		Return(output(t, "balanceOf(address)", 1000), nil)
+	balance, err := tok.BalanceOf(ctx, owner)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(1000), balance)
+
+	tag := [32]byte{1}
+	client.EXPECT().
+		Call(ctx, eth.Transaction{From: owner, To: &to, Input: input(t, "getOrder(uint64)", 7)}, latest).
+		Return(output(t, "getOrder(uint64)", []interface{}{owner, 5, [][32]byte{tag}}, true), nil)
+	order, err := tok.GetOrder(ctx, 7)
+	require.NoError(t, err)
+	require.Equal(t, &token.TokenGetOrderOutput{
+		Order:  token.Order{Maker: owner, Amount: big.NewInt(5), Tags: [][32]byte{tag}},
+		Filled: true,
+	}, order)
+}
+
+func TestToken_Call_Revert(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	client := mock.NewMockClient(ctrl)
+	tok := token.NewToken(contract, client)
+
+	e, err := parsed.Error("InsufficientBalance")
+	require.NoError(t, err)
+	args, err := e.Inputs.Pack(1, 2)
+	require.NoError(t, err)
+
+	data, err := json.Marshal(fmt.Sprintf("%s%x", e.Selector(), args))
+	require.NoError(t, err)
+
+	client.EXPECT().
+		Call(ctx, gomock.Any(), gomock.Any()).
+		Return(nil, &node.RPCError{Code: 3, Message: "execution reverted", Data: data})
+	_, err = tok.BalanceOf(ctx, owner)
+	require.Error(t, err)
+
+	revert, ok := err.(*abi.Revert)
+	require.True(t, ok)
+	require.Equal(t, "InsufficientBalance", revert.CustomError.Name)
+	require.Equal(t, "execution reverted: InsufficientBalance(available: 1, required: 2)", revert.Error())
+
+	client.EXPECT().
+		Call(ctx, gomock.Any(), gomock.Any()).
+		Return(nil, &node.RPCError{Code: -32000, Message: "header not found"})
+	_, err = tok.BalanceOf(ctx, owner)
+	require.EqualError(t, err, "could not call balanceOf(address): header not found (code -32000)")
+}
+
+func TestToken_Transactions(t *testing.T) {
+	tok := token.NewToken(contract, nil)
+	to := contract
+
+	tx, err := tok.Transfer(owner, big.NewInt(10))
+	require.NoError(t, err)
+	require.Equal(t, &eth.Transaction{To: &to, Input: input(t, "transfer(address,uint256)", owner, 10)}, tx)
+
+	tx, err = tok.Transfer1(owner, big.NewInt(10), []byte{0xca, 0xfe})
+	require.NoError(t, err)
+	require.Equal(t, input(t, "transfer(address,uint256,bytes)", owner, 10, []byte{0xca, 0xfe}), tx.Input)
+
+	orders := []token.Order{{Maker: owner, Amount: big.NewInt(1), Tags: [][32]byte{}}}
+	tx, err = tok.PlaceOrders(orders)
+	require.NoError(t, err)
+	require.Equal(t, input(t, "placeOrders((address,uint256,bytes32[])[])", orders), tx.Input)
+
+	_, err = tok.Transfer(owner, nil)
+	require.Error(t, err)
+
+	deploy, err := token.DeployToken("Token", big.NewInt(1000))
+	require.NoError(t, err)
+	require.Nil(t, deploy.To)
+
+	expected, err := parsed.EncodeDeploy(eth.Data(token.TokenBin), "Token", 1000)
+	require.NoError(t, err)
+	require.Equal(t, *expected, deploy.Input)
+}
+
+func TestToken_Events(t *testing.T) {
+	tok := token.NewToken(contract, nil)
+	transfer, err := parsed.Event("Transfer")
+	require.NoError(t, err)
+
+	filter, err := tok.FilterTransfer(nil, []eth.Address{owner})
+	require.NoError(t, err)
+	require.Equal(t, &eth.LogFilter{
+		Address: []eth.Address{contract},
+		Topics: [][]eth.Topic{
+			{transfer.Topic()},
+			nil,
+			{"0x0000000000000000000000009e44b7d42125b7bb4e809406ed5e1079ff500969"},
+		},
+	}, filter)
+
+	filter, err = tok.FilterMemo([]string{"abc"})
+	require.NoError(t, err)
+	require.Equal(t, eth.Topic("0x4e03657aea45a94fc7d47ba826c8d667c0d1e6e33a64a036ec44f58fa12d6c45"), filter.Topics[1][0])
+
+	log := eth.Log{
+		Address: contract,
+		Topics: []eth.Topic{
+			transfer.Topic(),
+			"0x0000000000000000000000009e44b7d42125b7bb4e809406ed5e1079ff500969",
+			"0x00000000000000000000000021ab6c9fac80c59d401b37cb43f81ea9dde7fe34",
+		},
+		Data: *eth.MustData("0x00000000000000000000000000000000000000000000000000000000000003e8"),
+	}
+
+	parsedLog, err := tok.ParseTransfer(log)
+	require.NoError(t, err)
+	require.Equal(t, &token.TokenTransfer{From: owner, To: contract, Value: big.NewInt(1000), Raw: log}, parsedLog)
+
+	_, err = tok.ParseMemo(log)
+	require.Error(t, err)
+}
@@ -0,0 +1,208 @@
+package bind
+
+// templateSource is the template of the generated bindings, executed with a *contract.
+const templateSource = `// Code generated by abigen. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/pkg/errors"
+
+	"github.com/INFURA/go-ethlibs/abi"
+	"github.com/INFURA/go-ethlibs/eth"
+	"github.com/INFURA/go-ethlibs/node"
+)
+
+// {{.Type}}ABI is the JSON ABI of the {{.Type}} contract.
+const {{.Type}}ABI = {{.ABI}}
+{{if .Bytecode}}
+// {{.Type}}Bin is the creation bytecode of the {{.Type}} contract.
+const {{.Type}}Bin = {{.Bytecode}}
+{{end}}
+var {{.ABIVar}} = abi.MustParse({{.Type}}ABI)
+
+// reference imports that may be unused by the contract's functions and events
+var (
+	_ = big.NewInt
+	_ = context.Background
+)
+{{range .Structs}}
+// {{.Name}} is a tuple of the {{$.Type}} contract.
+type {{.Name}} struct {
+{{- range .Fields}}
+	{{.Name}} {{.Type}} {{backquote .Tag}}
+{{- end}}
+}
+{{end}}
+// {{.Type}} is a binding of the {{.Type}} contract deployed at Address.
+type {{.Type}} struct {
+	Address eth.Address
+	Client  node.Client
+
+	// From is the optional sender of calls.
+	From eth.Address
+
+	// Block is the block calls are made at.
+	Block eth.BlockNumberOrTag
+}
+
+// New{{.Type}} returns a binding of the {{.Type}} contract deployed at address, making calls at the latest block.
+func New{{.Type}}(address eth.Address, client node.Client) *{{.Type}} {
+	return &{{.Type}}{
+		Address: address,
+		Client:  client,
+		Block:   *eth.MustBlockNumberOrTag(eth.TagLatest.String()),
+	}
+}
+{{with .Deploy}}
+// {{.Name}} returns the unsigned transaction deploying the {{$.Type}} contract.
+func {{.Name}}({{decl .Params}}) (*eth.Transaction, error) {
+	input, err := {{$.ABIVar}}.EncodeDeploy(eth.Data({{$.Type}}Bin){{range .Params}}, {{.Name}}{{end}})
+	if err != nil {
+		return nil, err
+	}
+
+	return &eth.Transaction{Input: *input}, nil
+}
+{{end}}
+// call makes an eth_call of a function and decodes its output, decoding the revert data of failed calls.
+func (_c *{{.Type}}) call(ctx context.Context, signature string, args ...interface{}) (*abi.Method, []interface{}, error) {
+	m, err := {{.ABIVar}}.Method(signature)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	input, err := m.EncodeCall(args...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	to := _c.Address
+	data, err := _c.Client.Call(ctx, eth.Transaction{From: _c.From, To: &to, Input: *input}, _c.Block)
+	if err != nil {
+		if rpcErr, ok := err.(*node.RPCError); ok {
+			if revert, revertErr := rpcErr.Revert({{.ABIVar}}); revertErr == nil {
+				return nil, nil, revert
+			}
+		}
+		return nil, nil, errors.Wrapf(err, "could not call %s", signature)
+	}
+
+	values, err := m.DecodeOutput(data.Bytes())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return m, values, nil
+}
+
+// transact returns the unsigned transaction calling a function.
+func (_c *{{.Type}}) transact(signature string, args ...interface{}) (*eth.Transaction, error) {
+	m, err := {{.ABIVar}}.Method(signature)
+	if err != nil {
+		return nil, err
+	}
+
+	input, err := m.EncodeCall(args...)
+	if err != nil {
+		return nil, err
+	}
+
+	to := _c.Address
+	return &eth.Transaction{From: _c.From, To: &to, Input: *input}, nil
+}
+{{range .Calls}}{{if .OutputStruct}}
+// {{.OutputStruct.Name}} is the output of {{.Signature}}.
+type {{.OutputStruct.Name}} struct {
+{{- range .OutputStruct.Fields}}
+	{{.Name}} {{.Type}} {{backquote .Tag}}
+{{- end}}
+}
+{{end}}
+// {{.Name}} calls {{.Signature}}.
+func (_c *{{$.Type}}) {{.Name}}(ctx context.Context{{range .Params}}, {{.Name}} {{.Type}}{{end}}) (
+{{- if .OutputStruct}}*{{.OutputStruct.Name}}, {{else if .Output}}{{.Output}}, {{end}}error) {
+{{- if .OutputStruct}}
+	m, values, err := _c.call(ctx, "{{.Signature}}"{{range .Params}}, {{.Name}}{{end}})
+	if err != nil {
+		return nil, err
+	}
+
+	out := {{.OutputStruct.Name}}{}
+	if err := m.Outputs.Copy(&out, values); err != nil {
+		return nil, err
+	}
+
+	return &out, nil
+{{- else if .Output}}
+	var out {{.Output}}
+	m, values, err := _c.call(ctx, "{{.Signature}}"{{range .Params}}, {{.Name}}{{end}})
+	if err != nil {
+		return out, err
+	}
+
+	err = abi.Assign(&m.Outputs[0].Type, &out, values[0])
+	return out, err
+{{- else}}
+	_, _, err := _c.call(ctx, "{{.Signature}}"{{range .Params}}, {{.Name}}{{end}})
+	return err
+{{- end}}
+}
+{{end}}{{range .Transacts}}
+// {{.Name}} returns the unsigned transaction calling {{.Signature}}.
+{{- if eq .Mutability "payable"}}
+// Set its Value to send ether with the call.
+{{- end}}
+func (_c *{{$.Type}}) {{.Name}}({{decl .Params}}) (*eth.Transaction, error) {
+	return _c.transact("{{.Signature}}"{{range .Params}}, {{.Name}}{{end}})
+}
+{{end}}{{range .Events}}
+// {{.Struct.Name}} is the {{.Signature}} event.
+type {{.Struct.Name}} struct {
+{{- range .Struct.Fields}}
+	{{.Name}} {{.Type}} {{backquote .Tag}}
+{{- end}}
+}
+
+// Filter{{.Name}} returns a filter matching {{.Signature}} events emitted by the contract.
+{{- if .Indexed}}
+// Each indexed argument matches any of the listed values, or any value when the list is empty.
+{{- end}}
+func (_c *{{$.Type}}) Filter{{.Name}}({{filterDecl .Indexed}}) (*eth.LogFilter, error) {
+	e, err := {{$.ABIVar}}.Event("{{.Signature}}")
+	if err != nil {
+		return nil, err
+	}
+{{range .Indexed}}
+	{{.Name}}Values := make([]interface{}, len({{.Name}}))
+	for i := range {{.Name}} {
+		{{.Name}}Values[i] = {{.Name}}[i]
+	}
+{{end}}
+	topics, err := e.FilterTopics({{range $i, $p := .Indexed}}{{if $i}}, {{end}}{{$p.Name}}Values{{end}})
+	if err != nil {
+		return nil, err
+	}
+
+	return &eth.LogFilter{Address: []eth.Address{_c.Address}, Topics: topics}, nil
+}
+
+// Parse{{.Name}} decodes a {{.Signature}} event from a log.
+func (_c *{{$.Type}}) Parse{{.Name}}(log eth.Log) (*{{.Struct.Name}}, error) {
+	e, err := {{$.ABIVar}}.Event("{{.Signature}}")
+	if err != nil {
+		return nil, err
+	}
+
+	event := {{.Struct.Name}}{}
+	if err := e.DecodeLogInto(log, &event); err != nil {
+		return nil, err
+	}
+
+	event.{{.Raw}} = log
+	return &event, nil
+}
+{{end}}`
@@ -0,0 +1,151 @@
+package abi
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/INFURA/go-ethlibs/eth"
+)
+
+var (
+	// ErrNoCalldata is returned when decoding empty calldata, e.g. of a plain ether transfer.
+	ErrNoCalldata = errors.New("no calldata")
+
+	// ErrUnknownSelector is returned when no function matches the selector of the calldata.
+	ErrUnknownSelector = errors.New("unknown function selector")
+
+	// ErrMalformedCalldata is returned when the calldata is too short to hold a selector, or when the arguments
+	// can't be decoded as the inputs of the function matching the selector.
+	ErrMalformedCalldata = errors.New("malformed calldata")
+)
+
+// Call is a decoded call of a contract function.
+type Call struct {
+	Method *Method
+	Args   []interface{}
+}
+
+// DecodeInput decodes the arguments of a call of the function from calldata, which must start with its selector.
+func (m *Method) DecodeInput(input eth.Input) ([]interface{}, error) {
+	data, err := calldata(input)
+	if err != nil {
+		return nil, err
+	}
+
+	if selector := input.FunctionSelector(); !strings.EqualFold(selector.String(), m.Selector().String()) {
+		return nil, errors.Wrapf(ErrUnknownSelector, "selector %s does not match %s", selector, m.Signature())
+	}
+
+	values, err := m.Inputs.Unpack(data)
+	if err != nil {
+		return nil, errors.Wrapf(ErrMalformedCalldata, "could not decode arguments of %s: %s", m.Signature(), err)
+	}
+
+	return values, nil
+}
+
+// DecodeCall decodes calldata of a call to one of the ABI's functions.
+func (a *ABI) DecodeCall(input eth.Input) (*Call, error) {
+	return DecodeCall(input, a)
+}
+
+// DecodeCall decodes calldata using the first of the ABIs with a function matching its selector.  The cause of the
+// returned error, see errors.Cause, is ErrNoCalldata, ErrUnknownSelector or ErrMalformedCalldata when the calldata
+// can't be decoded.
+func DecodeCall(input eth.Input, abis ...*ABI) (*Call, error) {
+	if _, err := calldata(input); err != nil {
+		return nil, err
+	}
+
+	selector := input.FunctionSelector()
+	for _, a := range abis {
+		m, err := a.MethodBySelector(*selector)
+		if err != nil {
+			continue
+		}
+
+		args, err := m.DecodeInput(input)
+		if err != nil {
+			return nil, err
+		}
+
+		return &Call{Method: m, Args: args}, nil
+	}
+
+	return nil, errors.Wrapf(ErrUnknownSelector, "no function with selector %s", selector)
+}
+
+// DecodeTransaction decodes the calldata of a transaction using the ABIs, see DecodeCall.  Contract creation
+// transactions can't be decoded since their input is the contract initcode.
+func DecodeTransaction(tx *eth.Transaction, abis ...*ABI) (*Call, error) {
+	if tx.To == nil {
+		return nil, errors.New("cannot decode the input of a contract creation transaction")
+	}
+
+	return DecodeCall(tx.Input, abis...)
+}
+
+// calldata validates the input and returns the data following the selector.
+func calldata(input eth.Input) ([]byte, error) {
+	if _, err := eth.NewData(input.String()); err != nil {
+		return nil, errors.Wrapf(ErrMalformedCalldata, "invalid hex: %s", err)
+	}
+
+	switch {
+	case len(input) <= 2:
+		return nil, ErrNoCalldata
+	case len(input)%2 != 0:
+		return nil, errors.Wrap(ErrMalformedCalldata, "odd number of hex digits")
+	case len(input) < 10:
+		return nil, errors.Wrapf(ErrMalformedCalldata, "%d bytes are too short for a selector", (len(input)-2)/2)
+	}
+
+	return input.Bytes()[4:], nil
+}
+
+// Values returns the arguments of the call keyed by name, unnamed arguments are keyed by their position instead.
+func (c *Call) Values() map[string]interface{} {
+	return c.Method.Inputs.toMap(c.Args)
+}
+
+// String returns the call in a human-readable form, e.g. transfer(to: 0x21aB6c9f...fE34, amount: 1000).
+func (c *Call) String() string {
+	return c.Method.Name + "(" + formatArguments(c.Method.Inputs, c.Args) + ")"
+}
+
+type callJSON struct {
+	Name      string             `json:"name"`
+	Signature string             `json:"signature"`
+	Selector  eth.Data4          `json:"selector"`
+	Args      []callArgumentJSON `json:"args"`
+}
+
+type callArgumentJSON struct {
+	Name  string      `json:"name"`
+	Type  string      `json:"type"`
+	Value interface{} `json:"value"`
+}
+
+// MarshalJSON encodes the call as its name, signature, selector and arguments.  Integers are represented as
+// decimal strings since they can exceed the precision of JSON numbers, bytes as hexadecimal strings and tuples as
+// objects keyed by component name.
+func (c Call) MarshalJSON() ([]byte, error) {
+	args := make([]callArgumentJSON, len(c.Args))
+	for i := range c.Args {
+		in := &c.Method.Inputs[i]
+		args[i] = callArgumentJSON{
+			Name:  in.Name,
+			Type:  in.Type.String(),
+			Value: jsonValue(&in.Type, c.Args[i]),
+		}
+	}
+
+	return json.Marshal(&callJSON{
+		Name:      c.Method.Name,
+		Signature: c.Method.Signature(),
+		Selector:  c.Method.Selector(),
+		Args:      args,
+	})
+}
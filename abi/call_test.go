@@ -0,0 +1,105 @@
+package abi_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/INFURA/go-ethlibs/abi"
+	"github.com/INFURA/go-ethlibs/eth"
+)
+
+func TestDecodeTransaction(t *testing.T) {
+	token := abi.MustParse(erc20)
+	other := abi.MustParse(`[{"type":"function","name":"ping","inputs":[],"outputs":[]}]`)
+
+	tx := eth.Transaction{
+		To: eth.MustAddress("0x21ab6c9fac80c59d401b37cb43f81ea9dde7fe34"),
+		Input: *eth.MustInput("0xa9059cbb" +
+			"000000000000000000000000fe5854255eb1eb921525fa856a3947ed2412a1d7" +
+			"000000000000000000000000000000000000000000000000000000070560c8c0"),
+	}
+
+	call, err := abi.DecodeTransaction(&tx, other, token)
+	require.NoError(t, err)
+	require.Equal(t, "transfer", call.Method.Name)
+	require.Equal(t, map[string]interface{}{
+		"to":     *eth.MustAddress("0xfe5854255eb1eb921525fa856a3947ed2412a1d7"),
+		"amount": call.Args[1],
+	}, call.Values())
+	require.Equal(t, "transfer(to: 0xFE5854255eb1Eb921525fa856a3947Ed2412A1D7, amount: 30155000000)", call.String())
+
+	b, err := json.Marshal(call)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"name": "transfer",
+		"signature": "transfer(address,uint256)",
+		"selector": "0xa9059cbb",
+		"args": [
+			{"name": "to", "type": "address", "value": "0xfe5854255eb1eb921525fa856a3947ed2412a1d7"},
+			{"name": "amount", "type": "uint256", "value": "30155000000"}
+		]
+	}`, string(b))
+
+	tx.To = nil
+	_, err = abi.DecodeTransaction(&tx, token)
+	require.Error(t, err, "contract creation input can't be decoded")
+}
+
+func TestDecodeCall_Tuple(t *testing.T) {
+	token := abi.MustParse(erc20)
+	submit, err := token.Method("submit")
+	require.NoError(t, err)
+
+	input, err := submit.EncodeCall([]interface{}{eth.MustAddress("0x21ab6c9fac80c59d401b37cb43f81ea9dde7fe34"), []int{1, 2}})
+	require.NoError(t, err)
+
+	call, err := token.DecodeCall(*input)
+	require.NoError(t, err)
+	require.Equal(t, "submit(order: (maker: 0x21aB6c9fAC80C59D401b37cB43F81ea9DDe7Fe34, amounts: [1, 2]))", call.String())
+
+	b, err := json.Marshal(call)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"name": "submit",
+		"signature": "submit((address,uint256[]))",
+		"selector": "`+submit.Selector().String()+`",
+		"args": [
+			{"name": "order", "type": "(address,uint256[])", "value": {"maker": "0x21ab6c9fac80c59d401b37cb43f81ea9dde7fe34", "amounts": ["1", "2"]}}
+		]
+	}`, string(b))
+}
+
+func TestDecodeCall_Errors(t *testing.T) {
+	token := abi.MustParse(erc20)
+
+	tests := []struct {
+		Input string
+		Cause error
+	}{
+		{"0x", abi.ErrNoCalldata},
+		{"0xa9059c", abi.ErrMalformedCalldata},
+		{"0xa9059cb", abi.ErrMalformedCalldata},
+		{"0xa9059cbz", abi.ErrMalformedCalldata},
+		{"0x12345678", abi.ErrUnknownSelector},
+		// transfer with a truncated amount
+		{"0xa9059cbb000000000000000000000000fe5854255eb1eb921525fa856a3947ed2412a1d70000", abi.ErrMalformedCalldata},
+		// transfer with dirty address bits
+		{"0xa9059cbb" +
+			"ff0000000000000000000000fe5854255eb1eb921525fa856a3947ed2412a1d7" +
+			"000000000000000000000000000000000000000000000000000000070560c8c0", abi.ErrMalformedCalldata},
+	}
+
+	for _, tt := range tests {
+		_, err := token.DecodeCall(eth.Input(tt.Input))
+		require.Error(t, err, tt.Input)
+		require.Equal(t, tt.Cause, errors.Cause(err), tt.Input)
+	}
+
+	balanceOf, err := token.Method("balanceOf")
+	require.NoError(t, err)
+	_, err = balanceOf.DecodeInput(eth.Input("0xa9059cbb"))
+	require.Equal(t, abi.ErrUnknownSelector, errors.Cause(err))
+}
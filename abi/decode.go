@@ -0,0 +1,236 @@
+package abi
+
+import (
+	"encoding/hex"
+	"math/big"
+
+	"github.com/pkg/errors"
+
+	"github.com/INFURA/go-ethlibs/eth"
+)
+
+// Decode decodes a single ABI encoded value of type t, as encoded by Encode.  Values are returned as the following
+// Go types:
+//
+//   - uint<M> and int<M>: *big.Int
+//   - address: eth.Address
+//   - bool: bool
+//   - bytes<M>, function and bytes: eth.Data
+//   - string: string
+//   - arrays, slices and tuples: []interface{}
+//
+// Decoding is strict, integers that overflow their type, addresses and booleans with dirty high bits, bytes with
+// non-zero padding and offsets or lengths that point outside of the data are rejected.
+func Decode(t *Type, data []byte) (interface{}, error) {
+	args := Arguments{{Type: *t}}
+	values, err := decodeTuple(args, data, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return values[0], nil
+}
+
+// decodeTuple decodes a tuple of the arguments starting at data[start:].  Offsets of dynamic values are relative to
+// the start of the tuple.
+func decodeTuple(args Arguments, data []byte, start int) ([]interface{}, error) {
+	values := make([]interface{}, len(args))
+	pos := start
+	for i := range args {
+		t := &args[i].Type
+
+		var (
+			value interface{}
+			err   error
+		)
+
+		if t.IsDynamic() {
+			offset, err := readLength(data, pos)
+			if err != nil {
+				return nil, wrapArgument(err, &args[i], i)
+			}
+
+			if offset > len(data)-start {
+				return nil, wrapArgument(errors.Errorf("offset %d is out of bounds", offset), &args[i], i)
+			}
+
+			value, err = decodeValue(t, data, start+offset)
+			if err != nil {
+				return nil, wrapArgument(err, &args[i], i)
+			}
+		} else {
+			value, err = decodeValue(t, data, pos)
+			if err != nil {
+				return nil, wrapArgument(err, &args[i], i)
+			}
+		}
+
+		values[i] = value
+		pos += t.headSize()
+	}
+
+	return values, nil
+}
+
+// decodeValue decodes a value of type t starting at data[pos:].
+func decodeValue(t *Type, data []byte, pos int) (interface{}, error) {
+	switch t.Kind {
+	case KindUint, KindInt:
+		word, err := readWord(data, pos)
+		if err != nil {
+			return nil, err
+		}
+		return decodeInteger(t, word)
+	case KindAddress:
+		word, err := readWord(data, pos)
+		if err != nil {
+			return nil, err
+		}
+		if !isZero(word[:12]) {
+			return nil, errors.New("address has dirty high bits")
+		}
+		d := eth.Data20("0x" + hex.EncodeToString(word[12:]))
+		a := eth.Address(eth.ToChecksumAddress(d.String()))
+		return a, nil
+	case KindBool:
+		word, err := readWord(data, pos)
+		if err != nil {
+			return nil, err
+		}
+		if !isZero(word[:31]) || word[31] > 1 {
+			return nil, errors.New("invalid boolean value")
+		}
+		return word[31] == 1, nil
+	case KindFixedBytes, KindFunction:
+		word, err := readWord(data, pos)
+		if err != nil {
+			return nil, err
+		}
+		if !isZero(word[t.Size:]) {
+			return nil, errors.Errorf("%s has non-zero padding", t)
+		}
+		return eth.Data("0x" + hex.EncodeToString(word[:t.Size])), nil
+	case KindBytes, KindString:
+		b, err := readDynamicBytes(data, pos)
+		if err != nil {
+			return nil, err
+		}
+		if t.Kind == KindString {
+			return string(b), nil
+		}
+		return eth.Data("0x" + hex.EncodeToString(b)), nil
+	case KindArray:
+		return decodeElements(t.Elem, t.Size, data, pos)
+	case KindSlice:
+		n, err := readLength(data, pos)
+		if err != nil {
+			return nil, err
+		}
+		return decodeElements(t.Elem, n, data, pos+32)
+	case KindTuple:
+		return decodeTuple(t.Components, data, pos)
+	default:
+		return nil, errors.Errorf("unsupported type %s", t)
+	}
+}
+
+// decodeElements decodes n elements of an array or slice as a tuple starting at data[pos:].
+func decodeElements(elem *Type, n int, data []byte, pos int) ([]interface{}, error) {
+	// Make sure the data is large enough to hold the heads of every element before allocating anything, so that a
+	// huge length can't be used to exhaust memory.
+	if n > (len(data)-pos)/elem.headSize() {
+		return nil, errors.Errorf("%d elements of type %s are out of bounds", n, elem)
+	}
+
+	args := make(Arguments, n)
+	for i := range args {
+		args[i] = Argument{Type: *elem}
+	}
+
+	return decodeTuple(args, data, pos)
+}
+
+// decodeInteger decodes a 32 byte big-endian two's complement integer and checks that it fits in t.
+func decodeInteger(t *Type, word []byte) (*big.Int, error) {
+	i := new(big.Int).SetBytes(word)
+	if t.Kind == KindUint {
+		if i.BitLen() > t.Size {
+			return nil, errors.Errorf("value overflows %s", t)
+		}
+		return i, nil
+	}
+
+	if word[0]&0x80 != 0 {
+		i.Sub(i, twoTo256)
+	}
+
+	limit := new(big.Int).Lsh(bigOne, uint(t.Size-1))
+	if i.Cmp(new(big.Int).Neg(limit)) < 0 || i.Cmp(limit) >= 0 {
+		return nil, errors.Errorf("value overflows %s", t)
+	}
+
+	return i, nil
+}
+
+// readDynamicBytes reads the length prefixed contents of a bytes or string value starting at data[pos:].
+func readDynamicBytes(data []byte, pos int) ([]byte, error) {
+	n, err := readLength(data, pos)
+	if err != nil {
+		return nil, err
+	}
+
+	start := pos + 32
+	if n > len(data)-start {
+		return nil, errors.Errorf("length %d is out of bounds", n)
+	}
+
+	padded := (n + 31) / 32 * 32
+	if padded <= len(data)-start && !isZero(data[start+n:start+padded]) {
+		return nil, errors.New("bytes have non-zero padding")
+	}
+
+	return data[start : start+n], nil
+}
+
+// readWord returns the 32 byte word at data[pos:].
+func readWord(data []byte, pos int) ([]byte, error) {
+	if pos < 0 || pos > len(data)-32 {
+		return nil, errors.Errorf("not enough data to decode, need %d bytes but have %d", pos+32, len(data))
+	}
+
+	return data[pos : pos+32], nil
+}
+
+// readLength reads the word at data[pos:] as an offset or length, which must be small enough to index data.
+func readLength(data []byte, pos int) (int, error) {
+	word, err := readWord(data, pos)
+	if err != nil {
+		return 0, err
+	}
+
+	i := new(big.Int).SetBytes(word)
+	if !i.IsInt64() || i.Int64() > int64(len(data)) {
+		return 0, errors.Errorf("offset or length %s is out of bounds", i)
+	}
+
+	return int(i.Int64()), nil
+}
+
+func isZero(b []byte) bool {
+	for _, c := range b {
+		if c != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// wrapArgument adds the name or position of the argument to a decoding error.
+func wrapArgument(err error, arg *Argument, i int) error {
+	if arg.Name != "" {
+		return errors.Wrapf(err, "could not decode %s", arg.Name)
+	}
+
+	return errors.Wrapf(err, "could not decode value at position %d", i)
+}
@@ -0,0 +1,155 @@
+package abi_test
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/INFURA/go-ethlibs/abi"
+	"github.com/INFURA/go-ethlibs/eth"
+)
+
+func mustHex(t *testing.T, w ...string) []byte {
+	b, err := hex.DecodeString(strings.Join(w, ""))
+	require.NoError(t, err)
+	return b
+}
+
+func TestDecode(t *testing.T) {
+	{
+		v, err := abi.Decode(abi.MustType("int16"), mustHex(t, "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff8000"))
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(-32768), v)
+	}
+
+	{
+		v, err := abi.Decode(abi.MustType("bool"), mustHex(t, "0000000000000000000000000000000000000000000000000000000000000000"))
+		require.NoError(t, err)
+		require.Equal(t, false, v)
+	}
+
+	{
+		v, err := abi.Decode(abi.MustType("string"), mustHex(t,
+			"0000000000000000000000000000000000000000000000000000000000000020",
+			"0000000000000000000000000000000000000000000000000000000000000000",
+		))
+		require.NoError(t, err)
+		require.Equal(t, "", v)
+	}
+
+	{
+		v, err := abi.Decode(abi.MustType("bytes2[]"), mustHex(t,
+			"0000000000000000000000000000000000000000000000000000000000000020",
+			"0000000000000000000000000000000000000000000000000000000000000001",
+			"abcd000000000000000000000000000000000000000000000000000000000000",
+		))
+		require.NoError(t, err)
+		require.Equal(t, []interface{}{eth.Data("0xabcd")}, v)
+	}
+
+	{
+		args := abi.Arguments{
+			{Name: "owner", Type: *abi.MustType("address")},
+			{Type: *abi.MustType("uint256")},
+		}
+		m, err := args.UnpackIntoMap(mustHex(t,
+			"00000000000000000000000021ab6c9fac80c59d401b37cb43f81ea9dde7fe34",
+			"0000000000000000000000000000000000000000000000000000000000000001",
+		))
+		require.NoError(t, err)
+		require.Equal(t, map[string]interface{}{
+			"owner": *eth.MustAddress("0x21ab6c9fac80c59d401b37cb43f81ea9dde7fe34"),
+			"arg1":  big.NewInt(1),
+		}, m)
+	}
+}
+
+func TestDecode_Invalid(t *testing.T) {
+	tests := []struct {
+		Type string
+		Data []byte
+	}{
+		{"uint256", nil},
+		{"uint256", mustHex(t, "00")},
+		{"uint8", mustHex(t, "0000000000000000000000000000000000000000000000000000000000000100")},
+		{"int8", mustHex(t, "0000000000000000000000000000000000000000000000000000000000000080")},
+		{"int8", mustHex(t, "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f")},
+		{"address", mustHex(t, "01000000000000000000000021ab6c9fac80c59d401b37cb43f81ea9dde7fe34")},
+		{"bool", mustHex(t, "0000000000000000000000000000000000000000000000000000000000000002")},
+		{"bytes1", mustHex(t, "0101000000000000000000000000000000000000000000000000000000000000")},
+		// offset out of bounds
+		{"bytes", mustHex(t, "0000000000000000000000000000000000000000000000000000000000000040")},
+		// huge offset
+		{"bytes", mustHex(t, "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")},
+		// length out of bounds
+		{"bytes", mustHex(t,
+			"0000000000000000000000000000000000000000000000000000000000000020",
+			"0000000000000000000000000000000000000000000000000000000000000021",
+			"0000000000000000000000000000000000000000000000000000000000000000",
+		)},
+		// dirty padding
+		{"string", mustHex(t,
+			"0000000000000000000000000000000000000000000000000000000000000020",
+			"0000000000000000000000000000000000000000000000000000000000000001",
+			"6100000000000000000000000000000000000000000000000000000000000001",
+		)},
+		// too many elements for the data
+		{"uint256[]", mustHex(t,
+			"0000000000000000000000000000000000000000000000000000000000000020",
+			"00000000000000000000000000000000000000000000000000000000ffffffff",
+		)},
+		{"uint256[2]", mustHex(t, "0000000000000000000000000000000000000000000000000000000000000001")},
+	}
+
+	for _, tt := range tests {
+		_, err := abi.Decode(abi.MustType(tt.Type), tt.Data)
+		require.Error(t, err, "%s %x", tt.Type, tt.Data)
+	}
+}
+
+func TestArguments_UnpackInto(t *testing.T) {
+	var args abi.Arguments
+	err := json.Unmarshal([]byte(`[
+		{"name":"order","type":"tuple","components":[{"name":"maker","type":"address"},{"name":"amounts","type":"uint64[]"},{"name":"salt","type":"bytes32"}]},
+		{"name":"ok","type":"bool"}
+	]`), &args)
+	require.NoError(t, err)
+
+	maker := eth.MustAddress("0x21ab6c9fac80c59d401b37cb43f81ea9dde7fe34")
+	salt := eth.MustHash("0x0000000000000000000000000000000000000000000000000000000000000007")
+	data, err := args.Pack([]interface{}{maker, []int{1, 2}, salt}, true)
+	require.NoError(t, err)
+
+	type Order struct {
+		Maker   eth.Address
+		Amounts []uint64
+		Salt    eth.Hash
+	}
+
+	result := struct {
+		Order Order
+		OK    bool `abi:"ok"`
+	}{}
+
+	err = args.UnpackInto(data, &result)
+	require.NoError(t, err)
+	require.Equal(t, Order{Maker: *maker, Amounts: []uint64{1, 2}, Salt: *salt}, result.Order)
+	require.True(t, result.OK)
+
+	// values that don't fit the destination are rejected
+	small := struct {
+		Order struct {
+			Amounts []uint8
+			Salt    eth.Data4
+		}
+	}{}
+	err = args.UnpackInto(data, &small)
+	require.Error(t, err)
+
+	err = args.UnpackInto(data, result)
+	require.Error(t, err, "destination must be a pointer")
+}
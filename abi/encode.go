@@ -0,0 +1,360 @@
+package abi
+
+import (
+	"math/big"
+	"reflect"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/INFURA/go-ethlibs/eth"
+)
+
+var (
+	bigOne      = big.NewInt(1)
+	twoTo256    = new(big.Int).Lsh(bigOne, 256)
+	bigIntType  = reflect.TypeOf(big.Int{})
+	quantityTyp = reflect.TypeOf(eth.Quantity{})
+)
+
+// Encode ABI encodes a single value of type t the same way as Solidity's abi.encode, i.e. as a tuple with a single
+// element, so dynamic values are preceded by their offset.  The accepted Go types are:
+//
+//   - uint<M> and int<M>: *big.Int, eth.Quantity and all of the Go integer types
+//   - address: eth.Address, [20]byte, a 20 byte []byte or a hexadecimal string
+//   - bool: bool
+//   - bytes<M>, function and bytes: []byte, [M]byte, the eth.Data types, eth.Input or a hexadecimal string, the
+//     length of the value must match for bytes<M>
+//   - string: string
+//   - arrays and slices: any Go array or slice of accepted values
+//   - tuples: []interface{} with a value for each component, a map[string]interface{} keyed by component name,
+//     or a struct whose fields are matched to the components by an `abi:"name"` tag or case-insensitively by name
+//
+// Pointers are dereferenced.
+func Encode(t *Type, value interface{}) ([]byte, error) {
+	return encodeTuple(Arguments{{Type: *t}}, []interface{}{value})
+}
+
+func encode(t *Type, rv reflect.Value) ([]byte, error) {
+	rv = indirect(rv)
+	if !rv.IsValid() {
+		return nil, errors.Errorf("cannot encode nil as %s", t)
+	}
+
+	switch t.Kind {
+	case KindUint, KindInt:
+		i, err := toBig(rv)
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot encode %s", t)
+		}
+		return encodeInteger(t, i)
+	case KindAddress:
+		b, err := toAddress(rv)
+		if err != nil {
+			return nil, err
+		}
+		return leftPad(b), nil
+	case KindBool:
+		if rv.Kind() != reflect.Bool {
+			return nil, errors.Errorf("cannot encode %s as bool", rv.Type())
+		}
+		if rv.Bool() {
+			return leftPad([]byte{1}), nil
+		}
+		return leftPad(nil), nil
+	case KindFixedBytes, KindFunction:
+		b, err := toBytes(rv)
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot encode %s", t)
+		}
+		if len(b) != t.Size {
+			return nil, errors.Errorf("cannot encode %d bytes as %s", len(b), t)
+		}
+		return rightPad(b), nil
+	case KindBytes:
+		b, err := toBytes(rv)
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot encode %s", t)
+		}
+		return encodeDynamicBytes(b), nil
+	case KindString:
+		if rv.Kind() != reflect.String {
+			return nil, errors.Errorf("cannot encode %s as string", rv.Type())
+		}
+		return encodeDynamicBytes([]byte(rv.String())), nil
+	case KindArray, KindSlice:
+		if rv.Kind() != reflect.Array && rv.Kind() != reflect.Slice {
+			return nil, errors.Errorf("cannot encode %s as %s", rv.Type(), t)
+		}
+
+		n := rv.Len()
+		if t.Kind == KindArray && n != t.Size {
+			return nil, errors.Errorf("cannot encode %d elements as %s", n, t)
+		}
+
+		args := make(Arguments, n)
+		values := make([]reflect.Value, n)
+		for i := 0; i < n; i++ {
+			args[i] = Argument{Type: *t.Elem}
+			values[i] = rv.Index(i)
+		}
+
+		encoded, err := encodeValues(args, values)
+		if err != nil {
+			return nil, err
+		}
+
+		if t.Kind == KindSlice {
+			return append(leftPad(big.NewInt(int64(n)).Bytes()), encoded...), nil
+		}
+		return encoded, nil
+	case KindTuple:
+		values, err := tupleValues(t.Components, rv)
+		if err != nil {
+			return nil, err
+		}
+		return encodeValues(t.Components, values)
+	default:
+		return nil, errors.Errorf("unsupported type %s", t)
+	}
+}
+
+// encodeTuple encodes the values as a tuple of the arguments.
+func encodeTuple(args Arguments, values []interface{}) ([]byte, error) {
+	rvs := make([]reflect.Value, len(values))
+	for i := range values {
+		rvs[i] = reflect.ValueOf(values[i])
+	}
+
+	return encodeValues(args, rvs)
+}
+
+// encodeValues encodes the head of every value followed by the contents of the dynamic ones, which their heads
+// point to by their offset from the start of the tuple.
+func encodeValues(args Arguments, values []reflect.Value) ([]byte, error) {
+	headSize := 0
+	for i := range args {
+		headSize += args[i].Type.headSize()
+	}
+
+	head := make([]byte, 0, headSize)
+	var tail []byte
+	for i := range args {
+		encoded, err := encode(&args[i].Type, values[i])
+		if err != nil {
+			if args[i].Name != "" {
+				return nil, errors.Wrapf(err, "invalid value for %s", args[i].Name)
+			}
+			return nil, errors.Wrapf(err, "invalid value at position %d", i)
+		}
+
+		if args[i].Type.IsDynamic() {
+			offset := big.NewInt(int64(headSize + len(tail)))
+			head = append(head, leftPad(offset.Bytes())...)
+			tail = append(tail, encoded...)
+		} else {
+			head = append(head, encoded...)
+		}
+	}
+
+	return append(head, tail...), nil
+}
+
+// tupleValues returns the value of each tuple component from a slice, map or struct.
+func tupleValues(components []Argument, rv reflect.Value) ([]reflect.Value, error) {
+	values := make([]reflect.Value, len(components))
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		if rv.Len() != len(components) {
+			return nil, errors.Errorf("cannot encode %d values as a tuple of %d components", rv.Len(), len(components))
+		}
+		for i := range components {
+			values[i] = rv.Index(i)
+		}
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			return nil, errors.Errorf("cannot encode %s as a tuple", rv.Type())
+		}
+		for i := range components {
+			v := rv.MapIndex(reflect.ValueOf(components[i].key(i)).Convert(rv.Type().Key()))
+			if !v.IsValid() {
+				return nil, errors.Errorf("missing tuple component %s", components[i].key(i))
+			}
+			values[i] = v
+		}
+	case reflect.Struct:
+		for i := range components {
+			field, ok := structField(rv, components[i].key(i))
+			if !ok {
+				return nil, errors.Errorf("struct %s has no field for tuple component %s", rv.Type(), components[i].key(i))
+			}
+			values[i] = field
+		}
+	default:
+		return nil, errors.Errorf("cannot encode %s as a tuple", rv.Type())
+	}
+
+	return values, nil
+}
+
+// structField returns the field of a struct matching name, either by its `abi:"name"` tag or case-insensitively by
+// its name ignoring any leading underscores.
+func structField(rv reflect.Value, name string) (reflect.Value, bool) {
+	typ := rv.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		if tag, ok := typ.Field(i).Tag.Lookup("abi"); ok && tag == name && typ.Field(i).PkgPath == "" {
+			return rv.Field(i), true
+		}
+	}
+
+	normalized := strings.TrimLeft(name, "_")
+	for i := 0; i < typ.NumField(); i++ {
+		f := typ.Field(i)
+		if f.PkgPath != "" {
+			// unexported
+			continue
+		}
+		if _, ok := f.Tag.Lookup("abi"); ok {
+			continue
+		}
+		if strings.EqualFold(f.Name, normalized) {
+			return rv.Field(i), true
+		}
+	}
+
+	return reflect.Value{}, false
+}
+
+// encodeInteger encodes i as a 32 byte big-endian two's complement integer after checking that it fits in t.
+func encodeInteger(t *Type, i *big.Int) ([]byte, error) {
+	if t.Kind == KindUint {
+		if i.Sign() < 0 || i.BitLen() > t.Size {
+			return nil, errors.Errorf("value %s overflows %s", i, t)
+		}
+		return leftPad(i.Bytes()), nil
+	}
+
+	limit := new(big.Int).Lsh(bigOne, uint(t.Size-1))
+	min := new(big.Int).Neg(limit)
+	if i.Cmp(min) < 0 || i.Cmp(limit) >= 0 {
+		return nil, errors.Errorf("value %s overflows %s", i, t)
+	}
+
+	if i.Sign() < 0 {
+		return leftPad(new(big.Int).Add(twoTo256, i).Bytes()), nil
+	}
+	return leftPad(i.Bytes()), nil
+}
+
+// encodeDynamicBytes encodes the length of b followed by b padded to a multiple of 32 bytes.
+func encodeDynamicBytes(b []byte) []byte {
+	encoded := leftPad(big.NewInt(int64(len(b))).Bytes())
+	if len(b) == 0 {
+		return encoded
+	}
+
+	return append(encoded, rightPad(b)...)
+}
+
+// leftPad pads b with leading zeros up to 32 bytes.
+func leftPad(b []byte) []byte {
+	padded := make([]byte, 32)
+	copy(padded[32-len(b):], b)
+	return padded
+}
+
+// rightPad pads b with trailing zeros up to the next multiple of 32 bytes.
+func rightPad(b []byte) []byte {
+	padded := make([]byte, (len(b)+31)/32*32)
+	copy(padded, b)
+	return padded
+}
+
+// indirect dereferences pointers and interfaces.
+func indirect(rv reflect.Value) reflect.Value {
+	for rv.IsValid() && (rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface) {
+		if rv.IsNil() {
+			return reflect.Value{}
+		}
+		rv = rv.Elem()
+	}
+
+	return rv
+}
+
+// toBig converts the supported integer representations to a big.Int.
+func toBig(rv reflect.Value) (*big.Int, error) {
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return big.NewInt(rv.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return new(big.Int).SetUint64(rv.Uint()), nil
+	}
+
+	switch rv.Type() {
+	case bigIntType:
+		i := rv.Interface().(big.Int)
+		return &i, nil
+	case quantityTyp:
+		return rv.Interface().(eth.Quantity).Big(), nil
+	}
+
+	return nil, errors.Errorf("unsupported integer value of type %s", rv.Type())
+}
+
+// toAddress converts the supported address representations to their 20 bytes.
+func toAddress(rv reflect.Value) ([]byte, error) {
+	switch v := rv.Interface().(type) {
+	case eth.Address:
+		return v.Bytes(), nil
+	case string:
+		d, err := eth.NewData20(v)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid address")
+		}
+		return d.Bytes(), nil
+	}
+
+	b, err := toBytes(rv)
+	if err != nil || len(b) != 20 {
+		return nil, errors.Errorf("cannot encode %s as address", rv.Type())
+	}
+
+	return b, nil
+}
+
+// toBytes converts the supported byte representations to a byte slice.
+func toBytes(rv reflect.Value) ([]byte, error) {
+	switch v := rv.Interface().(type) {
+	case []byte:
+		return v, nil
+	case string:
+		d, err := eth.NewData(v)
+		if err != nil {
+			return nil, err
+		}
+		return d.Bytes(), nil
+	case eth.Data:
+		return v.Bytes(), nil
+	case eth.Data4:
+		return v.Bytes(), nil
+	case eth.Data8:
+		return v.Bytes(), nil
+	case eth.Data20:
+		return v.Bytes(), nil
+	case eth.Data32:
+		return v.Bytes(), nil
+	case eth.Data256:
+		return v.Bytes(), nil
+	case eth.Input:
+		return v.Bytes(), nil
+	}
+
+	if rv.Kind() == reflect.Array && rv.Type().Elem().Kind() == reflect.Uint8 {
+		b := make([]byte, rv.Len())
+		reflect.Copy(reflect.ValueOf(b), rv)
+		return b, nil
+	}
+
+	return nil, errors.Errorf("unsupported bytes value of type %s", rv.Type())
+}
@@ -0,0 +1,219 @@
+package abi_test
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/INFURA/go-ethlibs/abi"
+	"github.com/INFURA/go-ethlibs/eth"
+)
+
+func method(t *testing.T, signature string) *abi.Method {
+	open := strings.Index(signature, "(")
+	typ, err := abi.NewType(signature[open:], nil)
+	require.NoError(t, err)
+
+	return &abi.Method{Name: signature[:open], Inputs: typ.Components}
+}
+
+func words(selector string, w ...string) string {
+	return selector + strings.Join(w, "")
+}
+
+func TestMethod_EncodeCall(t *testing.T) {
+	// Examples from https://docs.soliditylang.org/en/latest/abi-spec.html#examples
+	tests := []struct {
+		Signature string
+		Args      []interface{}
+		Expected  string
+	}{
+		{
+			Signature: "baz(uint32,bool)",
+			Args:      []interface{}{69, true},
+			Expected: words("0xcdcd77c0",
+				"0000000000000000000000000000000000000000000000000000000000000045",
+				"0000000000000000000000000000000000000000000000000000000000000001",
+			),
+		},
+		{
+			Signature: "bar(bytes3[2])",
+			Args:      []interface{}{[][]byte{[]byte("abc"), []byte("def")}},
+			Expected: words("0xfce353f6",
+				"6162630000000000000000000000000000000000000000000000000000000000",
+				"6465660000000000000000000000000000000000000000000000000000000000",
+			),
+		},
+		{
+			Signature: "sam(bytes,bool,uint256[])",
+			Args:      []interface{}{[]byte("dave"), true, []int{1, 2, 3}},
+			Expected: words("0xa5643bf2",
+				"0000000000000000000000000000000000000000000000000000000000000060",
+				"0000000000000000000000000000000000000000000000000000000000000001",
+				"00000000000000000000000000000000000000000000000000000000000000a0",
+				"0000000000000000000000000000000000000000000000000000000000000004",
+				"6461766500000000000000000000000000000000000000000000000000000000",
+				"0000000000000000000000000000000000000000000000000000000000000003",
+				"0000000000000000000000000000000000000000000000000000000000000001",
+				"0000000000000000000000000000000000000000000000000000000000000002",
+				"0000000000000000000000000000000000000000000000000000000000000003",
+			),
+		},
+		{
+			Signature: "f(uint256,uint32[],bytes10,bytes)",
+			Args:      []interface{}{big.NewInt(0x123), []uint32{0x456, 0x789}, []byte("1234567890"), []byte("Hello, world!")},
+			Expected: words("0x8be65246",
+				"0000000000000000000000000000000000000000000000000000000000000123",
+				"0000000000000000000000000000000000000000000000000000000000000080",
+				"3132333435363738393000000000000000000000000000000000000000000000",
+				"00000000000000000000000000000000000000000000000000000000000000e0",
+				"0000000000000000000000000000000000000000000000000000000000000002",
+				"0000000000000000000000000000000000000000000000000000000000000456",
+				"0000000000000000000000000000000000000000000000000000000000000789",
+				"000000000000000000000000000000000000000000000000000000000000000d",
+				"48656c6c6f2c20776f726c642100000000000000000000000000000000000000",
+			),
+		},
+		{
+			Signature: "g(uint256[][],string[])",
+			Args:      []interface{}{[][]int{{1, 2}, {3}}, []string{"one", "two", "three"}},
+			Expected: words("0x2289b18c",
+				"0000000000000000000000000000000000000000000000000000000000000040",
+				"0000000000000000000000000000000000000000000000000000000000000140",
+				"0000000000000000000000000000000000000000000000000000000000000002",
+				"0000000000000000000000000000000000000000000000000000000000000040",
+				"00000000000000000000000000000000000000000000000000000000000000a0",
+				"0000000000000000000000000000000000000000000000000000000000000002",
+				"0000000000000000000000000000000000000000000000000000000000000001",
+				"0000000000000000000000000000000000000000000000000000000000000002",
+				"0000000000000000000000000000000000000000000000000000000000000001",
+				"0000000000000000000000000000000000000000000000000000000000000003",
+				"0000000000000000000000000000000000000000000000000000000000000003",
+				"0000000000000000000000000000000000000000000000000000000000000060",
+				"00000000000000000000000000000000000000000000000000000000000000a0",
+				"00000000000000000000000000000000000000000000000000000000000000e0",
+				"0000000000000000000000000000000000000000000000000000000000000003",
+				"6f6e650000000000000000000000000000000000000000000000000000000000",
+				"0000000000000000000000000000000000000000000000000000000000000003",
+				"74776f0000000000000000000000000000000000000000000000000000000000",
+				"0000000000000000000000000000000000000000000000000000000000000005",
+				"7468726565000000000000000000000000000000000000000000000000000000",
+			),
+		},
+		{
+			Signature: "transfer(address,uint256)",
+			Args:      []interface{}{eth.MustAddress("0x21ab6c9fac80c59d401b37cb43f81ea9dde7fe34"), eth.QuantityFromInt64(1000)},
+			Expected: words("0xa9059cbb",
+				"00000000000000000000000021ab6c9fac80c59d401b37cb43f81ea9dde7fe34",
+				"00000000000000000000000000000000000000000000000000000000000003e8",
+			),
+		},
+		{
+			Signature: "neg(int8,int256)",
+			Args:      []interface{}{int8(-1), big.NewInt(-2)},
+			Expected: words(method(t, "neg(int8,int256)").Selector().String(),
+				"ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+				"fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffe",
+			),
+		},
+	}
+
+	for _, tt := range tests {
+		m := method(t, tt.Signature)
+		input, err := m.EncodeCall(tt.Args...)
+		require.NoError(t, err, tt.Signature)
+		require.Equal(t, tt.Expected, input.String(), tt.Signature)
+
+		// and it should decode back to equivalent values
+		decoded, err := m.Inputs.Unpack(input.Bytes()[4:])
+		require.NoError(t, err, tt.Signature)
+		reencoded, err := m.EncodeCall(decoded...)
+		require.NoError(t, err, tt.Signature)
+		require.Equal(t, tt.Expected, reencoded.String(), tt.Signature)
+	}
+}
+
+func TestEncode_Tuple(t *testing.T) {
+	typ, err := abi.NewType("(address,uint256[],bytes32)", []abi.Argument{})
+	require.NoError(t, err)
+
+	typ.Components[0].Name = "maker"
+	typ.Components[1].Name = "amounts"
+	typ.Components[2].Name = "_salt"
+
+	type Order struct {
+		Maker   eth.Address
+		Amounts []*big.Int
+		Salt    eth.Hash
+	}
+
+	type TaggedOrder struct {
+		Who     string     `abi:"maker"`
+		Amounts []uint64   `abi:"amounts"`
+		Salt    [32]byte   `abi:"_salt"`
+		Ignored complex128 `abi:"-"`
+	}
+
+	maker := eth.MustAddress("0x21ab6c9fac80c59d401b37cb43f81ea9dde7fe34")
+	salt := eth.MustHash("0x0000000000000000000000000000000000000000000000000000000000000007")
+
+	fromSlice, err := abi.Encode(typ, []interface{}{maker, []int{1, 2}, salt})
+	require.NoError(t, err)
+
+	fromStruct, err := abi.Encode(typ, &Order{Maker: *maker, Amounts: []*big.Int{big.NewInt(1), big.NewInt(2)}, Salt: *salt})
+	require.NoError(t, err)
+	require.Equal(t, fromSlice, fromStruct)
+
+	fromTagged, err := abi.Encode(typ, TaggedOrder{Who: maker.String(), Amounts: []uint64{1, 2}, Salt: [32]byte{31: 7}})
+	require.NoError(t, err)
+	require.Equal(t, fromSlice, fromTagged)
+
+	fromMap, err := abi.Encode(typ, map[string]interface{}{"maker": maker, "amounts": []int{1, 2}, "_salt": salt})
+	require.NoError(t, err)
+	require.Equal(t, fromSlice, fromMap)
+
+	decoded, err := abi.Decode(typ, fromSlice)
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{
+		*maker,
+		[]interface{}{big.NewInt(1), big.NewInt(2)},
+		eth.Data(salt.String()),
+	}, decoded)
+}
+
+func TestEncode_Errors(t *testing.T) {
+	tests := []struct {
+		Type  string
+		Value interface{}
+	}{
+		{"uint8", 256},
+		{"uint256", -1},
+		{"int8", 128},
+		{"int8", -129},
+		{"uint256", "not a number"},
+		{"uint256", nil},
+		{"address", "0x1234"},
+		{"address", []byte{1, 2, 3}},
+		{"bool", 1},
+		{"bytes4", []byte{1, 2, 3}},
+		{"bytes4", "0xzzzzzzzz"},
+		{"bytes", 5},
+		{"string", []byte("hi")},
+		{"uint256[2]", []int{1}},
+		{"uint256[]", 1},
+		{"(uint256,bool)", []interface{}{1}},
+		{"(uint256,bool)", map[string]interface{}{"arg0": 1}},
+		{"(uint256,bool)", struct{ Arg0 int }{}},
+	}
+
+	for _, tt := range tests {
+		_, err := abi.Encode(abi.MustType(tt.Type), tt.Value)
+		require.Error(t, err, "%s %v", tt.Type, tt.Value)
+	}
+
+	m := method(t, "transfer(address,uint256)")
+	_, err := m.EncodeCall(eth.MustAddress("0x21ab6c9fac80c59d401b37cb43f81ea9dde7fe34"))
+	require.Error(t, err, "wrong number of arguments must fail")
+}
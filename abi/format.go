@@ -0,0 +1,80 @@
+package abi
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// formatArguments formats decoded values as a comma separated list of name: value pairs, see formatValue.
+func formatArguments(args Arguments, values []interface{}) string {
+	parts := make([]string, len(values))
+	for i := range values {
+		if i < len(args) && args[i].Name != "" {
+			parts[i] = args[i].Name + ": " + formatValue(&args[i].Type, values[i])
+		} else if i < len(args) {
+			parts[i] = formatValue(&args[i].Type, values[i])
+		} else {
+			parts[i] = fmt.Sprint(values[i])
+		}
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// formatValue formats a decoded value for humans: integers in decimal, strings quoted, arrays in brackets and
+// tuples in parentheses.
+func formatValue(t *Type, value interface{}) string {
+	switch v := value.(type) {
+	case *big.Int:
+		return v.String()
+	case string:
+		return strconv.Quote(v)
+	case []interface{}:
+		if t.Kind == KindTuple && len(t.Components) == len(v) {
+			return "(" + formatArguments(t.Components, v) + ")"
+		}
+
+		parts := make([]string, len(v))
+		for i := range v {
+			if t.Elem != nil {
+				parts[i] = formatValue(t.Elem, v[i])
+			} else {
+				parts[i] = fmt.Sprint(v[i])
+			}
+		}
+		return "[" + strings.Join(parts, ", ") + "]"
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// jsonValue converts a decoded value into a form suitable for JSON: integers as decimal strings, since they can
+// exceed the precision of JSON numbers, and tuples as objects keyed by component name.
+func jsonValue(t *Type, value interface{}) interface{} {
+	switch v := value.(type) {
+	case *big.Int:
+		return v.String()
+	case []interface{}:
+		if t.Kind == KindTuple && len(t.Components) == len(v) {
+			m := make(map[string]interface{}, len(v))
+			for i := range v {
+				m[t.Components[i].key(i)] = jsonValue(&t.Components[i].Type, v[i])
+			}
+			return m
+		}
+
+		values := make([]interface{}, len(v))
+		for i := range v {
+			if t.Elem != nil {
+				values[i] = jsonValue(t.Elem, v[i])
+			} else {
+				values[i] = v[i]
+			}
+		}
+		return values
+	default:
+		return v
+	}
+}
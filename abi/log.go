@@ -0,0 +1,131 @@
+package abi
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/INFURA/go-ethlibs/eth"
+)
+
+// DecodedLog is an eth.Log decoded using the event that emitted it.
+type DecodedLog struct {
+	Event  *Event
+	Values map[string]interface{}
+}
+
+// DecodeLog finds the event that emitted the log and decodes its values.  Non-anonymous events are matched by the
+// first topic, and when none match the log is decoded with the only anonymous event that it can be decoded with, if
+// there is exactly one.
+func (a *ABI) DecodeLog(log eth.Log) (*DecodedLog, error) {
+	if len(log.Topics) > 0 {
+		if e, err := a.EventByTopic(log.Topics[0]); err == nil {
+			values, err := e.DecodeLog(log)
+			if err != nil {
+				return nil, err
+			}
+			return &DecodedLog{Event: e, Values: values}, nil
+		}
+	}
+
+	var decoded *DecodedLog
+	for i := range a.Events {
+		e := &a.Events[i]
+		if !e.Anonymous {
+			continue
+		}
+
+		values, err := e.DecodeLog(log)
+		if err != nil {
+			continue
+		}
+
+		if decoded != nil {
+			return nil, errors.Errorf("log matches both of the anonymous events %s and %s", decoded.Event.Signature(), e.Signature())
+		}
+		decoded = &DecodedLog{Event: e, Values: values}
+	}
+
+	if decoded == nil {
+		if len(log.Topics) == 0 {
+			return nil, errors.New("no event matches log without topics")
+		}
+		return nil, errors.Errorf("no event matches log with topic %s", log.Topics[0])
+	}
+
+	return decoded, nil
+}
+
+// DecodeLog decodes the values of a log emitted by the event, keyed by argument name.  Indexed values are decoded
+// from the topics and the rest from the data.  Indexed values of dynamic types such as strings, bytes, arrays and
+// tuples are stored as the keccak256 hash of their encoding, and are therefore returned as an eth.Hash.
+func (e *Event) DecodeLog(log eth.Log) (map[string]interface{}, error) {
+	args, values, err := e.decodeLog(log)
+	if err != nil {
+		return nil, err
+	}
+
+	return args.toMap(values), nil
+}
+
+// DecodeLogInto decodes the values of a log emitted by the event into v, see Arguments.Copy.  Hashed indexed values
+// can be assigned to eth.Hash, [32]byte or []byte fields.
+func (e *Event) DecodeLogInto(log eth.Log, v interface{}) error {
+	args, values, err := e.decodeLog(log)
+	if err != nil {
+		return err
+	}
+
+	return args.Copy(v, values)
+}
+
+// decodeLog returns the values of the log along with the arguments describing them, which are the event inputs
+// except that hashed indexed values are described as bytes32.
+func (e *Event) decodeLog(log eth.Log) (Arguments, []interface{}, error) {
+	topics := log.Topics
+	if !e.Anonymous {
+		if len(topics) == 0 || !strings.EqualFold(topics[0].String(), e.Topic().String()) {
+			return nil, nil, errors.Errorf("log was not emitted by event %s", e.Signature())
+		}
+		topics = topics[1:]
+	}
+
+	indexed := e.Inputs.Indexed()
+	if len(topics) != len(indexed) {
+		return nil, nil, errors.Errorf("event %s has %d indexed arguments but log has %d topics", e.Signature(), len(indexed), len(topics))
+	}
+
+	nonIndexed, err := e.Inputs.NonIndexed().Unpack(log.Data.Bytes())
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "could not decode data of event %s", e.Signature())
+	}
+
+	args := make(Arguments, len(e.Inputs))
+	values := make([]interface{}, len(e.Inputs))
+	for i, t, n := 0, 0, 0; i < len(e.Inputs); i++ {
+		args[i] = e.Inputs[i]
+		if !args[i].Indexed {
+			values[i] = nonIndexed[n]
+			n++
+			continue
+		}
+
+		topic := topics[t]
+		t++
+
+		if args[i].Type.IsDynamic() || args[i].Type.Kind == KindArray || args[i].Type.Kind == KindTuple {
+			// only the hash of the encoding is available
+			args[i].Type = Type{Kind: KindFixedBytes, Size: 32}
+			values[i] = topic
+			continue
+		}
+
+		value, err := Decode(&args[i].Type, topic.Bytes())
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "could not decode topic %d of event %s", t, e.Signature())
+		}
+		values[i] = value
+	}
+
+	return args, values, nil
+}
@@ -0,0 +1,135 @@
+package abi_test
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/INFURA/go-ethlibs/abi"
+	"github.com/INFURA/go-ethlibs/eth"
+)
+
+const events = `[
+  {"type":"event","name":"Transfer","inputs":[{"name":"from","type":"address","indexed":true},{"name":"to","type":"address","indexed":true},{"name":"value","type":"uint256","indexed":false}],"anonymous":false},
+  {"type":"event","name":"Registered","inputs":[{"name":"name","type":"string","indexed":true},{"name":"owner","type":"address","indexed":false},{"name":"tags","type":"string[]","indexed":false}],"anonymous":false},
+  {"type":"event","name":"Deposit","inputs":[{"name":"account","type":"address","indexed":true},{"name":"amount","type":"uint128","indexed":false}],"anonymous":true}
+]`
+
+func TestEvent_DecodeLog(t *testing.T) {
+	a := abi.MustParse(events)
+
+	// from the receipt of 0x9d2fb08850a9b38173044ae6a61974fde4eacca504e399ffd9d5c8af567113cc
+	transfer := eth.Log{
+		Address: *eth.MustAddress("0x21ab6c9fac80c59d401b37cb43f81ea9dde7fe34"),
+		Topics: []eth.Topic{
+			*eth.MustTopic("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"),
+			*eth.MustTopic("0x0000000000000000000000009e44b7d42125b7bb4e809406ed5e1079ff500969"),
+			*eth.MustTopic("0x000000000000000000000000fe5854255eb1eb921525fa856a3947ed2412a1d7"),
+		},
+		Data: *eth.MustData("0x000000000000000000000000000000000000000000000000000000070560c8c0"),
+	}
+
+	decoded, err := a.DecodeLog(transfer)
+	require.NoError(t, err)
+	require.Equal(t, "Transfer", decoded.Event.Name)
+	require.Equal(t, map[string]interface{}{
+		"from":  *eth.MustAddress("0x9e44b7d42125b7bb4e809406ed5e1079ff500969"),
+		"to":    *eth.MustAddress("0xfe5854255eb1eb921525fa856a3947ed2412a1d7"),
+		"value": big.NewInt(0x70560c8c0),
+	}, decoded.Values)
+
+	type Transfer struct {
+		From   eth.Address
+		To     string
+		Amount uint64 `abi:"value"`
+	}
+
+	into := Transfer{}
+	err = decoded.Event.DecodeLogInto(transfer, &into)
+	require.NoError(t, err)
+	require.Equal(t, Transfer{
+		From:   *eth.MustAddress("0x9e44b7d42125b7bb4e809406ed5e1079ff500969"),
+		To:     eth.MustAddress("0xfe5854255eb1eb921525fa856a3947ed2412a1d7").String(),
+		Amount: 0x70560c8c0,
+	}, into)
+
+	// an ERC-721 Transfer has the same signature but its tokenId is indexed, so it must not decode
+	erc721 := transfer
+	erc721.Topics = append(append([]eth.Topic{}, transfer.Topics...), *eth.MustTopic("0x0000000000000000000000000000000000000000000000000000000000000001"))
+	erc721.Data = eth.Data("0x")
+	_, err = a.DecodeLog(erc721)
+	require.Error(t, err)
+
+	// logs emitted by other events are rejected
+	registered, err := a.Event("Registered")
+	require.NoError(t, err)
+	_, err = registered.DecodeLog(transfer)
+	require.Error(t, err)
+}
+
+func TestEvent_DecodeLog_HashedIndexed(t *testing.T) {
+	a := abi.MustParse(events)
+	registered, err := a.Event("Registered")
+	require.NoError(t, err)
+
+	owner := eth.MustAddress("0x21ab6c9fac80c59d401b37cb43f81ea9dde7fe34")
+	data, err := registered.Inputs.NonIndexed().Pack(owner, []string{"a", "b"})
+	require.NoError(t, err)
+
+	nameHash := eth.Data("0x" + hex.EncodeToString([]byte("alice"))).Hash()
+	log := eth.Log{
+		Topics: []eth.Topic{registered.Topic(), nameHash},
+		Data:   eth.Data("0x" + hex.EncodeToString(data)),
+	}
+
+	decoded, err := a.DecodeLog(log)
+	require.NoError(t, err)
+	require.Equal(t, "Registered", decoded.Event.Name)
+	require.Equal(t, nameHash, decoded.Values["name"])
+	require.Equal(t, *owner, decoded.Values["owner"])
+	require.Equal(t, []interface{}{"a", "b"}, decoded.Values["tags"])
+
+	type Registered struct {
+		Name  [32]byte
+		Owner *eth.Address
+		Tags  []string
+	}
+
+	into := Registered{}
+	err = registered.DecodeLogInto(log, &into)
+	require.NoError(t, err)
+	require.Equal(t, nameHash.Bytes(), into.Name[:])
+	require.Equal(t, owner, into.Owner)
+	require.Equal(t, []string{"a", "b"}, into.Tags)
+}
+
+func TestEvent_DecodeLog_Anonymous(t *testing.T) {
+	a := abi.MustParse(events)
+	deposit, err := a.Event("Deposit")
+	require.NoError(t, err)
+
+	log := eth.Log{
+		Topics: []eth.Topic{*eth.MustTopic("0x00000000000000000000000021ab6c9fac80c59d401b37cb43f81ea9dde7fe34")},
+		Data:   *eth.MustData("0x0000000000000000000000000000000000000000000000000000000000000064"),
+	}
+
+	values, err := deposit.DecodeLog(log)
+	require.NoError(t, err)
+	require.Equal(t, *eth.MustAddress("0x21ab6c9fac80c59d401b37cb43f81ea9dde7fe34"), values["account"])
+	require.Equal(t, big.NewInt(100), values["amount"])
+
+	// the ABI falls back to the anonymous event since no topic matches
+	decoded, err := a.DecodeLog(log)
+	require.NoError(t, err)
+	require.Equal(t, deposit, decoded.Event)
+
+	// but not when the data doesn't fit
+	log.Data = *eth.MustData("0x0000000000000000000000000000000100000000000000000000000000000000")
+	_, err = a.DecodeLog(log)
+	require.Error(t, err)
+
+	_, err = a.DecodeLog(eth.Log{Data: eth.Data("0x")})
+	require.Error(t, err)
+}
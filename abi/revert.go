@@ -0,0 +1,126 @@
+package abi
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/INFURA/go-ethlibs/eth"
+)
+
+var (
+	// ErrorStringError is the Error(string) error used by require and revert with a reason string.
+	ErrorStringError = Error{Name: "Error", Inputs: Arguments{{Name: "reason", Type: Type{Kind: KindString}}}}
+
+	// PanicError is the Panic(uint256) error used by failed assertions and runtime errors such as overflows.
+	PanicError = Error{Name: "Panic", Inputs: Arguments{{Name: "code", Type: Type{Kind: KindUint, Size: 256}}}}
+)
+
+// panicReasons describes the panic codes, see https://docs.soliditylang.org/en/latest/control-structures.html#panic-via-assert-and-error-via-require
+var panicReasons = map[uint64]string{
+	0x00: "generic compiler inserted panic",
+	0x01: "assertion failed",
+	0x11: "arithmetic underflow or overflow",
+	0x12: "division or modulo by zero",
+	0x21: "conversion into non-existent enum type",
+	0x22: "access to incorrectly encoded storage byte array",
+	0x31: "pop on empty array",
+	0x32: "array index out of bounds",
+	0x41: "out of memory",
+	0x51: "call to zero-initialized variable of internal function type",
+}
+
+// PanicReason returns the meaning of a Panic(uint256) code.
+func PanicReason(code *big.Int) string {
+	if code.IsUint64() {
+		if reason, ok := panicReasons[code.Uint64()]; ok {
+			return reason
+		}
+	}
+
+	return "unknown panic code"
+}
+
+// Revert is the decoded data returned by a reverted call.  Exactly one of Reason, PanicCode or CustomError is set,
+// unless the call reverted without any data.
+type Revert struct {
+	// Reason is the message of a revert with an Error(string) reason, e.g. require(false, "reason").
+	Reason string
+
+	// PanicCode is the code of a Panic(uint256), e.g. 0x11 for overflows, see PanicReason.
+	PanicCode *big.Int
+
+	// CustomError and Args are the custom Solidity error and its arguments.
+	CustomError *Error
+	Args        []interface{}
+
+	// Data is the raw revert data.
+	Data eth.Data
+}
+
+// DecodeRevert decodes the data returned by a reverted call.  The standard Error(string) and Panic(uint256) errors
+// are always decoded, and custom errors are decoded using the first of the ABIs defining an error matching the
+// selector of the data.
+func DecodeRevert(data []byte, abis ...*ABI) (*Revert, error) {
+	r := Revert{Data: eth.Data(fmt.Sprintf("0x%x", data))}
+	if len(data) == 0 {
+		return &r, nil
+	}
+
+	if len(data) < 4 {
+		return nil, errors.Errorf("%d bytes of revert data are too short for a selector", len(data))
+	}
+
+	selector := eth.Data4(fmt.Sprintf("0x%x", data[:4]))
+	switch {
+	case strings.EqualFold(selector.String(), ErrorStringError.Selector().String()):
+		values, err := ErrorStringError.Inputs.Unpack(data[4:])
+		if err != nil {
+			return nil, errors.Wrap(err, "could not decode revert reason")
+		}
+		r.Reason = values[0].(string)
+		return &r, nil
+	case strings.EqualFold(selector.String(), PanicError.Selector().String()):
+		values, err := PanicError.Inputs.Unpack(data[4:])
+		if err != nil {
+			return nil, errors.Wrap(err, "could not decode panic code")
+		}
+		r.PanicCode = values[0].(*big.Int)
+		return &r, nil
+	}
+
+	for _, a := range abis {
+		e, err := a.ErrorBySelector(selector)
+		if err != nil {
+			continue
+		}
+
+		values, err := e.Inputs.Unpack(data[4:])
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not decode arguments of %s", e.Signature())
+		}
+
+		r.CustomError = e
+		r.Args = values
+		return &r, nil
+	}
+
+	return nil, errors.Errorf("unknown error selector %s", selector)
+}
+
+// Error describes the revert, e.g. "execution reverted: reason", or "execution reverted: panic: arithmetic underflow
+// or overflow (0x11)".
+func (r *Revert) Error() string {
+	switch {
+	case r.PanicCode != nil:
+		return fmt.Sprintf("execution reverted: panic: %s (0x%x)", PanicReason(r.PanicCode), r.PanicCode)
+	case r.CustomError != nil:
+		return "execution reverted: " + r.CustomError.Name + "(" + formatArguments(r.CustomError.Inputs, r.Args) + ")"
+	case r.Reason != "":
+		return "execution reverted: " + r.Reason
+	default:
+		return "execution reverted"
+	}
+}
@@ -0,0 +1,73 @@
+package abi_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/INFURA/go-ethlibs/abi"
+	"github.com/INFURA/go-ethlibs/eth"
+)
+
+func TestDecodeRevert(t *testing.T) {
+	{
+		// require(false, "Not enough Ether provided.")
+		data := eth.MustData("0x08c379a0" +
+			"0000000000000000000000000000000000000000000000000000000000000020" +
+			"000000000000000000000000000000000000000000000000000000000000001a" +
+			"4e6f7420656e6f7567682045746865722070726f76696465642e000000000000")
+
+		r, err := abi.DecodeRevert(data.Bytes())
+		require.NoError(t, err)
+		require.Equal(t, "Not enough Ether provided.", r.Reason)
+		require.Equal(t, *data, r.Data)
+		require.EqualError(t, r, "execution reverted: Not enough Ether provided.")
+	}
+
+	{
+		data := eth.MustData("0x4e487b71" + "0000000000000000000000000000000000000000000000000000000000000011")
+
+		r, err := abi.DecodeRevert(data.Bytes())
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(0x11), r.PanicCode)
+		require.EqualError(t, r, "execution reverted: panic: arithmetic underflow or overflow (0x11)")
+		require.Equal(t, "unknown panic code", abi.PanicReason(big.NewInt(0x99)))
+	}
+
+	{
+		r, err := abi.DecodeRevert(nil)
+		require.NoError(t, err)
+		require.EqualError(t, r, "execution reverted")
+	}
+
+	{
+		token := abi.MustParse(erc20)
+		e, err := token.Error("InsufficientBalance")
+		require.NoError(t, err)
+
+		args, err := e.Inputs.Pack(1, 2)
+		require.NoError(t, err)
+		data := append(e.Selector().Bytes(), args...)
+
+		_, err = abi.DecodeRevert(data)
+		require.Error(t, err, "custom errors require an ABI")
+
+		r, err := abi.DecodeRevert(data, abi.MustParse(events), token)
+		require.NoError(t, err)
+		require.Equal(t, e, r.CustomError)
+		require.Equal(t, []interface{}{big.NewInt(1), big.NewInt(2)}, r.Args)
+		require.EqualError(t, r, "execution reverted: InsufficientBalance(available: 1, required: 2)")
+	}
+
+	invalid := []string{
+		"0x08c379",
+		"0x08c379a0",
+		"0x4e487b71" + "00",
+	}
+
+	for _, d := range invalid {
+		_, err := abi.DecodeRevert(eth.MustData(d).Bytes())
+		require.Error(t, err, d)
+	}
+}
@@ -0,0 +1,139 @@
+package abi
+
+import (
+	"encoding/hex"
+	"reflect"
+
+	"github.com/pkg/errors"
+
+	"github.com/INFURA/go-ethlibs/eth"
+)
+
+// EncodeTopic encodes the value of an indexed event argument of type t as it is stored in a log topic.  Values of
+// static elementary types are ABI encoded, while strings, bytes, arrays and tuples are stored as the keccak256 hash
+// of their encoding in place, see https://docs.soliditylang.org/en/latest/abi-spec.html#indexed-event-encoding
+func EncodeTopic(t *Type, value interface{}) (eth.Topic, error) {
+	rv := reflect.ValueOf(value)
+
+	var (
+		encoded []byte
+		err     error
+	)
+
+	switch t.Kind {
+	case KindString, KindBytes:
+		// the contents without padding or length prefix
+		rv = indirect(rv)
+		if !rv.IsValid() {
+			return "", errors.Errorf("cannot encode nil as %s", t)
+		}
+		if t.Kind == KindString && rv.Kind() == reflect.String {
+			encoded = []byte(rv.String())
+		} else {
+			encoded, err = toBytes(rv)
+		}
+	case KindArray, KindSlice, KindTuple:
+		encoded, err = encodeInPlace(t, rv)
+	default:
+		encoded, err = encode(t, rv)
+		if err == nil {
+			return eth.Topic("0x" + hex.EncodeToString(encoded)), nil
+		}
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	return eth.Topic("0x" + hex.EncodeToString(keccak256(encoded))), nil
+}
+
+// encodeInPlace encodes a value for hashing as an indexed event argument: elementary values are padded to 32 bytes
+// and arrays and tuples are the concatenation of their elements, without any offsets or length prefixes.
+func encodeInPlace(t *Type, rv reflect.Value) ([]byte, error) {
+	rv = indirect(rv)
+	if !rv.IsValid() {
+		return nil, errors.Errorf("cannot encode nil as %s", t)
+	}
+
+	switch t.Kind {
+	case KindString:
+		if rv.Kind() != reflect.String {
+			return nil, errors.Errorf("cannot encode %s as string", rv.Type())
+		}
+		return rightPad([]byte(rv.String())), nil
+	case KindBytes:
+		b, err := toBytes(rv)
+		if err != nil {
+			return nil, err
+		}
+		return rightPad(b), nil
+	case KindArray, KindSlice:
+		if rv.Kind() != reflect.Array && rv.Kind() != reflect.Slice {
+			return nil, errors.Errorf("cannot encode %s as %s", rv.Type(), t)
+		}
+		if t.Kind == KindArray && rv.Len() != t.Size {
+			return nil, errors.Errorf("cannot encode %d elements as %s", rv.Len(), t)
+		}
+
+		var encoded []byte
+		for i := 0; i < rv.Len(); i++ {
+			e, err := encodeInPlace(t.Elem, rv.Index(i))
+			if err != nil {
+				return nil, err
+			}
+			encoded = append(encoded, e...)
+		}
+		return encoded, nil
+	case KindTuple:
+		values, err := tupleValues(t.Components, rv)
+		if err != nil {
+			return nil, err
+		}
+
+		var encoded []byte
+		for i := range values {
+			e, err := encodeInPlace(&t.Components[i].Type, values[i])
+			if err != nil {
+				return nil, err
+			}
+			encoded = append(encoded, e...)
+		}
+		return encoded, nil
+	default:
+		return encode(t, rv)
+	}
+}
+
+// FilterTopics returns the topics of an eth.LogFilter matching logs emitted by the event.  Each of values is the
+// list of acceptable values of the corresponding indexed argument, where an empty list matches any value.
+func (e *Event) FilterTopics(values ...[]interface{}) ([][]eth.Topic, error) {
+	indexed := e.Inputs.Indexed()
+	if len(values) > len(indexed) {
+		return nil, errors.Errorf("event %s has %d indexed arguments but received %d", e.Signature(), len(indexed), len(values))
+	}
+
+	var topics [][]eth.Topic
+	if !e.Anonymous {
+		topics = append(topics, []eth.Topic{e.Topic()})
+	}
+
+	for i := range values {
+		var options []eth.Topic
+		for j := range values[i] {
+			topic, err := EncodeTopic(&indexed[i].Type, values[i][j])
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid value for %s", indexed[i].key(i))
+			}
+			options = append(options, topic)
+		}
+		topics = append(topics, options)
+	}
+
+	// trailing wildcards are implied
+	for len(topics) > 0 && len(topics[len(topics)-1]) == 0 {
+		topics = topics[:len(topics)-1]
+	}
+
+	return topics, nil
+}
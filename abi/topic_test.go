@@ -0,0 +1,129 @@
+package abi_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/sha3"
+
+	"github.com/INFURA/go-ethlibs/abi"
+	"github.com/INFURA/go-ethlibs/eth"
+)
+
+func keccakTopic(b []byte) eth.Topic {
+	hash := sha3.NewLegacyKeccak256()
+	hash.Write(b)
+	return eth.Topic("0x" + hex.EncodeToString(hash.Sum(nil)))
+}
+
+func TestEncodeTopic(t *testing.T) {
+	tests := []struct {
+		typ      string
+		value    interface{}
+		expected eth.Topic
+	}{
+		{
+			typ:      "address",
+			value:    "0x21ab6c9fac80c59d401b37cb43f81ea9dde7fe34",
+			expected: "0x00000000000000000000000021ab6c9fac80c59d401b37cb43f81ea9dde7fe34",
+		},
+		{
+			typ:      "int8",
+			value:    -1,
+			expected: "0xffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+		},
+		{
+			typ:      "bool",
+			value:    true,
+			expected: "0x0000000000000000000000000000000000000000000000000000000000000001",
+		},
+		{
+			// strings and bytes are hashed without padding
+			typ:      "string",
+			value:    "abc",
+			expected: "0x4e03657aea45a94fc7d47ba826c8d667c0d1e6e33a64a036ec44f58fa12d6c45",
+		},
+		{
+			typ:      "bytes",
+			value:    []byte("abc"),
+			expected: "0x4e03657aea45a94fc7d47ba826c8d667c0d1e6e33a64a036ec44f58fa12d6c45",
+		},
+		{
+			// arrays are hashed without a length prefix
+			typ:   "uint256[]",
+			value: []int{1, 2},
+			expected: keccakTopic(mustHex(t,
+				"0000000000000000000000000000000000000000000000000000000000000001",
+				"0000000000000000000000000000000000000000000000000000000000000002",
+			)),
+		},
+		{
+			// dynamic elements are padded in place, without offsets
+			typ:   "(string,uint8)",
+			value: []interface{}{"abc", 1},
+			expected: keccakTopic(mustHex(t,
+				"6162630000000000000000000000000000000000000000000000000000000000",
+				"0000000000000000000000000000000000000000000000000000000000000001",
+			)),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.typ, func(t *testing.T) {
+			topic, err := abi.EncodeTopic(abi.MustType(test.typ), test.value)
+			require.NoError(t, err)
+			require.Equal(t, test.expected, topic)
+		})
+	}
+
+	_, err := abi.EncodeTopic(abi.MustType("uint8"), 256)
+	require.Error(t, err)
+}
+
+func TestEvent_FilterTopics(t *testing.T) {
+	a := abi.MustParse(events)
+	transfer, err := a.Event("Transfer")
+	require.NoError(t, err)
+
+	from := "0x9e44b7d42125b7bb4e809406ed5e1079ff500969"
+	topics, err := transfer.FilterTopics([]interface{}{from})
+	require.NoError(t, err)
+	require.Equal(t, [][]eth.Topic{
+		{transfer.Topic()},
+		{"0x0000000000000000000000009e44b7d42125b7bb4e809406ed5e1079ff500969"},
+	}, topics)
+
+	// wildcards are nil, and trailing wildcards are trimmed
+	topics, err = transfer.FilterTopics(nil, []interface{}{from, eth.MustAddress("0xfe5854255eb1eb921525fa856a3947ed2412a1d7")})
+	require.NoError(t, err)
+	require.Equal(t, [][]eth.Topic{
+		{transfer.Topic()},
+		nil,
+		{
+			"0x0000000000000000000000009e44b7d42125b7bb4e809406ed5e1079ff500969",
+			"0x000000000000000000000000fe5854255eb1eb921525fa856a3947ed2412a1d7",
+		},
+	}, topics)
+
+	topics, err = transfer.FilterTopics(nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, [][]eth.Topic{{transfer.Topic()}}, topics)
+
+	_, err = transfer.FilterTopics(nil, nil, nil)
+	require.Error(t, err)
+
+	_, err = transfer.FilterTopics([]interface{}{"not an address"})
+	require.Error(t, err)
+
+	// anonymous events have no signature topic
+	deposit, err := a.Event("Deposit")
+	require.NoError(t, err)
+	topics, err = deposit.FilterTopics([]interface{}{from})
+	require.NoError(t, err)
+	require.Equal(t, [][]eth.Topic{{"0x0000000000000000000000009e44b7d42125b7bb4e809406ed5e1079ff500969"}}, topics)
+
+	topics, err = deposit.FilterTopics()
+	require.NoError(t, err)
+	require.Empty(t, topics)
+}
@@ -0,0 +1,271 @@
+package abi
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Kind identifies the family an ABI Type belongs to.
+type Kind int
+
+const (
+	KindUint       Kind = iota // KindUint refers to the uint<M> types, uint is an alias of uint256.
+	KindInt                    // KindInt refers to the int<M> types, int is an alias of int256.
+	KindAddress                // KindAddress refers to the address type.
+	KindBool                   // KindBool refers to the bool type.
+	KindFixedBytes             // KindFixedBytes refers to the bytes<M> types.
+	KindFunction               // KindFunction refers to the function type, an address followed by a selector.
+	KindBytes                  // KindBytes refers to the dynamically sized bytes type.
+	KindString                 // KindString refers to the dynamically sized string type.
+	KindArray                  // KindArray refers to the fixed length <type>[M] types.
+	KindSlice                  // KindSlice refers to the dynamically sized <type>[] types.
+	KindTuple                  // KindTuple refers to tuples, which are used to encode structs.
+)
+
+// Type describes a single ABI type such as uint256, bytes32[] or (address,uint256)[2].
+type Type struct {
+	Kind Kind
+
+	// Size is the number of bits of integer types, the number of bytes of fixed bytes types and the length of arrays.
+	Size int
+
+	// Elem is the type of the elements of arrays and slices.
+	Elem *Type
+
+	// Components are the elements of a tuple.
+	Components []Argument
+}
+
+// NewType parses an ABI type string as found in the "type" field of JSON ABI definitions.  Tuples must be supplied
+// with their components, since their type string is just "tuple" followed by any array suffixes.
+func NewType(typ string, components []Argument) (*Type, error) {
+	if typ == "" {
+		return nil, errors.New("empty type")
+	}
+
+	// Array suffixes are parsed from the right, so uint256[2][] is a slice of arrays of two uint256 values
+	if strings.HasSuffix(typ, "]") {
+		open := strings.LastIndex(typ, "[")
+		if open == -1 {
+			return nil, errors.Errorf("invalid type %s, unbalanced brackets", typ)
+		}
+
+		elem, err := NewType(typ[:open], components)
+		if err != nil {
+			return nil, err
+		}
+
+		size := typ[open+1 : len(typ)-1]
+		if size == "" {
+			return &Type{Kind: KindSlice, Elem: elem}, nil
+		}
+
+		n, err := strconv.Atoi(size)
+		if err != nil || n <= 0 {
+			return nil, errors.Errorf("invalid type %s, bad array length %q", typ, size)
+		}
+
+		return &Type{Kind: KindArray, Size: n, Elem: elem}, nil
+	}
+
+	switch typ {
+	case "address":
+		return &Type{Kind: KindAddress}, nil
+	case "bool":
+		return &Type{Kind: KindBool}, nil
+	case "string":
+		return &Type{Kind: KindString}, nil
+	case "bytes":
+		return &Type{Kind: KindBytes}, nil
+	case "function":
+		return &Type{Kind: KindFunction, Size: 24}, nil
+	case "uint":
+		return &Type{Kind: KindUint, Size: 256}, nil
+	case "int":
+		return &Type{Kind: KindInt, Size: 256}, nil
+	case "tuple":
+		if len(components) == 0 {
+			return nil, errors.New("tuple types must have components")
+		}
+		return &Type{Kind: KindTuple, Components: components}, nil
+	}
+
+	// Handle types that are spelled out as tuples, e.g. (uint256,address)
+	if strings.HasPrefix(typ, "(") && strings.HasSuffix(typ, ")") {
+		parts, err := splitTuple(typ[1 : len(typ)-1])
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid type %s", typ)
+		}
+
+		args := make([]Argument, len(parts))
+		for i, part := range parts {
+			t, err := NewType(part, nil)
+			if err != nil {
+				return nil, err
+			}
+			args[i] = Argument{Type: *t}
+		}
+
+		return &Type{Kind: KindTuple, Components: args}, nil
+	}
+
+	switch {
+	case strings.HasPrefix(typ, "uint"):
+		bits, err := parseSize(typ, "uint", 8, 256, 8)
+		if err != nil {
+			return nil, err
+		}
+		return &Type{Kind: KindUint, Size: bits}, nil
+	case strings.HasPrefix(typ, "int"):
+		bits, err := parseSize(typ, "int", 8, 256, 8)
+		if err != nil {
+			return nil, err
+		}
+		return &Type{Kind: KindInt, Size: bits}, nil
+	case strings.HasPrefix(typ, "bytes"):
+		n, err := parseSize(typ, "bytes", 1, 32, 1)
+		if err != nil {
+			return nil, err
+		}
+		return &Type{Kind: KindFixedBytes, Size: n}, nil
+	case strings.HasPrefix(typ, "fixed"), strings.HasPrefix(typ, "ufixed"):
+		return nil, errors.Errorf("unsupported type %s, fixed point numbers are not supported", typ)
+	}
+
+	return nil, errors.Errorf("unknown type %s", typ)
+}
+
+// MustType is like NewType but panics if the type can't be parsed.
+func MustType(typ string) *Type {
+	t, err := NewType(typ, nil)
+	if err != nil {
+		panic(err)
+	}
+
+	return t
+}
+
+// parseSize parses the numeric suffix of a type like uint64 or bytes4.
+func parseSize(typ string, prefix string, min int, max int, multiple int) (int, error) {
+	s := typ[len(prefix):]
+	n, err := strconv.Atoi(s)
+	if err != nil || strconv.Itoa(n) != s || n < min || n > max || n%multiple != 0 {
+		return 0, errors.Errorf("invalid type %s", typ)
+	}
+
+	return n, nil
+}
+
+// splitTuple splits the inside of a tuple type string on the commas that are not nested within another tuple.
+func splitTuple(s string) ([]string, error) {
+	if s == "" {
+		return nil, errors.New("tuple types must have components")
+	}
+
+	var (
+		parts []string
+		depth int
+		start int
+	)
+
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth < 0 {
+				return nil, errors.New("unbalanced parentheses")
+			}
+		case ',':
+			if depth == 0 {
+				parts = append(parts, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+
+	if depth != 0 {
+		return nil, errors.New("unbalanced parentheses")
+	}
+
+	return append(parts, s[start:]), nil
+}
+
+// String returns the canonical representation of the type used when computing signatures, for example uint is
+// always represented as uint256 and tuples are represented by their components in parentheses.
+func (t *Type) String() string {
+	switch t.Kind {
+	case KindUint:
+		return "uint" + strconv.Itoa(t.Size)
+	case KindInt:
+		return "int" + strconv.Itoa(t.Size)
+	case KindAddress:
+		return "address"
+	case KindBool:
+		return "bool"
+	case KindFixedBytes:
+		return "bytes" + strconv.Itoa(t.Size)
+	case KindFunction:
+		return "function"
+	case KindBytes:
+		return "bytes"
+	case KindString:
+		return "string"
+	case KindArray:
+		return t.Elem.String() + "[" + strconv.Itoa(t.Size) + "]"
+	case KindSlice:
+		return t.Elem.String() + "[]"
+	case KindTuple:
+		types := make([]string, len(t.Components))
+		for i := range t.Components {
+			types[i] = t.Components[i].Type.String()
+		}
+		return "(" + strings.Join(types, ",") + ")"
+	default:
+		return "unknown"
+	}
+}
+
+// IsDynamic returns true if the encoding of the type is dynamically sized, and therefore stored after the static
+// head of the enclosing tuple.
+func (t *Type) IsDynamic() bool {
+	switch t.Kind {
+	case KindBytes, KindString, KindSlice:
+		return true
+	case KindArray:
+		return t.Elem.IsDynamic()
+	case KindTuple:
+		for i := range t.Components {
+			if t.Components[i].Type.IsDynamic() {
+				return true
+			}
+		}
+		return false
+	default:
+		return false
+	}
+}
+
+// headSize returns the number of bytes the type occupies in the head of an enclosing tuple, which is 32 bytes for
+// dynamic types since their head is an offset to their contents.
+func (t *Type) headSize() int {
+	if t.IsDynamic() {
+		return 32
+	}
+
+	switch t.Kind {
+	case KindArray:
+		return t.Size * t.Elem.headSize()
+	case KindTuple:
+		size := 0
+		for i := range t.Components {
+			size += t.Components[i].Type.headSize()
+		}
+		return size
+	default:
+		return 32
+	}
+}
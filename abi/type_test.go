@@ -0,0 +1,67 @@
+package abi_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/INFURA/go-ethlibs/abi"
+)
+
+func TestNewType(t *testing.T) {
+	tests := []struct {
+		Type      string
+		Canonical string
+		Kind      abi.Kind
+		Dynamic   bool
+	}{
+		{"uint", "uint256", abi.KindUint, false},
+		{"int", "int256", abi.KindInt, false},
+		{"uint8", "uint8", abi.KindUint, false},
+		{"int64", "int64", abi.KindInt, false},
+		{"address", "address", abi.KindAddress, false},
+		{"bool", "bool", abi.KindBool, false},
+		{"bytes1", "bytes1", abi.KindFixedBytes, false},
+		{"bytes32", "bytes32", abi.KindFixedBytes, false},
+		{"function", "function", abi.KindFunction, false},
+		{"bytes", "bytes", abi.KindBytes, true},
+		{"string", "string", abi.KindString, true},
+		{"uint256[]", "uint256[]", abi.KindSlice, true},
+		{"uint[3]", "uint256[3]", abi.KindArray, false},
+		{"string[2]", "string[2]", abi.KindArray, true},
+		{"uint8[2][]", "uint8[2][]", abi.KindSlice, true},
+		{"(uint256,address)", "(uint256,address)", abi.KindTuple, false},
+		{"(uint256,(bytes,bool))[2]", "(uint256,(bytes,bool))[2]", abi.KindArray, true},
+	}
+
+	for _, tt := range tests {
+		typ, err := abi.NewType(tt.Type, nil)
+		require.NoError(t, err, tt.Type)
+		require.Equal(t, tt.Canonical, typ.String())
+		require.Equal(t, tt.Kind, typ.Kind, tt.Type)
+		require.Equal(t, tt.Dynamic, typ.IsDynamic(), tt.Type)
+	}
+
+	invalid := []string{
+		"",
+		"uint7",
+		"uint264",
+		"uint08",
+		"int0",
+		"bytes0",
+		"bytes33",
+		"fixed128x18",
+		"tuple",
+		"uint256[0]",
+		"uint256[-1]",
+		"uint256]",
+		"(uint256",
+		"()",
+		"foo",
+	}
+
+	for _, typ := range invalid {
+		_, err := abi.NewType(typ, nil)
+		require.Error(t, err, typ)
+	}
+}
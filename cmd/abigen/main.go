@@ -0,0 +1,78 @@
+// Command abigen generates typed Go bindings for a contract from its JSON ABI, and optionally its bytecode.
+//
+// Usage:
+//
+//	abigen -abi Token.abi [-bin Token.bin] -pkg token -type Token [-out token.go]
+//
+// The ABI may also be read from a hardhat or truffle build artifact.
+package main
+
+import (
+	"flag"
+	"io/ioutil"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/INFURA/go-ethlibs/abi/bind"
+)
+
+var (
+	abiFile = flag.String("abi", "", "path of the JSON ABI, use - for stdin")
+	binFile = flag.String("bin", "", "optional path of the contract creation bytecode")
+	pkg     = flag.String("pkg", "", "package name of the generated file")
+	typ     = flag.String("type", "", "type name of the contract binding")
+	outFile = flag.String("out", "", "output file, defaults to stdout")
+)
+
+func main() {
+	flag.Parse()
+	if *abiFile == "" || *pkg == "" || *typ == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	var (
+		abiJSON []byte
+		err     error
+	)
+
+	if *abiFile == "-" {
+		abiJSON, err = ioutil.ReadAll(os.Stdin)
+	} else {
+		abiJSON, err = ioutil.ReadFile(*abiFile)
+	}
+	if err != nil {
+		log.Fatalf("[FATAL] Could not read ABI: %v", err)
+	}
+
+	bytecode := ""
+	if *binFile != "" {
+		b, err := ioutil.ReadFile(*binFile)
+		if err != nil {
+			log.Fatalf("[FATAL] Could not read bytecode: %v", err)
+		}
+		bytecode = strings.TrimSpace(string(b))
+	}
+
+	code, err := bind.Generate(bind.Options{
+		Package:  *pkg,
+		Type:     *typ,
+		ABI:      string(abiJSON),
+		Bytecode: bytecode,
+	})
+	if err != nil {
+		log.Fatalf("[FATAL] Could not generate binding: %v", err)
+	}
+
+	if *outFile == "" {
+		if _, err := os.Stdout.Write(code); err != nil {
+			log.Fatalf("[FATAL] Could not write binding: %v", err)
+		}
+		return
+	}
+
+	if err := ioutil.WriteFile(*outFile, code, 0644); err != nil {
+		log.Fatalf("[FATAL] Could not write binding: %v", err)
+	}
+}
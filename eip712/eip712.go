@@ -0,0 +1,532 @@
+// Package eip712 implements hashing and signing of typed structured data as described in
+// https://eips.ethereum.org/EIPS/eip-712, as used by eth_signTypedData_v4.
+package eip712
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/sha3"
+
+	"github.com/INFURA/go-ethlibs/abi"
+	"github.com/INFURA/go-ethlibs/eth"
+)
+
+// DomainType is the name of the type of the domain.
+const DomainType = "EIP712Domain"
+
+// Field is a member of a struct type.
+type Field struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// Types are the struct types of typed data, keyed by name.
+type Types map[string][]Field
+
+// Domain is the domain separator of typed data, which prevents signatures from being valid across applications.  Only
+// the fields that are set are part of the domain.
+type Domain struct {
+	Name              *string       `json:"name,omitempty"`
+	Version           *string       `json:"version,omitempty"`
+	ChainId           *eth.Quantity `json:"chainId,omitempty"`
+	VerifyingContract *eth.Address  `json:"verifyingContract,omitempty"`
+	Salt              *eth.Hash     `json:"salt,omitempty"`
+}
+
+// TypedData is the typed structured data to sign, in the JSON format used by eth_signTypedData_v4.
+type TypedData struct {
+	Types       Types                  `json:"types"`
+	PrimaryType string                 `json:"primaryType"`
+	Domain      Domain                 `json:"domain"`
+	Message     map[string]interface{} `json:"message"`
+}
+
+// arrayType matches the element type and length of array types, e.g. Person[] or uint256[2].
+var arrayType = regexp.MustCompile(`^(.*)\[([0-9]*)\]$`)
+
+// Parse parses typed data from its JSON representation.  Integers may be JSON numbers, decimal strings or
+// hexadecimal strings.
+func Parse(data []byte) (*TypedData, error) {
+	td := TypedData{}
+	if err := json.Unmarshal(data, &td); err != nil {
+		return nil, err
+	}
+
+	return &td, nil
+}
+
+func (td *TypedData) UnmarshalJSON(data []byte) error {
+	// Decode numbers as json.Number, since float64 would lose the precision of large integers
+	type typedData TypedData
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	parsed := typedData{}
+	if err := decoder.Decode(&parsed); err != nil {
+		return errors.Wrap(err, "could not parse typed data")
+	}
+
+	*td = TypedData(parsed)
+	return nil
+}
+
+func (d *Domain) UnmarshalJSON(data []byte) error {
+	type domain Domain
+	parsed := struct {
+		*domain
+		ChainId json.RawMessage `json:"chainId,omitempty"`
+	}{domain: (*domain)(d)}
+
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		return err
+	}
+
+	d.ChainId = nil
+	if len(parsed.ChainId) == 0 || string(parsed.ChainId) == "null" {
+		return nil
+	}
+
+	// the chain id is commonly a JSON number rather than a quantity
+	var value interface{} = json.Number(parsed.ChainId)
+	if parsed.ChainId[0] == '"' {
+		s := ""
+		if err := json.Unmarshal(parsed.ChainId, &s); err != nil {
+			return err
+		}
+		value = s
+	}
+
+	i, err := toBig(value)
+	if err != nil || i.Sign() < 0 {
+		return errors.Errorf("invalid chainId %s", parsed.ChainId)
+	}
+
+	q := eth.QuantityFromBigInt(i)
+	d.ChainId = &q
+	return nil
+}
+
+// Fields returns the fields of the EIP712Domain type matching the fields of the domain that are set, which is used
+// when the types of the typed data don't include EIP712Domain.
+func (d *Domain) Fields() []Field {
+	var fields []Field
+	if d.Name != nil {
+		fields = append(fields, Field{Name: "name", Type: "string"})
+	}
+	if d.Version != nil {
+		fields = append(fields, Field{Name: "version", Type: "string"})
+	}
+	if d.ChainId != nil {
+		fields = append(fields, Field{Name: "chainId", Type: "uint256"})
+	}
+	if d.VerifyingContract != nil {
+		fields = append(fields, Field{Name: "verifyingContract", Type: "address"})
+	}
+	if d.Salt != nil {
+		fields = append(fields, Field{Name: "salt", Type: "bytes32"})
+	}
+
+	return fields
+}
+
+// values returns the fields of the domain that are set, keyed by name.
+func (d *Domain) values() map[string]interface{} {
+	values := map[string]interface{}{}
+	if d.Name != nil {
+		values["name"] = *d.Name
+	}
+	if d.Version != nil {
+		values["version"] = *d.Version
+	}
+	if d.ChainId != nil {
+		values["chainId"] = d.ChainId.Big()
+	}
+	if d.VerifyingContract != nil {
+		values["verifyingContract"] = d.VerifyingContract.String()
+	}
+	if d.Salt != nil {
+		values["salt"] = d.Salt.String()
+	}
+
+	return values
+}
+
+// types returns the types of the typed data, adding the EIP712Domain type derived from the domain when missing.
+func (td *TypedData) types() Types {
+	if _, ok := td.Types[DomainType]; ok {
+		return td.Types
+	}
+
+	types := Types{DomainType: td.Domain.Fields()}
+	for name, fields := range td.Types {
+		types[name] = fields
+	}
+
+	return types
+}
+
+// EncodeType returns the encoding of a struct type, which is its signature followed by the signatures of the struct
+// types it references sorted by name, e.g.
+//
+//	Mail(Person from,Person to,string contents)Person(string name,address wallet)
+func (td *TypedData) EncodeType(name string) (string, error) {
+	return td.types().encodeType(name)
+}
+
+// TypeHash returns the keccak256 hash of the encoding of a struct type.
+func (td *TypedData) TypeHash(name string) (eth.Hash, error) {
+	encoded, err := td.EncodeType(name)
+	if err != nil {
+		return "", err
+	}
+
+	return hash([]byte(encoded)), nil
+}
+
+// HashStruct returns the hash of a value of a struct type, which is the keccak256 hash of its type hash followed by
+// the encoding of its fields.
+func (td *TypedData) HashStruct(name string, value map[string]interface{}) (eth.Hash, error) {
+	return td.types().hashStruct(name, value)
+}
+
+// DomainSeparator returns the hash of the domain.
+func (td *TypedData) DomainSeparator() (eth.Hash, error) {
+	h, err := td.HashStruct(DomainType, td.Domain.values())
+	if err != nil {
+		return "", errors.Wrap(err, "could not hash domain")
+	}
+
+	return h, nil
+}
+
+// Hash returns the digest to sign, which is keccak256("\x19\x01" ‖ domainSeparator ‖ hashStruct(message)).  When the
+// primary type is EIP712Domain the message is omitted.
+func (td *TypedData) Hash() (eth.Hash, error) {
+	separator, err := td.DomainSeparator()
+	if err != nil {
+		return "", err
+	}
+
+	data := append([]byte{0x19, 0x01}, separator.Bytes()...)
+	if td.PrimaryType != DomainType {
+		message, err := td.HashStruct(td.PrimaryType, td.Message)
+		if err != nil {
+			return "", errors.Wrap(err, "could not hash message")
+		}
+		data = append(data, message.Bytes()...)
+	}
+
+	return hash(data), nil
+}
+
+// Sign signs the typed data with a private key.
+func (td *TypedData) Sign(privKey []byte) (*eth.Signature, error) {
+	h, err := td.Hash()
+	if err != nil {
+		return nil, err
+	}
+
+	return eth.ECSign(&h, privKey, eth.QuantityFromInt64(0))
+}
+
+// Recover returns the address of the account that signed the typed data.
+func (td *TypedData) Recover(sig *eth.Signature) (*eth.Address, error) {
+	h, err := td.Hash()
+	if err != nil {
+		return nil, err
+	}
+
+	return sig.Recover(&h)
+}
+
+// encodeType returns the encoding of a struct type, see TypedData.EncodeType.
+func (types Types) encodeType(name string) (string, error) {
+	if _, ok := types[name]; !ok {
+		return "", errors.Errorf("unknown type %s", name)
+	}
+
+	deps := map[string]bool{}
+	if err := types.dependencies(name, deps); err != nil {
+		return "", err
+	}
+	delete(deps, name)
+
+	sorted := make([]string, 0, len(deps))
+	for dep := range deps {
+		sorted = append(sorted, dep)
+	}
+	sort.Strings(sorted)
+
+	b := strings.Builder{}
+	for _, t := range append([]string{name}, sorted...) {
+		fields := make([]string, len(types[t]))
+		for i, f := range types[t] {
+			fields[i] = f.Type + " " + f.Name
+		}
+		b.WriteString(t + "(" + strings.Join(fields, ",") + ")")
+	}
+
+	return b.String(), nil
+}
+
+// dependencies adds the struct types referenced by a struct type, including itself, to deps.
+func (types Types) dependencies(name string, deps map[string]bool) error {
+	if deps[name] {
+		return nil
+	}
+	deps[name] = true
+
+	for _, f := range types[name] {
+		base := f.Type
+		for {
+			m := arrayType.FindStringSubmatch(base)
+			if m == nil {
+				break
+			}
+			base = m[1]
+		}
+
+		if _, ok := types[base]; ok {
+			if err := types.dependencies(base, deps); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if _, err := abi.NewType(base, nil); err != nil || base == "tuple" || base == "function" {
+			return errors.Errorf("unknown type %s of %s.%s", base, name, f.Name)
+		}
+	}
+
+	return nil
+}
+
+// hashStruct returns the hash of a value of a struct type, see TypedData.HashStruct.
+func (types Types) hashStruct(name string, value map[string]interface{}) (eth.Hash, error) {
+	encoded, err := types.encodeData(name, value)
+	if err != nil {
+		return "", err
+	}
+
+	return hash(encoded), nil
+}
+
+// encodeData returns the type hash of a struct followed by the encoding of each of its fields.
+func (types Types) encodeData(name string, value map[string]interface{}) ([]byte, error) {
+	encodedType, err := types.encodeType(name)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := types[name]
+	if len(value) > len(fields) {
+		for key := range value {
+			if !hasField(fields, key) {
+				return nil, errors.Errorf("%s has no field %s", name, key)
+			}
+		}
+	}
+
+	encoded := hash([]byte(encodedType)).Bytes()
+	for _, f := range fields {
+		v, ok := value[f.Name]
+		if !ok || v == nil {
+			return nil, errors.Errorf("missing value of %s.%s", name, f.Name)
+		}
+
+		e, err := types.encodeValue(f.Type, v)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not encode %s.%s", name, f.Name)
+		}
+		encoded = append(encoded, e...)
+	}
+
+	return encoded, nil
+}
+
+// encodeValue returns the 32 byte encoding of a field value.  Structs, arrays, strings and bytes are encoded as the
+// keccak256 hash of their contents, and other values as they are ABI encoded.
+func (types Types) encodeValue(typ string, value interface{}) ([]byte, error) {
+	if m := arrayType.FindStringSubmatch(typ); m != nil {
+		elems, err := toSlice(value)
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot encode %T as %s", value, typ)
+		}
+
+		if m[2] != "" {
+			if n, err := strconv.Atoi(m[2]); err != nil || n != len(elems) {
+				return nil, errors.Errorf("cannot encode %d elements as %s", len(elems), typ)
+			}
+		}
+
+		var encoded []byte
+		for i := range elems {
+			e, err := types.encodeValue(m[1], elems[i])
+			if err != nil {
+				return nil, errors.Wrapf(err, "could not encode element %d", i)
+			}
+			encoded = append(encoded, e...)
+		}
+		return hash(encoded).Bytes(), nil
+	}
+
+	if _, ok := types[typ]; ok {
+		fields, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, errors.Errorf("cannot encode %T as %s", value, typ)
+		}
+
+		h, err := types.hashStruct(typ, fields)
+		if err != nil {
+			return nil, err
+		}
+		return h.Bytes(), nil
+	}
+
+	t, err := abi.NewType(typ, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	switch t.Kind {
+	case abi.KindString:
+		s, ok := value.(string)
+		if !ok {
+			return nil, errors.Errorf("cannot encode %T as string", value)
+		}
+		return hash([]byte(s)).Bytes(), nil
+	case abi.KindBytes:
+		b, err := toBytes(value)
+		if err != nil {
+			return nil, err
+		}
+		return hash(b).Bytes(), nil
+	case abi.KindFixedBytes:
+		b, err := toBytes(value)
+		if err != nil {
+			return nil, err
+		}
+		value = b
+	case abi.KindUint, abi.KindInt:
+		i, err := toBig(value)
+		if err != nil {
+			return nil, err
+		}
+		value = i
+	}
+
+	return abi.Encode(t, value)
+}
+
+func hasField(fields []Field, name string) bool {
+	for i := range fields {
+		if fields[i].Name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// toSlice converts slices and arrays to a slice of their elements.
+func toSlice(value interface{}) ([]interface{}, error) {
+	if elems, ok := value.([]interface{}); ok {
+		return elems, nil
+	}
+
+	rv := reflect.ValueOf(value)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return nil, errors.New("not a slice or array")
+	}
+
+	elems := make([]interface{}, rv.Len())
+	for i := range elems {
+		elems[i] = rv.Index(i).Interface()
+	}
+
+	return elems, nil
+}
+
+// toBig converts the supported integer representations, including JSON numbers and decimal or hexadecimal strings.
+func toBig(value interface{}) (*big.Int, error) {
+	switch v := value.(type) {
+	case json.Number:
+		i, ok := new(big.Int).SetString(v.String(), 10)
+		if !ok {
+			return nil, errors.Errorf("invalid integer %s", v)
+		}
+		return i, nil
+	case string:
+		base := 10
+		s := v
+		if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+			base, s = 16, s[2:]
+		} else if strings.HasPrefix(s, "-0x") {
+			base, s = 16, "-"+s[3:]
+		}
+		i, ok := new(big.Int).SetString(s, base)
+		if !ok {
+			return nil, errors.Errorf("invalid integer %q", v)
+		}
+		return i, nil
+	case float64:
+		if v != float64(int64(v)) {
+			return nil, errors.Errorf("invalid integer %v", v)
+		}
+		return big.NewInt(int64(v)), nil
+	case *big.Int:
+		return v, nil
+	case big.Int:
+		return &v, nil
+	case eth.Quantity:
+		return v.Big(), nil
+	case *eth.Quantity:
+		return v.Big(), nil
+	case int:
+		return big.NewInt(int64(v)), nil
+	case int64:
+		return big.NewInt(v), nil
+	case uint64:
+		return new(big.Int).SetUint64(v), nil
+	}
+
+	return nil, errors.Errorf("unsupported integer value of type %T", value)
+}
+
+// toBytes converts hexadecimal strings and byte slices to bytes.
+func toBytes(value interface{}) ([]byte, error) {
+	switch v := value.(type) {
+	case []byte:
+		return v, nil
+	case string:
+		if !strings.HasPrefix(v, "0x") || len(v)%2 != 0 {
+			return nil, errors.Errorf("invalid hex string %q", v)
+		}
+		b, err := hex.DecodeString(v[2:])
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid hex string %q", v)
+		}
+		return b, nil
+	case eth.Data:
+		return v.Bytes(), nil
+	case eth.Data32:
+		return v.Bytes(), nil
+	}
+
+	return nil, errors.Errorf("unsupported bytes value of type %T", value)
+}
+
+func hash(data []byte) eth.Hash {
+	h := sha3.NewLegacyKeccak256()
+	h.Write(data)
+	return eth.Hash("0x" + hex.EncodeToString(h.Sum(nil)))
+}
@@ -0,0 +1,230 @@
+package eip712_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/INFURA/go-ethlibs/eip712"
+	"github.com/INFURA/go-ethlibs/eth"
+)
+
+// mail is the example from the EIP, see https://github.com/ethereum/EIPs/blob/master/assets/eip-712/Example.js
+const mail = `{
+  "types": {
+    "EIP712Domain": [
+      {"name": "name", "type": "string"},
+      {"name": "version", "type": "string"},
+      {"name": "chainId", "type": "uint256"},
+      {"name": "verifyingContract", "type": "address"}
+    ],
+    "Person": [
+      {"name": "name", "type": "string"},
+      {"name": "wallet", "type": "address"}
+    ],
+    "Mail": [
+      {"name": "from", "type": "Person"},
+      {"name": "to", "type": "Person"},
+      {"name": "contents", "type": "string"}
+    ]
+  },
+  "primaryType": "Mail",
+  "domain": {
+    "name": "Ether Mail",
+    "version": "1",
+    "chainId": 1,
+    "verifyingContract": "0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC"
+  },
+  "message": {
+    "from": {"name": "Cow", "wallet": "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826"},
+    "to": {"name": "Bob", "wallet": "0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB"},
+    "contents": "Hello, Bob!"
+  }
+}`
+
+func TestTypedData_Hash(t *testing.T) {
+	td, err := eip712.Parse([]byte(mail))
+	require.NoError(t, err)
+
+	encoded, err := td.EncodeType("Mail")
+	require.NoError(t, err)
+	require.Equal(t, "Mail(Person from,Person to,string contents)Person(string name,address wallet)", encoded)
+
+	typeHash, err := td.TypeHash("Mail")
+	require.NoError(t, err)
+	require.Equal(t, eth.Hash("0xa0cedeb2dc280ba39b857546d74f5549c3a1d7bdc2dd96bf881f76108e23dac2"), typeHash)
+
+	separator, err := td.DomainSeparator()
+	require.NoError(t, err)
+	require.Equal(t, eth.Hash("0xf2cee375fa42b42143804025fc449deafd50cc031ca257e0b194a650a912090f"), separator)
+
+	message, err := td.HashStruct("Mail", td.Message)
+	require.NoError(t, err)
+	require.Equal(t, eth.Hash("0xc52c0ee5d84264471806290a3f2c4cecfc5490626bf912d01f240d7a274b371e"), message)
+
+	digest, err := td.Hash()
+	require.NoError(t, err)
+	require.Equal(t, eth.Hash("0xbe609aee343fb3c4b28e1df9e632fca64fcfaede20f02e86244efddf30957bd2"), digest)
+
+	// the EIP712Domain type is derived from the domain when omitted
+	delete(td.Types, eip712.DomainType)
+	derived, err := td.Hash()
+	require.NoError(t, err)
+	require.Equal(t, digest, derived)
+}
+
+func TestTypedData_SignAndRecover(t *testing.T) {
+	td, err := eip712.Parse([]byte(mail))
+	require.NoError(t, err)
+
+	// keccak256("cow")
+	key := eth.MustData("0xc85ef7d79691fe79573b1a7064c19c1a9819ebdbd1faaab1a8ec92344438aaf4").Bytes()
+	sig, err := td.Sign(key)
+	require.NoError(t, err)
+
+	r, s, v := sig.EIP155Values()
+	require.Equal(t, "0x4355c47d63924e8a72e509b65029052eb6c299d53a04e167c5775fd466751c9d", r.String())
+	require.Equal(t, "0x7299936d304c153f6443dfa05f40ff007d72911b6f72307f996231605b91562", s.String())
+	require.Equal(t, int64(28), v.Int64())
+
+	signer, err := td.Recover(sig)
+	require.NoError(t, err)
+	require.Equal(t, "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826", signer.String())
+
+	// changing the message changes the signer
+	td.Message["contents"] = "Hello, Alice!"
+	other, err := td.Recover(sig)
+	require.NoError(t, err)
+	require.NotEqual(t, signer.String(), other.String())
+}
+
+func TestTypedData_Arrays(t *testing.T) {
+	// the nested arrays example from eth-sig-util's signTypedData_v4 tests
+	raw := `{
+	  "types": {
+	    "EIP712Domain": [
+	      {"name": "name", "type": "string"},
+	      {"name": "version", "type": "string"},
+	      {"name": "chainId", "type": "uint256"},
+	      {"name": "verifyingContract", "type": "address"}
+	    ],
+	    "Person": [
+	      {"name": "name", "type": "string"},
+	      {"name": "wallets", "type": "address[]"}
+	    ],
+	    "Mail": [
+	      {"name": "from", "type": "Person"},
+	      {"name": "to", "type": "Person[]"},
+	      {"name": "contents", "type": "string"}
+	    ],
+	    "Group": [
+	      {"name": "name", "type": "string"},
+	      {"name": "members", "type": "Person[]"}
+	    ]
+	  },
+	  "domain": {
+	    "name": "Ether Mail",
+	    "version": "1",
+	    "chainId": "0x1",
+	    "verifyingContract": "0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC"
+	  },
+	  "primaryType": "Mail",
+	  "message": {
+	    "from": {
+	      "name": "Cow",
+	      "wallets": ["0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826", "0xDeaDbeefdEAdbeefdEadbEEFdeadbeEFdEaDbeeF"]
+	    },
+	    "to": [
+	      {
+	        "name": "Bob",
+	        "wallets": [
+	          "0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB",
+	          "0xB0BdaBea57B0BDABeA57b0bdABEA57b0BDabEa57",
+	          "0xB0B0b0b0b0b0B000000000000000000000000000"
+	        ]
+	      }
+	    ],
+	    "contents": "Hello, Bob!"
+	  }
+	}`
+
+	td, err := eip712.Parse([]byte(raw))
+	require.NoError(t, err)
+
+	encoded, err := td.EncodeType("Group")
+	require.NoError(t, err)
+	require.Equal(t, "Group(string name,Person[] members)Person(string name,address[] wallets)", encoded)
+
+	message, err := td.HashStruct("Mail", td.Message)
+	require.NoError(t, err)
+	require.Equal(t, eth.Hash("0xeb4221181ff3f1a83ea7313993ca9218496e424604ba9492bb4052c03d5c3df8"), message)
+
+	digest, err := td.Hash()
+	require.NoError(t, err)
+	require.Equal(t, eth.Hash("0xa85c2e2b118698e88db68a8105b794a8cc7cec074e89ef991cb4f5f533819cc2"), digest)
+}
+
+func TestTypedData_Invalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(td *eip712.TypedData)
+	}{
+		{
+			name:   "unknown primary type",
+			mutate: func(td *eip712.TypedData) { td.PrimaryType = "Letter" },
+		},
+		{
+			name:   "missing field",
+			mutate: func(td *eip712.TypedData) { delete(td.Message, "contents") },
+		},
+		{
+			name:   "extra field",
+			mutate: func(td *eip712.TypedData) { td.Message["cc"] = "Alice" },
+		},
+		{
+			name: "invalid address",
+			mutate: func(td *eip712.TypedData) {
+				td.Message["to"] = map[string]interface{}{"name": "Bob", "wallet": "0x1234"}
+			},
+		},
+		{
+			name: "unknown field type",
+			mutate: func(td *eip712.TypedData) {
+				td.Types["Person"] = []eip712.Field{{Name: "name", Type: "string"}, {Name: "wallet", Type: "Wallet"}}
+			},
+		},
+		{
+			name: "missing domain value",
+			mutate: func(td *eip712.TypedData) {
+				td.Domain.ChainId = nil
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			td, err := eip712.Parse([]byte(mail))
+			require.NoError(t, err)
+
+			test.mutate(td)
+			_, err = td.Hash()
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestDomain_UnmarshalJSON(t *testing.T) {
+	for _, chainId := range []string{`1337`, `"1337"`, `"0x539"`} {
+		d := eip712.Domain{}
+		err := json.Unmarshal([]byte(`{"name":"App","chainId":`+chainId+`}`), &d)
+		require.NoError(t, err)
+		require.Equal(t, "App", *d.Name)
+		require.Nil(t, d.Version)
+		require.Equal(t, int64(1337), d.ChainId.Int64())
+		require.Equal(t, []eip712.Field{{Name: "name", Type: "string"}, {Name: "chainId", Type: "uint256"}}, d.Fields())
+	}
+
+	err := json.Unmarshal([]byte(`{"chainId":-1}`), &eip712.Domain{})
+	require.Error(t, err)
+}
@@ -44,7 +44,7 @@ func NewAccessListFromRLP(v rlp.Value) (AccessList, error) {
 		if l == 0 || l > 2 {
 			return nil, errors.Errorf("invalid access list entry %d", j)
 		}
-		address, err := NewAddress(accessRLP.List[0].String)
+		address, err := NewAddress(accessRLP.List[0].AsHex())
 		if err != nil {
 			return nil, errors.Wrapf(err, "invalid access list entry address %d", j)
 		}
@@ -53,7 +53,7 @@ func NewAccessListFromRLP(v rlp.Value) (AccessList, error) {
 			// 2nd item is the storage keys
 			accessList[j].StorageKeys = make([]Data32, len(accessRLP.List[1].List))
 			for k, key := range accessRLP.List[1].List {
-				d, err := NewData32(key.String)
+				d, err := NewData32(key.AsHex())
 				if err != nil {
 					return nil, errors.Wrapf(err, "invalid access list entry %d storage key %d", j, k)
 				}
@@ -0,0 +1,125 @@
+package eth
+
+import (
+	"math"
+
+	"github.com/pkg/errors"
+
+	"github.com/INFURA/go-ethlibs/rlp"
+)
+
+// SigningHash returns the hash signed by the authority of an EIP-7702 authorization, which is
+// keccak256(0x05 || rlp([chain_id, address, nonce])).
+func (a *SetCodeAuthorization) SigningHash() (*Hash, error) {
+	if a.ChainID == nil {
+		return nil, errors.New("chainId is required, use 0 to authorize on every chain")
+	}
+
+	message := rlp.Value{List: []rlp.Value{
+		a.ChainID.RLP(),
+		a.Address.RLP(),
+		a.Nonce.RLP(),
+	}}
+
+	encoded, err := message.Encode()
+	if err != nil {
+		return nil, err
+	}
+
+	preimage, err := NewData("0x05" + encoded[2:])
+	if err != nil {
+		return nil, err
+	}
+
+	h := preimage.Hash()
+	return &h, nil
+}
+
+// Sign signs the authorization with the bytes of a private key, updating its V, R and S values.
+func (a *SetCodeAuthorization) Sign(privKeyBytes []byte) error {
+	signer, err := NewKeySigner(privKeyBytes)
+	if err != nil {
+		return err
+	}
+
+	return a.SignWith(signer)
+}
+
+// SignWith signs the authorization with the signer, updating its V, R and S values.  The signer becomes the authority
+// whose account delegates to the authorization's Address.
+func (a *SetCodeAuthorization) SignWith(signer Signer) error {
+	hash, err := a.SigningHash()
+	if err != nil {
+		return err
+	}
+
+	signature, err := signer.SignHash(*hash)
+	if err != nil {
+		return err
+	}
+
+	a.R, a.S, a.V = signature.EIP2718Values()
+
+	// recover the authority as well, which must be the signer
+	authority, err := a.Authority()
+	if err != nil {
+		return errors.Wrap(err, "could not recover authority")
+	}
+
+	if authority.String() != ToChecksumAddress(signer.Address().String()) {
+		return errors.Errorf("authority %s does not match signer %s", authority, signer.Address())
+	}
+
+	return nil
+}
+
+// Authority returns the address of the account which signed the authorization, or an error if its signature is
+// invalid, including signatures with a high s value which EIP-7702 rejects.
+func (a *SetCodeAuthorization) Authority() (*Address, error) {
+	if err := a.validateSignature(); err != nil {
+		return nil, err
+	}
+
+	hash, err := a.SigningHash()
+	if err != nil {
+		return nil, err
+	}
+
+	return ECRecover(hash, &a.R, &a.S, &a.V)
+}
+
+// Validate checks the authorization the way EIP-7702 clients do before applying it on the chain with chainId: its
+// chain id must be 0 or chainId, its nonce must be below 2^64-1, and its signature must be valid with a low s value.
+// Authorizations failing validation are skipped by clients rather than invalidating their transaction.
+func (a *SetCodeAuthorization) Validate(chainId Quantity) error {
+	if a.ChainID == nil {
+		return errors.New("chainId is required")
+	}
+
+	if a.ChainID.Big().Sign() != 0 && a.ChainID.Big().Cmp(chainId.Big()) != 0 {
+		return errors.Errorf("authorization chainId %s does not match chain %s", a.ChainID, chainId.String())
+	}
+
+	if !a.Nonce.Big().IsUint64() || a.Nonce.Big().Uint64() == math.MaxUint64 {
+		return errors.Errorf("authorization nonce %s is too large", a.Nonce.String())
+	}
+
+	return a.validateSignature()
+}
+
+func (a *SetCodeAuthorization) validateSignature() error {
+	if v := a.V.Big(); !v.IsInt64() || (v.Int64() != 0 && v.Int64() != 1) {
+		return errors.Errorf("authorization yParity must be 0x0 or 0x1, not %s", a.V.String())
+	}
+
+	r, s := a.R.Big(), a.S.Big()
+	if r.Sign() <= 0 || r.Cmp(secp256k1N) >= 0 {
+		return errors.New("authorization r value is out of range")
+	}
+
+	if s.Sign() <= 0 || s.Cmp(secp256k1HalfN) > 0 {
+		return errors.New("authorization s value must be in the lower half of the curve order")
+	}
+
+	return nil
+}
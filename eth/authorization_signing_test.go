@@ -0,0 +1,119 @@
+package eth_test
+
+import (
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/sha3"
+
+	"github.com/INFURA/go-ethlibs/eth"
+)
+
+func TestSetCodeAuthorization_SigningHash(t *testing.T) {
+	auth := eth.SetCodeAuthorization{
+		ChainID: eth.MustQuantity("0x1"),
+		Address: *eth.MustAddress("0x000000000000000000000000000000000000aaaa"),
+		Nonce:   eth.QuantityFromInt64(0x1),
+	}
+
+	// 0x05 || rlp([1, 0x...aaaa, 1])
+	preimage, err := hex.DecodeString("05d70194000000000000000000000000000000000000aaaa01")
+	require.NoError(t, err)
+	hash := sha3.NewLegacyKeccak256()
+	hash.Write(preimage)
+
+	actual, err := auth.SigningHash()
+	require.NoError(t, err)
+	require.Equal(t, "0x"+hex.EncodeToString(hash.Sum(nil)), actual.String())
+
+	auth.ChainID = nil
+	_, err = auth.SigningHash()
+	require.Error(t, err)
+}
+
+func TestSetCodeAuthorization_Sign(t *testing.T) {
+	signer, err := eth.NewKeySignerFromHex("0xfad9c8855b740a0b7ed4c221dbad0f33a83a49cad6b3fe8d5817ac83d38b6a19")
+	require.NoError(t, err)
+
+	for _, chainId := range []string{"0x0", "0x1"} {
+		auth := eth.SetCodeAuthorization{
+			ChainID: eth.MustQuantity(chainId),
+			Address: *eth.MustAddress("0x000000000000000000000000000000000000aaaa"),
+			Nonce:   eth.QuantityFromInt64(7),
+		}
+
+		require.NoError(t, auth.SignWith(signer))
+		require.NoError(t, auth.Validate(eth.QuantityFromInt64(1)))
+
+		authority, err := auth.Authority()
+		require.NoError(t, err)
+		require.Equal(t, signer.Address(), *authority)
+
+		// the signature survives an RLP round trip
+		list := eth.AuthorizationList{auth}
+		decoded, err := eth.NewAuthorizationListFromRLP(list.RLP())
+		require.NoError(t, err)
+
+		authority, err = decoded[0].Authority()
+		require.NoError(t, err)
+		require.Equal(t, signer.Address(), *authority)
+
+		// changing any signed field changes the authority
+		decoded[0].Nonce = eth.QuantityFromInt64(8)
+		authority, err = decoded[0].Authority()
+		require.NoError(t, err)
+		require.NotEqual(t, signer.Address(), *authority)
+	}
+
+	// signers aren't required to return checksummed addresses
+	auth := eth.SetCodeAuthorization{
+		ChainID: eth.MustQuantity("0x1"),
+		Address: *eth.MustAddress("0x000000000000000000000000000000000000aaaa"),
+		Nonce:   eth.QuantityFromInt64(7),
+	}
+	require.NoError(t, auth.SignWith(lowercaseSigner{signer}))
+}
+
+// lowercaseSigner returns the address of the signer in lowercase, like signers which don't checksum addresses.
+type lowercaseSigner struct {
+	*eth.KeySigner
+}
+
+func (s lowercaseSigner) Address() eth.Address {
+	return eth.Address(strings.ToLower(s.KeySigner.Address().String()))
+}
+
+func TestSetCodeAuthorization_Validate(t *testing.T) {
+	signer, err := eth.NewKeySignerFromHex("0xfad9c8855b740a0b7ed4c221dbad0f33a83a49cad6b3fe8d5817ac83d38b6a19")
+	require.NoError(t, err)
+
+	auth := eth.SetCodeAuthorization{
+		ChainID: eth.MustQuantity("0x5"),
+		Address: *eth.MustAddress("0x000000000000000000000000000000000000aaaa"),
+		Nonce:   eth.QuantityFromInt64(0),
+	}
+	require.NoError(t, auth.SignWith(signer))
+
+	require.NoError(t, auth.Validate(eth.QuantityFromInt64(5)))
+	require.Error(t, auth.Validate(eth.QuantityFromInt64(1)), "authorizations for other chains are invalid")
+
+	// the high-s twin of a valid signature recovers the same key, but EIP-7702 rejects it
+	n, _ := new(big.Int).SetString("fffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364141", 16)
+	highS := auth
+	highS.S = eth.QuantityFromBigInt(new(big.Int).Sub(n, auth.S.Big()))
+	highS.V = eth.QuantityFromInt64(1 - auth.V.Int64())
+	require.Error(t, highS.Validate(eth.QuantityFromInt64(5)))
+	_, err = highS.Authority()
+	require.Error(t, err)
+
+	badV := auth
+	badV.V = eth.QuantityFromInt64(27)
+	require.Error(t, badV.Validate(eth.QuantityFromInt64(5)))
+
+	maxNonce := auth
+	maxNonce.Nonce = eth.QuantityFromBigInt(new(big.Int).SetUint64(^uint64(0)))
+	require.Error(t, maxNonce.Validate(eth.QuantityFromInt64(5)))
+}
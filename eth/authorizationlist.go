@@ -52,7 +52,7 @@ func NewAuthorizationListFromRLP(v rlp.Value) (AuthorizationList, error) {
 		if err != nil {
 			return nil, errors.Wrapf(err, "invalid authorization %d chain ID", i)
 		}
-		address, err := NewAddress(authorization[1].String)
+		address, err := NewAddress(authorization[1].AsHex())
 		if err != nil {
 			return nil, errors.Wrapf(err, "invalid authorization %d address", i)
 		}
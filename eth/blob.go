@@ -0,0 +1,135 @@
+package eth
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"math/big"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// FieldElementsPerBlob and BytesPerFieldElement are the dimensions of an EIP-4844 blob, whose size is BlobSize.
+	FieldElementsPerBlob = 4096
+	BytesPerFieldElement = 32
+	BlobSize             = FieldElementsPerBlob * BytesPerFieldElement
+
+	// KZGCommitmentSize and KZGProofSize are the sizes of compressed BLS12-381 G1 points.
+	KZGCommitmentSize = 48
+	KZGProofSize      = 48
+
+	// VersionedHashVersionKZG is the version byte of versioned hashes of KZG commitments.
+	VersionedHashVersionKZG = byte(0x01)
+)
+
+// blsModulus is the order of the BLS12-381 scalar field, every field element of a blob must be below it.
+var blsModulus, _ = new(big.Int).SetString("73eda753299d7d483339d80809a1d80553bda402fffe5bfeffffffff00000001", 16)
+
+// KZGVerifier verifies the KZG proof that a blob matches its commitment, as the verify_blob_kzg_proof function of
+// EIP-4844 does.  The kzg package implements it with go-kzg-4844 and the mainnet trusted setup, others can wrap a
+// different KZG library such as c-kzg-4844.
+type KZGVerifier interface {
+	VerifyBlobKZGProof(blob []byte, commitment []byte, proof []byte) error
+}
+
+// KZGToVersionedHash returns the versioned hash of a KZG commitment, which is 0x01 || sha256(commitment)[1:].
+func KZGToVersionedHash(commitment Data) (*Hash, error) {
+	b := commitment.Bytes()
+	if len(b) != KZGCommitmentSize {
+		return nil, errors.Errorf("commitment must be %d bytes long, not %d", KZGCommitmentSize, len(b))
+	}
+
+	sum := sha256.Sum256(b)
+	sum[0] = VersionedHashVersionKZG
+	h := Hash(fmt.Sprintf("0x%x", sum[:]))
+	return &h, nil
+}
+
+// VersionedHashes returns the versioned hashes of the commitments of the bundle.
+func (b *BlobsBundleV1) VersionedHashes() (Hashes, error) {
+	hashes := make(Hashes, len(b.Commitments))
+	for i := range b.Commitments {
+		h, err := KZGToVersionedHash(b.Commitments[i])
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid commitment %d", i)
+		}
+		hashes[i] = *h
+	}
+
+	return hashes, nil
+}
+
+// Verify checks that the bundle is well-formed, with as many blobs as commitments and proofs, each of the right size
+// and with every field element of the blobs below the BLS12-381 modulus.  The proof of each blob is then verified with
+// the verifier, which is required.
+func (b *BlobsBundleV1) Verify(verifier KZGVerifier) error {
+	if verifier == nil {
+		return errors.New("a KZG verifier is required to verify the blob proofs")
+	}
+
+	if len(b.Blobs) != len(b.Commitments) || len(b.Blobs) != len(b.Proofs) {
+		return errors.Errorf("bundle has %d blobs, %d commitments and %d proofs", len(b.Blobs), len(b.Commitments), len(b.Proofs))
+	}
+
+	for i := range b.Blobs {
+		blob, commitment, proof := b.Blobs[i].Bytes(), b.Commitments[i].Bytes(), b.Proofs[i].Bytes()
+		if len(blob) != BlobSize {
+			return errors.Errorf("blob %d must be %d bytes long, not %d", i, BlobSize, len(blob))
+		}
+		if len(commitment) != KZGCommitmentSize {
+			return errors.Errorf("commitment %d must be %d bytes long, not %d", i, KZGCommitmentSize, len(commitment))
+		}
+		if len(proof) != KZGProofSize {
+			return errors.Errorf("proof %d must be %d bytes long, not %d", i, KZGProofSize, len(proof))
+		}
+
+		element := new(big.Int)
+		for j := 0; j < FieldElementsPerBlob; j++ {
+			element.SetBytes(blob[j*BytesPerFieldElement : (j+1)*BytesPerFieldElement])
+			if element.Cmp(blsModulus) >= 0 {
+				return errors.Errorf("blob %d field element %d is not canonical", i, j)
+			}
+		}
+
+		if err := verifier.VerifyBlobKZGProof(blob, commitment, proof); err != nil {
+			return errors.Wrapf(err, "invalid proof for blob %d", i)
+		}
+	}
+
+	return nil
+}
+
+// VerifyBlobBundle checks that the blobs of a blob transaction decoded from its network representation match the
+// transaction: the versioned hashes of the commitments must be the transaction's BlobVersionedHashes, and the bundle
+// must pass BlobsBundleV1.Verify with the verifier.
+func (t *Transaction) VerifyBlobBundle(verifier KZGVerifier) error {
+	if t.TransactionType() != TransactionTypeBlob {
+		return errors.New("not a blob transaction")
+	}
+
+	if t.BlobBundle == nil {
+		return errors.New("blob transaction has no blobs")
+	}
+
+	if len(t.BlobVersionedHashes) == 0 {
+		return errors.New("blob transaction has no blob versioned hashes")
+	}
+
+	if len(t.BlobVersionedHashes) != len(t.BlobBundle.Commitments) {
+		return errors.Errorf("transaction has %d blob versioned hashes but %d commitments", len(t.BlobVersionedHashes), len(t.BlobBundle.Commitments))
+	}
+
+	hashes, err := t.BlobBundle.VersionedHashes()
+	if err != nil {
+		return err
+	}
+
+	for i := range hashes {
+		if !bytes.Equal(hashes[i].Bytes(), t.BlobVersionedHashes[i].Bytes()) {
+			return errors.Errorf("blob versioned hash %d is %s, but commitment hashes to %s", i, t.BlobVersionedHashes[i], hashes[i])
+		}
+	}
+
+	return t.BlobBundle.Verify(verifier)
+}
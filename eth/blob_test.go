@@ -0,0 +1,104 @@
+package eth_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/INFURA/go-ethlibs/eth"
+	"github.com/INFURA/go-ethlibs/eth/kzg"
+)
+
+// zeroBlobTransaction decodes the network representation of a blob transaction with a single blob of zeros, whose
+// commitment and proof are the point at infinity, see TestTransaction_FromRawEIP4844.
+func zeroBlobTransaction(t *testing.T) *eth.Transaction {
+	raw := `0x03fa020125f8b7010516058261a894030405000000000000000000000000000000000063b20000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000c00fe1a0010657f37554c781402a22917dee2f75def7ab966d7b770905398eba3c44401401a069263c625a202b369d6a507123da6da09913411a798569800ab6acc8ea025fc8a07a55f584d158ece16253c9c4c932d07eb8bdff4870076c9b7e37aecd074e3528fa020004ba020000` +
+		strings.Repeat(`00`, 131072) +
+		strings.Repeat(`f1b0c00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000`, 2)
+
+	tx := eth.Transaction{}
+	require.NoError(t, tx.FromRaw(raw))
+	return &tx
+}
+
+type kzgVerifierFunc func(blob, commitment, proof []byte) error
+
+func (f kzgVerifierFunc) VerifyBlobKZGProof(blob, commitment, proof []byte) error {
+	return f(blob, commitment, proof)
+}
+
+func TestKZGToVersionedHash(t *testing.T) {
+	h, err := eth.KZGToVersionedHash(eth.Data("0xc0" + strings.Repeat("00", 47)))
+	require.NoError(t, err)
+	require.Equal(t, "0x010657f37554c781402a22917dee2f75def7ab966d7b770905398eba3c444014", h.String())
+
+	_, err = eth.KZGToVersionedHash(eth.Data("0xc0"))
+	require.Error(t, err)
+}
+
+func TestTransaction_VerifyBlobBundle(t *testing.T) {
+	tx := zeroBlobTransaction(t)
+
+	hashes, err := tx.BlobBundle.VersionedHashes()
+	require.NoError(t, err)
+	require.Equal(t, tx.BlobVersionedHashes, hashes)
+
+	calls := 0
+	verifier := kzgVerifierFunc(func(blob, commitment, proof []byte) error {
+		calls++
+		require.Len(t, blob, eth.BlobSize)
+		require.Len(t, commitment, eth.KZGCommitmentSize)
+		require.Len(t, proof, eth.KZGProofSize)
+		return nil
+	})
+
+	require.NoError(t, tx.VerifyBlobBundle(verifier))
+	require.Equal(t, 1, calls)
+
+	// the proof of the blob of zeros is valid under the mainnet trusted setup
+	mainnet, err := kzg.MainnetVerifier()
+	require.NoError(t, err)
+	require.NoError(t, tx.VerifyBlobBundle(mainnet))
+
+	// and a valid point which isn't the proof of the blob is rejected
+	tx.BlobBundle.Proofs[0] = eth.Data("0x97f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb")
+	require.Error(t, tx.VerifyBlobBundle(mainnet))
+
+	// a verifier is required
+	require.Error(t, tx.VerifyBlobBundle(nil))
+
+	// proof failures are reported
+	failing := kzgVerifierFunc(func(blob, commitment, proof []byte) error {
+		return errors.New("bad proof")
+	})
+	require.EqualError(t, tx.VerifyBlobBundle(failing), "invalid proof for blob 0: bad proof")
+
+	// versioned hashes must match the commitments
+	tx = zeroBlobTransaction(t)
+	tx.BlobVersionedHashes[0] = eth.Hash("0x01" + strings.Repeat("00", 31))
+	require.Error(t, tx.VerifyBlobBundle(verifier))
+
+	tx = zeroBlobTransaction(t)
+	tx.BlobVersionedHashes = append(tx.BlobVersionedHashes, tx.BlobVersionedHashes[0])
+	require.Error(t, tx.VerifyBlobBundle(verifier))
+
+	// blobs must have the right size and canonical field elements
+	tx = zeroBlobTransaction(t)
+	tx.BlobBundle.Blobs[0] = eth.Data("0x" + strings.Repeat("00", 1024))
+	require.Error(t, tx.VerifyBlobBundle(verifier))
+
+	tx = zeroBlobTransaction(t)
+	tx.BlobBundle.Blobs[0] = eth.Data("0x" + strings.Repeat("ff", 32) + strings.Repeat("00", eth.BlobSize-32))
+	require.EqualError(t, tx.VerifyBlobBundle(verifier), "blob 0 field element 0 is not canonical")
+
+	tx = zeroBlobTransaction(t)
+	tx.BlobBundle.Proofs = nil
+	require.Error(t, tx.VerifyBlobBundle(verifier))
+
+	// and transactions decoded without their blobs can't be verified
+	tx = zeroBlobTransaction(t)
+	tx.BlobBundle = nil
+	require.Error(t, tx.VerifyBlobBundle(verifier))
+}
@@ -39,6 +39,9 @@ type Block struct {
 	ExcessBlobGas *Quantity `json:"excessBlobGas,omitempty"`
 	BlobGasUsed   *Quantity `json:"blobGasUsed,omitempty"`
 
+	// EIP-7685 Execution Layer Requests
+	RequestsHash *Hash `json:"requestsHash,omitempty"`
+
 	// Ethhash POW Fields
 	Nonce   *Data8 `json:"nonce"`
 	MixHash *Data  `json:"mixHash"`
@@ -52,6 +55,9 @@ type Block struct {
 
 	// Track the flavor so we can re-encode correctly
 	flavor string
+
+	// The RLP encoded uncle headers when decoded with FromRaw, so that RawRepresentation can re-encode them
+	uncleHeaders []Data
 }
 
 func (b *Block) DepopulateTransactions() {
@@ -132,6 +138,9 @@ func (b Block) MarshalJSON() ([]byte, error) {
 				ExcessBlobGas *Quantity `json:"excessBlobGas,omitempty"`
 				BlobGasUsed   *Quantity `json:"blobGasUsed,omitempty"`
 
+				// EIP-7685 Execution Layer Requests
+				RequestsHash *Hash `json:"requestsHash,omitempty"`
+
 				Nonce   *Data8 `json:"nonce"`
 				MixHash *Data  `json:"mixHash"`
 			}
@@ -163,6 +172,7 @@ func (b Block) MarshalJSON() ([]byte, error) {
 				ParentBeaconBlockRoot: b.ParentBeaconBlockRoot,
 				ExcessBlobGas:         b.ExcessBlobGas,
 				BlobGasUsed:           b.BlobGasUsed,
+				RequestsHash:          b.RequestsHash,
 			}
 
 			return json.Marshal(&w)
@@ -1,6 +1,9 @@
 package eth
 
 import (
+	"encoding/hex"
+	"strings"
+
 	"github.com/pkg/errors"
 
 	"github.com/INFURA/go-ethlibs/rlp"
@@ -8,8 +11,17 @@ import (
 
 // FromRaw populates Block fields from the RLP-encoded raw block input string.
 func (b *Block) FromRaw(input string) error {
-	// Decode the input string as an rlp.Value
-	decoded, err := rlp.From(input)
+	if !strings.HasPrefix(input, "0x") {
+		return errors.New("input must start with 0x")
+	}
+
+	raw, err := hex.DecodeString(input[2:])
+	if err != nil {
+		return errors.Wrap(err, "could not decode raw block hex")
+	}
+
+	// Decode the input bytes as an rlp.Value
+	decoded, err := rlp.FromBytes(raw)
 	if err != nil {
 		return errors.Wrap(err, "could not RLP decode raw input")
 	}
@@ -41,8 +53,10 @@ func (b *Block) FromRaw(input string) error {
 	//   - 15 items for legacy pre-London blocks
 	//   - 16 items for EIP-1559 London blocks
 	//   - 17 items for EIP-4895 Shanghai blocks
+	//   - 20 items for EIP-4844 and EIP-4788 Cancun blocks
+	//   - 21 items for EIP-7685 Prague blocks
 	switch len(header) {
-	case 15, 16, 17:
+	case 15, 16, 17, 20, 21:
 	default:
 		return errors.Errorf("unexpected decoded header list size %d", len(header))
 	}
@@ -55,16 +69,16 @@ func (b *Block) FromRaw(input string) error {
 			Index:     &index,
 		}
 		// Each transaction in the txs RLP list is either an opaque binary blob (an EIP-2718 tx) or itself an RLP list
-		// (a legacy pre-2718 transaction).  2718 transactions can be passed to Transaction.FromRaw as is, legacy
-		// transactions need to be converted to raw blobs via rlp.Value.Encode first.
-		rawTx := txRlp.String
+		// (a legacy pre-2718 transaction).  2718 transactions can be decoded as is, legacy transactions need to be
+		// converted to raw blobs via rlp.Value.EncodeToBytes first.
+		rawTx := txRlp.Bytes
 		if txRlp.IsList() {
-			rawTx, err = txRlp.Encode()
+			rawTx, err = txRlp.EncodeToBytes()
 			if err != nil {
 				return errors.Wrap(err, "could not re-encode transaction")
 			}
 		}
-		if err := tx.FromRaw(rawTx); err != nil {
+		if err := tx.fromRawBytes(rawTx, rlp.Options{}); err != nil {
 			return errors.Wrap(err, "could not decode transaction")
 		}
 
@@ -75,6 +89,7 @@ func (b *Block) FromRaw(input string) error {
 	}
 
 	uncleHashes := make([]Hash, len(uncles))
+	uncleHeaders := make([]Data, len(uncles))
 	for i, u := range uncles {
 		h, err := u.Hash()
 		if err != nil {
@@ -85,6 +100,12 @@ func (b *Block) FromRaw(input string) error {
 		} else {
 			return errors.Wrap(err, "could not encode uncle to hash")
 		}
+
+		encoded, err := u.Encode()
+		if err != nil {
+			return errors.Wrap(err, "could not encode uncle RLP data")
+		}
+		uncleHeaders[i] = Data(encoded)
 	}
 
 	withdrawals := make([]Withdrawal, 0)
@@ -98,7 +119,7 @@ func (b *Block) FromRaw(input string) error {
 			if err != nil {
 				return errors.Wrap(err, "could not decode withdrawals")
 			}
-			address, err := NewAddress(withdrawalRlp.List[2].String)
+			address, err := NewAddress(withdrawalRlp.List[2].AsHex())
 			if err != nil {
 				return errors.Wrap(err, "could not decode withdrawals")
 			}
@@ -117,49 +138,49 @@ func (b *Block) FromRaw(input string) error {
 	}
 
 	// ParentHash
-	if p, err := NewHash(header[0].String); err == nil {
+	if p, err := NewHash(header[0].AsHex()); err == nil {
 		b.ParentHash = *p
 	} else {
 		return errors.Wrap(err, "could not convert header field 0 to ParentHash")
 	}
 
 	// SHA3Uncles
-	if u, err := NewHash(header[1].String); err == nil {
+	if u, err := NewHash(header[1].AsHex()); err == nil {
 		b.SHA3Uncles = *u
 	} else {
 		return errors.Wrap(err, "could not convert header field 1 to SHA3Uncles")
 	}
 
 	// Miner
-	if m, err := NewAddress(header[2].String); err == nil {
+	if m, err := NewAddress(header[2].AsHex()); err == nil {
 		b.Miner = *m
 	} else {
 		return errors.Wrap(err, "could not convert header field 2 to Miner")
 	}
 
 	// StateRoot
-	if s, err := NewData32(header[3].String); err == nil {
+	if s, err := NewData32(header[3].AsHex()); err == nil {
 		b.StateRoot = *s
 	} else {
 		return errors.Wrap(err, "could not convert header field 3 to StateRoot")
 	}
 
 	// TransactionsRoot
-	if t, err := NewData32(header[4].String); err == nil {
+	if t, err := NewData32(header[4].AsHex()); err == nil {
 		b.TransactionsRoot = *t
 	} else {
 		return errors.Wrap(err, "could not convert header field 4 to TransactionsRoot")
 	}
 
 	// ReceiptsRoot
-	if r, err := NewData32(header[5].String); err == nil {
+	if r, err := NewData32(header[5].AsHex()); err == nil {
 		b.ReceiptsRoot = *r
 	} else {
 		return errors.Wrap(err, "could not convert header field 5 to ReceiptsRoot")
 	}
 
 	// LogsBloom
-	if l, err := NewData256(header[6].String); err == nil {
+	if l, err := NewData256(header[6].AsHex()); err == nil {
 		b.LogsBloom = *l
 	} else {
 		return errors.Wrap(err, "could not convert header field 6 to LogsBloom")
@@ -205,21 +226,21 @@ func (b *Block) FromRaw(input string) error {
 	}
 
 	// ExtraData
-	if d, err := NewData(header[12].String); err == nil {
+	if d, err := NewData(header[12].AsHex()); err == nil {
 		b.ExtraData = *d
 	} else {
 		return errors.Wrap(err, "could not convert header field 12 to ExtraData")
 	}
 
 	// MixHash
-	if d, err := NewData(header[13].String); err == nil {
+	if d, err := NewData(header[13].AsHex()); err == nil {
 		b.MixHash = d
 	} else {
 		return errors.Wrap(err, "could not convert header field 13 to MixHash")
 	}
 
 	// Nonce
-	if n, err := NewData8(header[14].String); err == nil {
+	if n, err := NewData8(header[14].AsHex()); err == nil {
 		b.Nonce = n
 	} else {
 		return errors.Wrap(err, "could not convert header field 14 to Nonce")
@@ -236,7 +257,7 @@ func (b *Block) FromRaw(input string) error {
 
 	// WithdrawalsRoot (EIP-4895 enabled Shanghai blocks)
 	if len(header) >= 17 {
-		d, err := NewData32(header[16].String)
+		d, err := NewData32(header[16].AsHex())
 		if err != nil {
 			return errors.Wrap(err, "could not convert header field 16 to WithdrawalsRoot")
 		}
@@ -244,8 +265,122 @@ func (b *Block) FromRaw(input string) error {
 		b.Withdrawals = withdrawals
 	}
 
+	// BlobGasUsed, ExcessBlobGas (EIP-4844) and ParentBeaconBlockRoot (EIP-4788) for Cancun blocks
+	if len(header) >= 20 {
+		q, err := NewQuantityFromRLP(header[17])
+		if err != nil {
+			return errors.Wrap(err, "could not convert header field 17 to BlobGasUsed")
+		}
+		b.BlobGasUsed = q
+
+		q, err = NewQuantityFromRLP(header[18])
+		if err != nil {
+			return errors.Wrap(err, "could not convert header field 18 to ExcessBlobGas")
+		}
+		b.ExcessBlobGas = q
+
+		h, err := NewHash(header[19].AsHex())
+		if err != nil {
+			return errors.Wrap(err, "could not convert header field 19 to ParentBeaconBlockRoot")
+		}
+		b.ParentBeaconBlockRoot = h
+	}
+
+	// RequestsHash (EIP-7685 enabled Prague blocks)
+	if len(header) >= 21 {
+		h, err := NewHash(header[20].AsHex())
+		if err != nil {
+			return errors.Wrap(err, "could not convert header field 20 to RequestsHash")
+		}
+		b.RequestsHash = h
+	}
+
 	b.Hash = hash
 	b.Uncles = uncleHashes
+	b.uncleHeaders = uncleHeaders
 	b.Transactions = transactions
 	return nil
 }
+
+// RawRepresentation returns the block RLP encoded as it would be returned by debug_getRawBlock, which is the inverse
+// of FromRaw.  The block must have been fetched with its transactions populated, and since JSON blocks only carry the
+// hashes of their uncles a block with uncles can only be encoded if it was decoded with FromRaw.
+func (b *Block) RawRepresentation() (*Data, error) {
+	header, err := b.HeaderRLP()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not encode block header")
+	}
+
+	uncles, err := b.uncleHeadersRLP()
+	if err != nil {
+		return nil, err
+	}
+
+	txs := rlp.Value{List: make([]rlp.Value, 0, len(b.Transactions))}
+	for i := range b.Transactions {
+		if !b.Transactions[i].Populated {
+			return nil, errors.New("block transactions must be populated to encode the block")
+		}
+
+		raw, err := b.Transactions[i].RawRepresentation()
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not encode transaction %d", i)
+		}
+
+		// legacy transactions are embedded as RLP lists, while EIP-2718 transactions are embedded as opaque strings
+		if b.Transactions[i].TransactionType() == TransactionTypeLegacy {
+			decoded, err := rlp.FromBytes(raw.Bytes())
+			if err != nil {
+				return nil, errors.Wrapf(err, "could not decode transaction %d", i)
+			}
+			txs.List = append(txs.List, *decoded)
+		} else {
+			txs.List = append(txs.List, raw.RLP())
+		}
+	}
+
+	block := rlp.Value{List: []rlp.Value{
+		*header,
+		txs,
+		*uncles,
+	}}
+
+	// Withdrawals (EIP-4895 enabled Shanghai blocks)
+	if b.WithdrawalsRoot != nil {
+		withdrawals := rlp.Value{List: make([]rlp.Value, 0, len(b.Withdrawals))}
+		for i := range b.Withdrawals {
+			withdrawals.List = append(withdrawals.List, b.Withdrawals[i].RLP())
+		}
+		block.List = append(block.List, withdrawals)
+	}
+
+	encoded, err := block.Encode()
+	if err != nil {
+		return nil, err
+	}
+
+	return NewData(encoded)
+}
+
+// uncleHeadersRLP returns the list of uncle headers of the block, which are only known when it was decoded with
+// FromRaw and must still match the block's uncle hashes.
+func (b *Block) uncleHeadersRLP() (*rlp.Value, error) {
+	if len(b.uncleHeaders) != len(b.Uncles) {
+		return nil, errors.New("cannot encode a block with uncles without their headers")
+	}
+
+	uncles := rlp.Value{List: make([]rlp.Value, 0, len(b.uncleHeaders))}
+	for i := range b.uncleHeaders {
+		if b.uncleHeaders[i].Hash() != b.Uncles[i] {
+			return nil, errors.Errorf("uncle header %d does not match uncle hash %s", i, b.Uncles[i].String())
+		}
+
+		decoded, err := rlp.FromBytes(b.uncleHeaders[i].Bytes())
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not decode uncle header %d", i)
+		}
+		uncles.List = append(uncles.List, *decoded)
+	}
+
+	return &uncles, nil
+}
@@ -64,6 +64,11 @@ func TestBlock_FromRaw(t *testing.T) {
 	// verify the call data of another tx since the first example didnt have any
 	tx0 := block.Transactions[0]
 	require.Equal(t, "0xa9059cbb0000000000000000000000005310850866bbf6637223e222cf27db17cc0d7881000000000000000000000000000000000000000000000a968163f0a57b400000", tx0.Input.String())
+
+	// re-encoding the decoded block should produce the original raw block
+	encoded, err := block.RawRepresentation()
+	require.NoError(t, err)
+	require.Equal(t, input, encoded.String())
 }
 
 func TestBlock_FromRawWithUncle(t *testing.T) {
@@ -75,6 +80,20 @@ func TestBlock_FromRawWithUncle(t *testing.T) {
 	require.Equal(t, uint64(9685083), block.Number.UInt64())
 	require.Equal(t, "0xb433a0919e648facefd7d74609294f4592a9470088fec64379e7a1ca2a7677e0", block.Hash.String())
 	require.Equal(t, "0x0cf5de8aa4aed686f0fc8691e42f9934b7315bcc4181d46af788aeb696be9801", block.ParentHash.String())
+
+	// the uncle headers are kept so the block can be re-encoded
+	encoded, err := block.RawRepresentation()
+	require.NoError(t, err)
+	require.Equal(t, input, encoded.String())
+
+	// but only the hashes of uncles are known in JSON, so the block cannot be re-encoded from it
+	b, err := json.Marshal(&block)
+	require.NoError(t, err)
+
+	fromJSON := eth.Block{}
+	require.NoError(t, json.Unmarshal(b, &fromJSON))
+	_, err = fromJSON.RawRepresentation()
+	require.Error(t, err)
 }
 
 func TestBlock_FromRaw_EIP2930(t *testing.T) {
@@ -141,6 +160,11 @@ func TestBlock_FromRaw_EIP2930(t *testing.T) {
 	require.Equal(t, expectedLastTx.S, lastTx.S)
 	require.Equal(t, expectedLastTx.ChainId, lastTx.ChainId)
 	require.Equal(t, *expectedLastTx.AccessList, *lastTx.AccessList)
+
+	// re-encoding the decoded block should produce the original raw block
+	encoded, err := block.RawRepresentation()
+	require.NoError(t, err)
+	require.Equal(t, raw, encoded.String())
 }
 
 func TestBlock_FromRaw_Aleut(t *testing.T) {
@@ -158,6 +182,11 @@ func TestBlock_FromRaw_Aleut(t *testing.T) {
 	require.Equal(t, uint64(55), block.Number.UInt64())
 	require.Equal(t, "0xa91a8b53fdfdc3473ddc1f1942756b4455a53307da935719c559177f80959eb6", block.Transactions[0].Hash.String())
 	require.Equal(t, "0x34ce2279ab20504ed0db3b1983c0f31511dc5dac1ad9325435abe3768ee36006", block.ParentHash.String())
+
+	// re-encoding the decoded block should produce the original raw block
+	encoded, err := block.RawRepresentation()
+	require.NoError(t, err)
+	require.Equal(t, raw, encoded.String())
 }
 
 func TestBlock_FromRaw_BaikalGenesis(t *testing.T) {
@@ -172,6 +201,11 @@ func TestBlock_FromRaw_BaikalGenesis(t *testing.T) {
 	require.Equal(t, "0xa0bc5d43c72a990cedeb59d305702602b34c3ee8585e77d03c7a4fa64d79636e", block.Hash.String())
 	require.Len(t, block.Transactions, 0)
 	require.Equal(t, uint64(0), block.Number.UInt64())
+
+	// re-encoding the decoded block should produce the original raw block
+	encoded, err := block.RawRepresentation()
+	require.NoError(t, err)
+	require.Equal(t, raw, encoded.String())
 }
 
 func TestBlockFromRaw_ErigonMerge(t *testing.T) {
@@ -199,6 +233,11 @@ func TestBlockFromRaw_ErigonMerge(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, int64(2), tx.ChainId.Int64())
 	require.Equal(t, "0x5d7c132dbcab7511b78de0fad3d4a37988b438bd50db5f77e63213b691dd1c1f", tx.Hash.String())
+
+	// re-encoding the decoded block should produce the original raw block
+	encoded, err := block.RawRepresentation()
+	require.NoError(t, err)
+	require.Equal(t, raw, encoded.String())
 }
 
 func TestBlock_FromRaw_ZhejiangBlock(t *testing.T) {
@@ -310,4 +349,14 @@ func TestBlock_FromRaw_ZhejiangBlock(t *testing.T) {
 			require.Equal(t, blockFromJSON.Withdrawals, b.Withdrawals)
 		})
 	})
+
+	t.Run("RawRepresentation", func(t *testing.T) {
+		fromRLP, err := blockFromRLP.RawRepresentation()
+		require.NoError(t, err)
+		require.Equal(t, rawRLP, fromRLP.String())
+
+		fromJSON, err := blockFromJSON.RawRepresentation()
+		require.NoError(t, err)
+		require.Equal(t, rawRLP, fromJSON.String())
+	})
 }
@@ -0,0 +1,236 @@
+package eth
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/INFURA/go-ethlibs/rlp"
+)
+
+// header holds the fields of a block that are part of its RLP encoded header, which is what the block hash is
+// computed from.
+type header struct {
+	ParentHash       Hash
+	SHA3Uncles       Data32
+	Miner            Address
+	StateRoot        Data32
+	TransactionsRoot Data32
+	ReceiptsRoot     Data32
+	LogsBloom        Data256
+	Difficulty       Quantity
+	Number           *Quantity
+	GasLimit         Quantity
+	GasUsed          Quantity
+	Timestamp        Quantity
+	ExtraData        Data
+	MixHash          *Data
+	Nonce            *Data8
+
+	// optional fields added by later forks, in the order they appear in the header
+	BaseFeePerGas         *Quantity
+	WithdrawalsRoot       *Data32
+	BlobGasUsed           *Quantity
+	ExcessBlobGas         *Quantity
+	ParentBeaconBlockRoot *Hash
+	RequestsHash          *Hash
+}
+
+// RLP returns the RLP encoding of the header.  Optional fork fields are included up to the last one that is set,
+// and none of the fields before it may be missing.
+func (h *header) RLP() (*rlp.Value, error) {
+	if h.Number == nil {
+		return nil, errors.New("header is missing number")
+	}
+
+	if h.MixHash == nil || h.Nonce == nil {
+		return nil, errors.New("header is missing mixHash or nonce")
+	}
+
+	value := rlp.Value{List: []rlp.Value{
+		h.ParentHash.RLP(),
+		h.SHA3Uncles.RLP(),
+		h.Miner.RLP(),
+		h.StateRoot.RLP(),
+		h.TransactionsRoot.RLP(),
+		h.ReceiptsRoot.RLP(),
+		h.LogsBloom.RLP(),
+		h.Difficulty.RLP(),
+		h.Number.RLP(),
+		h.GasLimit.RLP(),
+		h.GasUsed.RLP(),
+		h.Timestamp.RLP(),
+		h.ExtraData.RLP(),
+		h.MixHash.RLP(),
+		h.Nonce.RLP(),
+	}}
+
+	optional := []struct {
+		name  string
+		value *rlp.Value
+	}{
+		{"baseFeePerGas", nil},
+		{"withdrawalsRoot", nil},
+		{"blobGasUsed", nil},
+		{"excessBlobGas", nil},
+		{"parentBeaconBlockRoot", nil},
+		{"requestsHash", nil},
+	}
+
+	if h.BaseFeePerGas != nil {
+		v := h.BaseFeePerGas.RLP()
+		optional[0].value = &v
+	}
+	if h.WithdrawalsRoot != nil {
+		v := h.WithdrawalsRoot.RLP()
+		optional[1].value = &v
+	}
+	if h.BlobGasUsed != nil {
+		v := h.BlobGasUsed.RLP()
+		optional[2].value = &v
+	}
+	if h.ExcessBlobGas != nil {
+		v := h.ExcessBlobGas.RLP()
+		optional[3].value = &v
+	}
+	if h.ParentBeaconBlockRoot != nil {
+		v := h.ParentBeaconBlockRoot.RLP()
+		optional[4].value = &v
+	}
+	if h.RequestsHash != nil {
+		v := h.RequestsHash.RLP()
+		optional[5].value = &v
+	}
+
+	last := -1
+	for i := range optional {
+		if optional[i].value != nil {
+			last = i
+		}
+	}
+
+	for i := 0; i <= last; i++ {
+		if optional[i].value == nil {
+			return nil, errors.Errorf("header is missing %s which is required by %s", optional[i].name, optional[last].name)
+		}
+		value.List = append(value.List, *optional[i].value)
+	}
+
+	return &value, nil
+}
+
+// Hash returns the keccak256 hash of the RLP encoded header.
+func (h *header) Hash() (*Hash, error) {
+	value, err := h.RLP()
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := value.Hash()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not hash header")
+	}
+
+	return NewHash(s)
+}
+
+// verifyHash compares the hash computed from h with the hash reported for it.
+func verifyHash(h *header, reported Hash) error {
+	computed, err := h.Hash()
+	if err != nil {
+		return err
+	}
+
+	if !strings.EqualFold(computed.String(), reported.String()) {
+		return errors.Errorf("block hash %s does not match computed header hash %s", reported, computed)
+	}
+
+	return nil
+}
+
+func (b *Block) header() *header {
+	return &header{
+		ParentHash:            b.ParentHash,
+		SHA3Uncles:            b.SHA3Uncles,
+		Miner:                 b.Miner,
+		StateRoot:             b.StateRoot,
+		TransactionsRoot:      b.TransactionsRoot,
+		ReceiptsRoot:          b.ReceiptsRoot,
+		LogsBloom:             b.LogsBloom,
+		Difficulty:            b.Difficulty,
+		Number:                b.Number,
+		GasLimit:              b.GasLimit,
+		GasUsed:               b.GasUsed,
+		Timestamp:             b.Timestamp,
+		ExtraData:             b.ExtraData,
+		MixHash:               b.MixHash,
+		Nonce:                 b.Nonce,
+		BaseFeePerGas:         b.BaseFeePerGas,
+		WithdrawalsRoot:       b.WithdrawalsRoot,
+		BlobGasUsed:           b.BlobGasUsed,
+		ExcessBlobGas:         b.ExcessBlobGas,
+		ParentBeaconBlockRoot: b.ParentBeaconBlockRoot,
+		RequestsHash:          b.RequestsHash,
+	}
+}
+
+// HeaderRLP returns the RLP encoded header of the block, including any optional fork fields that are set.
+func (b *Block) HeaderRLP() (*rlp.Value, error) {
+	return b.header().RLP()
+}
+
+// ComputeHash returns the block hash computed from the header fields of the block.
+func (b *Block) ComputeHash() (*Hash, error) {
+	return b.header().Hash()
+}
+
+// VerifyHash returns an error if the hash computed from the header fields of the block doesn't match its .Hash,
+// which means the fields were not reported consistently.
+func (b *Block) VerifyHash() error {
+	if b.Hash == nil {
+		return errors.New("block has no hash to verify")
+	}
+
+	return verifyHash(b.header(), *b.Hash)
+}
+
+func (nh *NewHeadsResult) header() *header {
+	return &header{
+		ParentHash:            nh.ParentHash,
+		SHA3Uncles:            nh.SHA3Uncles,
+		Miner:                 nh.Miner,
+		StateRoot:             nh.StateRoot,
+		TransactionsRoot:      nh.TransactionsRoot,
+		ReceiptsRoot:          nh.ReceiptsRoot,
+		LogsBloom:             nh.LogsBloom,
+		Difficulty:            nh.Difficulty,
+		Number:                &nh.Number,
+		GasLimit:              nh.GasLimit,
+		GasUsed:               nh.GasUsed,
+		Timestamp:             nh.Timestamp,
+		ExtraData:             nh.ExtraData,
+		MixHash:               nh.MixHash,
+		Nonce:                 nh.Nonce,
+		BaseFeePerGas:         nh.BaseFeePerGas,
+		WithdrawalsRoot:       nh.WithdrawalsRoot,
+		BlobGasUsed:           nh.BlobGasUsed,
+		ExcessBlobGas:         nh.ExcessBlobGas,
+		ParentBeaconBlockRoot: nh.ParentBeaconBlockRoot,
+		RequestsHash:          nh.RequestsHash,
+	}
+}
+
+// HeaderRLP returns the RLP encoded header of the new head, including any optional fork fields that are set.
+func (nh *NewHeadsResult) HeaderRLP() (*rlp.Value, error) {
+	return nh.header().RLP()
+}
+
+// ComputeHash returns the block hash computed from the header fields of the new head.
+func (nh *NewHeadsResult) ComputeHash() (*Hash, error) {
+	return nh.header().Hash()
+}
+
+// VerifyHash returns an error if the hash computed from the header fields of the new head doesn't match its .Hash.
+func (nh *NewHeadsResult) VerifyHash() error {
+	return verifyHash(nh.header(), nh.Hash)
+}
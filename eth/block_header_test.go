@@ -0,0 +1,171 @@
+package eth_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/INFURA/go-ethlibs/eth"
+	"github.com/INFURA/go-ethlibs/rlp"
+)
+
+func TestBlock_ComputeHash(t *testing.T) {
+	tests := []struct {
+		Name   string
+		Block  string
+		Fields int
+	}{
+		{
+			Name:   "mainnet frontier-era block",
+			Block:  `{"difficulty":"0x98657b1e19e81","extraData":"0x737061726b706f6f6c2d6574682d636e2d687a32","gasLimit":"0x7a121d","gasUsed":"0x4b85fa","hash":"0x774c34a19ff36b1d3669c54fe385502ad32862ed728da53b72b771f82c08b474","logsBloom":"0x040000000010005000208000100000000010020002040012020244000031000022000580002052000010280005000880021000020a1100100024000444280001801410809320014300800408800000034000000440680200004000014100a3108100102c0a0104004000004004000800080002200080440004002010400000000000001000220800000210000024800000400080200000000081080401600000060880000508001080040188102080010084010080800090c00040000004450000004002002001000408800096080000000100818202c0010000002a00202028e090210010000088800000868020800000204b0002814408000000001c002004","miner":"0x5a0b54d5dc17e0aadc383d2db43b0a0d3e029c4c","mixHash":"0xde5facfa3d7a83279235a85dd63f073237cea69e4de80792909b77f5aa1aec31","nonce":"0x7d89c94c00f655f7","number":"0x6e2e24","parentHash":"0xb297aa3999efd8af14e0c4abec26c2eadcb5b6600be37379ebb5fa23e1f29cfc","receiptsRoot":"0x65e1afb1fa83a7c34736fced95fd9f6729e169dd28dda0e669b5190a99531ee6","sha3Uncles":"0x1dcc4de8dec75d7aab85b567b6ccd41ad312451b948a7413f0a142fd40d49347","stateRoot":"0x4ec8687bacabac1dc901e0353e27ac3aaa19b28b3c7ef42c6d49268205e2dc75","timestamp":"0x5c65d52d","transactions":[],"transactionsRoot":"0x1f11eec059a1be005f4d0122d1c8afb9ce3fe2b6bdc4f0e94bc3edba5884e3b9","uncles":[]}`,
+			Fields: 15,
+		},
+		{
+			Name:   "baikal london block",
+			Block:  `{"baseFeePerGas":"0x7","difficulty":"0x2","extraData":"0x00000000000000000000000000000000000000000000000000000000000000009d3524c9acd91e52c9636eff76173d1de58419611da303ee131bc4dae18ecba17a01174694cce18611bbd691b24f171d5a68ae208e43f792b105fdaf950963af00","gasLimit":"0x1c9c380","gasUsed":"0xcf1f","hash":"0xe47daeae74c521fc4a8e7fece9820bcb68a1d83382ef06700e67caba4245ef47","logsBloom":"0x00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000","miner":"0x0000000000000000000000000000000000000000","mixHash":"0x0000000000000000000000000000000000000000000000000000000000000000","nonce":"0x0000000000000000","number":"0x8496","parentHash":"0xd8c32ba3341e8a239c41390fd68fd51a76ded9a4cc0260918acc1a54e0f3315d","receiptsRoot":"0x340f7266a1624ed1d28caeed494df579e00e1e2355587d256c333b7f80ab1756","sha3Uncles":"0x1dcc4de8dec75d7aab85b567b6ccd41ad312451b948a7413f0a142fd40d49347","stateRoot":"0x93bfec7c3496021d3ff4674ad96ffe856e1f3cf1fed59770756ab4a074d0e535","timestamp":"0x60ab38f3","transactions":[],"transactionsRoot":"0x658830e11eab0d07fd04dfe524711e509111ad0b543bb310296cb67a638445e4","uncles":[]}`,
+			Fields: 16,
+		},
+		{
+			Name:   "zhejiang shanghai block",
+			Block:  `{"baseFeePerGas":"0x7","difficulty":"0x0","extraData":"0x","gasLimit":"0x1c9c380","gasUsed":"0x0","hash":"0x5affb899fa8ffaa05c5eb0b6a575c188e07b6e5a9e2bfacc68fef07c6e03e16d","logsBloom":"0x00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000","miner":"0xf97e180c050e5ab072211ad2c213eb5aee4df134","mixHash":"0x29826a39a321555d4c49136f75940cbf2905aa6d83eeabe4caf78459034c4c1c","nonce":"0x0000000000000000","number":"0xa80f","parentHash":"0xdee92882dee56c645f143c789ea73ce49fd4fcf0f32f33e761c27c992ea6fc7d","receiptsRoot":"0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421","sha3Uncles":"0x1dcc4de8dec75d7aab85b567b6ccd41ad312451b948a7413f0a142fd40d49347","stateRoot":"0x2a746996126dee2bdfad85143f6faa8577e7277ebfdbc8047e8f21e212cf624e","timestamp":"0x63e28a08","transactions":[],"transactionsRoot":"0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421","uncles":[],"withdrawalsRoot":"0xc209d0a9f422316d602b55ff3aa0b45d09883d1e835c3aea080754c5a12478c4"}`,
+			Fields: 17,
+		},
+		{
+			Name:   "dencun devnet cancun block",
+			Block:  `{"baseFeePerGas":"0x7","blobGasUsed":"0x60000","difficulty":"0x0","excessBlobGas":"0x0","extraData":"0x4e65746865726d696e64","gasLimit":"0x1c9c380","gasUsed":"0xf618","hash":"0xfc2715ff196e23ae613ed6f837abd9035329a720a1f4e8dce3b0694c867ba052","logsBloom":"0x00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000","miner":"0xf97e180c050e5ab072211ad2c213eb5aee4df134","mixHash":"0xfe22e918a42ab40a176372da0352271d7a8d206af1f0f81b4ae24e0366b294e1","nonce":"0x0000000000000000","number":"0x2a1cb","parentBeaconBlockRoot":"0x3e75ca617f5191780dc90f5054d192c29167813ca0e38b84b26c30ae8886998b","parentHash":"0x0efbec3f110f71016eabe050984405a0f5b5bf7d4c653aaeb876a2a83d7e2a95","receiptsRoot":"0x9af165447e5b3193e9ac8389418648ee6d6cb1d37459fe65cfc245fc358721bd","sha3Uncles":"0x1dcc4de8dec75d7aab85b567b6ccd41ad312451b948a7413f0a142fd40d49347","stateRoot":"0x4662ac8f239eb6b068a89d82db55fbd699bc883a43812a6ced13976f91c30b71","timestamp":"0x65004480","transactions":[],"transactionsRoot":"0xc5f62b8c7d89e8dce123a91ebe4fd2428f9960f13316e99ff4a8754bd8ee6fa8","uncles":[],"withdrawalsRoot":"0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421"}`,
+			Fields: 20,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			block := eth.Block{}
+			err := json.Unmarshal([]byte(tt.Block), &block)
+			require.NoError(t, err)
+
+			header, err := block.HeaderRLP()
+			require.NoError(t, err)
+			require.Len(t, header.List, tt.Fields)
+
+			h, err := block.ComputeHash()
+			require.NoError(t, err)
+			require.Equal(t, block.Hash.String(), h.String())
+			require.NoError(t, block.VerifyHash())
+
+			// any change to a header field should be detected
+			block.GasUsed = eth.QuantityFromUInt64(block.GasUsed.UInt64() + 1)
+			require.Error(t, block.VerifyHash())
+		})
+	}
+}
+
+func TestBlock_HeaderRLP_FromRaw(t *testing.T) {
+	cancun := `{"baseFeePerGas":"0x7","blobGasUsed":"0x60000","difficulty":"0x0","excessBlobGas":"0x0","extraData":"0x4e65746865726d696e64","gasLimit":"0x1c9c380","gasUsed":"0xf618","hash":"0xfc2715ff196e23ae613ed6f837abd9035329a720a1f4e8dce3b0694c867ba052","logsBloom":"0x00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000","miner":"0xf97e180c050e5ab072211ad2c213eb5aee4df134","mixHash":"0xfe22e918a42ab40a176372da0352271d7a8d206af1f0f81b4ae24e0366b294e1","nonce":"0x0000000000000000","number":"0x2a1cb","parentBeaconBlockRoot":"0x3e75ca617f5191780dc90f5054d192c29167813ca0e38b84b26c30ae8886998b","parentHash":"0x0efbec3f110f71016eabe050984405a0f5b5bf7d4c653aaeb876a2a83d7e2a95","receiptsRoot":"0x9af165447e5b3193e9ac8389418648ee6d6cb1d37459fe65cfc245fc358721bd","sha3Uncles":"0x1dcc4de8dec75d7aab85b567b6ccd41ad312451b948a7413f0a142fd40d49347","stateRoot":"0x4662ac8f239eb6b068a89d82db55fbd699bc883a43812a6ced13976f91c30b71","timestamp":"0x65004480","transactions":[],"transactionsRoot":"0xc5f62b8c7d89e8dce123a91ebe4fd2428f9960f13316e99ff4a8754bd8ee6fa8","uncles":[],"withdrawalsRoot":"0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421"}`
+
+	block := eth.Block{}
+	err := json.Unmarshal([]byte(cancun), &block)
+	require.NoError(t, err)
+
+	t.Run("cancun", func(t *testing.T) {
+		header, err := block.HeaderRLP()
+		require.NoError(t, err)
+
+		raw, err := rlp.Value{List: []rlp.Value{*header, {List: []rlp.Value{}}, {List: []rlp.Value{}}, {List: []rlp.Value{}}}}.Encode()
+		require.NoError(t, err)
+
+		decoded := eth.Block{}
+		err = decoded.FromRaw(raw)
+		require.NoError(t, err)
+		require.Equal(t, block.Hash.String(), decoded.Hash.String())
+		require.Equal(t, block.BlobGasUsed, decoded.BlobGasUsed)
+		require.Equal(t, block.ExcessBlobGas, decoded.ExcessBlobGas)
+		require.Equal(t, block.ParentBeaconBlockRoot, decoded.ParentBeaconBlockRoot)
+		require.NoError(t, decoded.VerifyHash())
+	})
+
+	t.Run("prague", func(t *testing.T) {
+		prague := block.DeepCopy()
+		prague.RequestsHash = eth.MustHash("0xe3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855")
+
+		header, err := prague.HeaderRLP()
+		require.NoError(t, err)
+		require.Len(t, header.List, 21)
+
+		h, err := prague.ComputeHash()
+		require.NoError(t, err)
+		require.NotEqual(t, block.Hash.String(), h.String())
+
+		raw, err := rlp.Value{List: []rlp.Value{*header, {List: []rlp.Value{}}, {List: []rlp.Value{}}, {List: []rlp.Value{}}}}.Encode()
+		require.NoError(t, err)
+
+		decoded := eth.Block{}
+		err = decoded.FromRaw(raw)
+		require.NoError(t, err)
+		require.Equal(t, h.String(), decoded.Hash.String())
+		require.Equal(t, prague.RequestsHash, decoded.RequestsHash)
+		require.NoError(t, decoded.VerifyHash())
+	})
+}
+
+func TestBlock_HeaderRLP_Errors(t *testing.T) {
+	block := eth.Block{}
+	err := json.Unmarshal([]byte(`{"baseFeePerGas":"0x7","blobGasUsed":"0x60000","difficulty":"0x0","excessBlobGas":"0x0","extraData":"0x4e65746865726d696e64","gasLimit":"0x1c9c380","gasUsed":"0xf618","hash":"0xfc2715ff196e23ae613ed6f837abd9035329a720a1f4e8dce3b0694c867ba052","logsBloom":"0x00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000","miner":"0xf97e180c050e5ab072211ad2c213eb5aee4df134","mixHash":"0xfe22e918a42ab40a176372da0352271d7a8d206af1f0f81b4ae24e0366b294e1","nonce":"0x0000000000000000","number":"0x2a1cb","parentBeaconBlockRoot":"0x3e75ca617f5191780dc90f5054d192c29167813ca0e38b84b26c30ae8886998b","parentHash":"0x0efbec3f110f71016eabe050984405a0f5b5bf7d4c653aaeb876a2a83d7e2a95","receiptsRoot":"0x9af165447e5b3193e9ac8389418648ee6d6cb1d37459fe65cfc245fc358721bd","sha3Uncles":"0x1dcc4de8dec75d7aab85b567b6ccd41ad312451b948a7413f0a142fd40d49347","stateRoot":"0x4662ac8f239eb6b068a89d82db55fbd699bc883a43812a6ced13976f91c30b71","timestamp":"0x65004480","transactions":[],"transactionsRoot":"0xc5f62b8c7d89e8dce123a91ebe4fd2428f9960f13316e99ff4a8754bd8ee6fa8","uncles":[],"withdrawalsRoot":"0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421"}`), &block)
+	require.NoError(t, err)
+
+	t.Run("missing earlier fork field", func(t *testing.T) {
+		b := block.DeepCopy()
+		b.WithdrawalsRoot = nil
+		_, err := b.HeaderRLP()
+		require.Error(t, err)
+	})
+
+	t.Run("missing nonce", func(t *testing.T) {
+		b := block.DeepCopy()
+		b.Nonce = nil
+		_, err := b.ComputeHash()
+		require.Error(t, err)
+	})
+
+	t.Run("missing hash", func(t *testing.T) {
+		b := block.DeepCopy()
+		b.Hash = nil
+		require.Error(t, b.VerifyHash())
+	})
+}
+
+func TestNewHeadsResult_ComputeHash(t *testing.T) {
+	tests := []struct {
+		Name string
+		Head string
+	}{
+		{
+			Name: "mainnet geth head",
+			Head: `{"difficulty":"0x9dd74b59cb6be","extraData":"0x7070796520e4b883e5bda9e7a59ee4bb99e9b1bc","gasLimit":"0x7a4f2b","gasUsed":"0x47c15f","hash":"0xcf026edb3d84e540aed9ca11b3c1dfa678bd4bda2d4cc31953e006de6292d53a","logsBloom":"0x80000110a40001802020200141130000820013a00200ac0100a12ca121ac40600200041020022808882048480a0812284240808da98800020901ac10d07e4692c500514038100d098207aa080d6004b220400066c286528840004ec18800a3040404c0b02283074918d00000010034402b628180401a0600400c2431001001b40041000252000200404405400020480200020180402808044000900042101ea0020404254010f000514818020081a0808000290100a200291c80209c20982008840011223050100270051640081a1220040000a00500436104208015804058f831323000a0112140121040860030801084000000007200980004400244080081","miner":"0x829bd824b016326a401d083b33d092293333a830","mixHash":"0x4ed4476377a8246b7930dedcbbe3c967496a9794ebd0fc2310d1c7906f94db87","nonce":"0xd28cb2800a6b6fff","number":"0x6e3044","parentHash":"0xf997a57caff40e89e9f8716568de63f1c1632629d9de750f06c8f7c32bf2c379","receiptsRoot":"0x1889c02d922caf787adccc53e4a48c865615fd02e113eb0e2fb76dbb49f7403a","sha3Uncles":"0x1dcc4de8dec75d7aab85b567b6ccd41ad312451b948a7413f0a142fd40d49347","stateRoot":"0x015cfcb599289caeffeb41087745a128768c46d41313e1c31d9133ceb638fbcd","timestamp":"0x5c65fe8d","transactionsRoot":"0x10e942a373a9383a6d183dd12d346ed5d692dca75597d23d3536dd4035f4b6ba"}`,
+		},
+		{
+			Name: "baikal london head",
+			Head: `{"baseFeePerGas":"0x7","difficulty":"0x2","extraData":"0xd883010a04846765746888676f312e31362e34856c696e757800000000000000374f32d650a93280d5ca9f52d607947f1b4765e54082836c3fe15c6e819d10d37d347e4ad6d286f144616bf62ccebe2c193b0cc30cee121094628e2bd1f77dfe00","gasLimit":"0x1c9c380","gasUsed":"0x0","hash":"0x6e8b3dc23631bcfde27758b286b229c41b9af761118f13e0a16ce47e2e742baa","logsBloom":"0x00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000","miner":"0x0000000000000000000000000000000000000000","mixHash":"0x0000000000000000000000000000000000000000000000000000000000000000","nonce":"0x0000000000000000","number":"0x9514","parentHash":"0xabce6f5b6df7e81f56053d3c125731d6f94b31181f0664c91ad46d7494e096c3","receiptsRoot":"0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421","sha3Uncles":"0x1dcc4de8dec75d7aab85b567b6ccd41ad312451b948a7413f0a142fd40d49347","stateRoot":"0x1a874f978fe35ff14806c527efe288496b04888d56cc88935b937aaccf615802","timestamp":"0x60ad27b7","transactionsRoot":"0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421"}`,
+		},
+		{
+			Name: "dencun devnet cancun head",
+			Head: `{"baseFeePerGas":"0x7","blobGasUsed":"0x60000","difficulty":"0x0","excessBlobGas":"0x200000","extraData":"0xd883010d00846765746888676f312e32312e30856c696e7578","gasLimit":"0x1c9c380","gasUsed":"0xf618","hash":"0x430ab7f664886887ba62dcaa5cf83d8e44b1bf0ad4a576410dbc84a109f95dbf","logsBloom":"0x00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000","miner":"0xf97e180c050e5ab072211ad2c213eb5aee4df134","mixHash":"0x050fa496047dbdcb8de6b0a64974637fb120f390f4bebf73acec5d01844185ab","nonce":"0x0000000000000000","number":"0x393f0","parentBeaconBlockRoot":"0x1951d53e036e0961e9785ef881d922f633fc52645eca81a5528da5e64db947d4","parentHash":"0x71c731f4fa13cc5a9ae3ae4f20420298f00629f06db252944995bd46d2121a91","receiptsRoot":"0x9af165447e5b3193e9ac8389418648ee6d6cb1d37459fe65cfc245fc358721bd","sha3Uncles":"0x1dcc4de8dec75d7aab85b567b6ccd41ad312451b948a7413f0a142fd40d49347","stateRoot":"0x438bba4641dd03719086b83db167fe8dd2d1eedda588fb69316a905e66a2727a","timestamp":"0x650db730","transactionsRoot":"0x1b01eb935388e62c3abcb75c4fd63f95b669fb1e5777eb54483e062fea55c71e","withdrawalsRoot":"0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			head := eth.NewHeadsResult{}
+			err := json.Unmarshal([]byte(tt.Head), &head)
+			require.NoError(t, err)
+
+			h, err := head.ComputeHash()
+			require.NoError(t, err)
+			require.Equal(t, head.Hash.String(), h.String())
+			require.NoError(t, head.VerifyHash())
+
+			head.StateRoot = eth.Data32(head.ReceiptsRoot)
+			require.Error(t, head.VerifyHash())
+		})
+	}
+}
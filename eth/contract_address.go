@@ -0,0 +1,66 @@
+package eth
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/INFURA/go-ethlibs/rlp"
+)
+
+// CreateAddress returns the address of the contract created by sender with nonce, either by a contract creation
+// transaction or by the CREATE opcode, which is the last 20 bytes of keccak256(rlp([sender, nonce])).
+func CreateAddress(sender Address, nonce Quantity) (*Address, error) {
+	message := rlp.Value{List: []rlp.Value{
+		sender.RLP(),
+		nonce.RLP(),
+	}}
+
+	encoded, err := message.Encode()
+	if err != nil {
+		return nil, err
+	}
+
+	preimage, err := NewData(encoded)
+	if err != nil {
+		return nil, err
+	}
+
+	return hashToAddress(preimage.Hash())
+}
+
+// Create2Address returns the address of the contract created by deployer with the CREATE2 opcode, which is the last 20
+// bytes of keccak256(0xff || deployer || salt || keccak256(initCode)), see EIP-1014.
+func Create2Address(deployer Address, salt Data32, initCodeHash Hash) (*Address, error) {
+	preimage, err := NewData("0xff" + strings.ToLower(deployer.String()[2:]) + salt.String()[2:] + initCodeHash.String()[2:])
+	if err != nil {
+		return nil, err
+	}
+
+	return hashToAddress(preimage.Hash())
+}
+
+// ContractAddress returns the address of the contract created by the transaction, computed from its From and Nonce
+// rather than read from its receipt, or an error if the transaction doesn't create a contract.
+func (t *Transaction) ContractAddress() (*Address, error) {
+	if t.To != nil {
+		return nil, errors.New("transaction does not create a contract")
+	}
+
+	if t.From == "" {
+		return nil, errors.New("transaction sender is unknown")
+	}
+
+	return CreateAddress(t.From, t.Nonce)
+}
+
+// hashToAddress returns the address made of the last 20 bytes of the hash.
+func hashToAddress(h Hash) (*Address, error) {
+	// skip the 0x and the first 12 bytes of the hash
+	addr, err := NewAddress("0x" + h.String()[26:])
+	if err != nil {
+		return nil, errors.Wrap(err, "could not convert hash to address")
+	}
+
+	return addr, nil
+}
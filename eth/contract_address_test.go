@@ -0,0 +1,106 @@
+package eth_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/INFURA/go-ethlibs/eth"
+)
+
+func TestCreateAddress(t *testing.T) {
+	sender := *eth.MustAddress("0x6ac7ea33f8831ea9dcc53393aaa88b25a785dbf0")
+	expected := []string{
+		"0xcd234a471b72ba2f1ccf0a70fcaba648a5eecd8d",
+		"0x343c43a37d37dff08ae8c4a11544c718abb4fcf8",
+		"0xf778b86fa74e846c4f0a1fbd1335fe81c00a0c91",
+		"0xfffd933a0bc612844eaf0c6fe3e5b8e9b6c1d19c",
+	}
+
+	for nonce, address := range expected {
+		actual, err := eth.CreateAddress(sender, eth.QuantityFromInt64(int64(nonce)))
+		require.NoError(t, err)
+		require.Equal(t, eth.MustAddress(address), actual, "nonce %d", nonce)
+	}
+}
+
+func TestCreate2Address(t *testing.T) {
+	// test cases from EIP-1014
+	tests := []struct {
+		deployer string
+		salt     string
+		initCode string
+		expected string
+	}{
+		{
+			deployer: "0x0000000000000000000000000000000000000000",
+			salt:     "0x0000000000000000000000000000000000000000000000000000000000000000",
+			initCode: "0x00",
+			expected: "0x4D1A2e2bB4F88F0250f26Ffff098B0b30B26BF38",
+		},
+		{
+			deployer: "0xdeadbeef00000000000000000000000000000000",
+			salt:     "0x0000000000000000000000000000000000000000000000000000000000000000",
+			initCode: "0x00",
+			expected: "0xB928f69Bb1D91Cd65274e3c79d8986362984fDA3",
+		},
+		{
+			deployer: "0xdeadbeef00000000000000000000000000000000",
+			salt:     "0x000000000000000000000000feed000000000000000000000000000000000000",
+			initCode: "0x00",
+			expected: "0xD04116cDd17beBE565EB2422F2497E06cC1C9833",
+		},
+		{
+			deployer: "0x0000000000000000000000000000000000000000",
+			salt:     "0x0000000000000000000000000000000000000000000000000000000000000000",
+			initCode: "0xdeadbeef",
+			expected: "0x70f2b2914A2a4b783FaEFb75f459A580616Fcb5e",
+		},
+		{
+			deployer: "0x00000000000000000000000000000000deadbeef",
+			salt:     "0x00000000000000000000000000000000000000000000000000000000cafebabe",
+			initCode: "0xdeadbeef",
+			expected: "0x60f3f640a8508fC6a86d45DF051962668E1e8AC7",
+		},
+		{
+			deployer: "0x00000000000000000000000000000000deadbeef",
+			salt:     "0x00000000000000000000000000000000000000000000000000000000cafebabe",
+			initCode: "0x" + strings.Repeat("deadbeef", 11),
+			expected: "0x1d8bfDC5D46DC4f61D6b6115972536eBE6A8854C",
+		},
+		{
+			deployer: "0x0000000000000000000000000000000000000000",
+			salt:     "0x0000000000000000000000000000000000000000000000000000000000000000",
+			initCode: "0x",
+			expected: "0xE33C0C7F7df4809055C3ebA6c09CFe4BaF1BD9e0",
+		},
+	}
+
+	for _, tt := range tests {
+		initCodeHash := eth.Data(tt.initCode).Hash()
+		actual, err := eth.Create2Address(*eth.MustAddress(tt.deployer), eth.Data32(tt.salt), initCodeHash)
+		require.NoError(t, err)
+		require.Equal(t, tt.expected, actual.String(), "%+v", tt)
+	}
+}
+
+func TestTransaction_ContractAddress(t *testing.T) {
+	tx := eth.Transaction{
+		From:  *eth.MustAddress("0x6ac7ea33f8831ea9dcc53393aaa88b25a785dbf0"),
+		Nonce: eth.QuantityFromInt64(3),
+	}
+
+	address, err := tx.ContractAddress()
+	require.NoError(t, err)
+	require.Equal(t, eth.MustAddress("0xfffd933a0bc612844eaf0c6fe3e5b8e9b6c1d19c"), address)
+
+	// calls don't create contracts
+	tx.To = eth.MustAddress("0xdf0a88b2b68c673713a8ec826003676f272e3573")
+	_, err = tx.ContractAddress()
+	require.Error(t, err)
+
+	// and contracts can't be known without the sender
+	_, err = (&eth.Transaction{}).ContractAddress()
+	require.Error(t, err)
+}
@@ -0,0 +1,192 @@
+package eth
+
+import (
+	"math/big"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// BaseFeeChangeDenominator bounds the change of the base fee between blocks to 1/8th, and ElasticityMultiplier is
+	// the ratio of the gas limit to the gas target, see EIP-1559.
+	BaseFeeChangeDenominator = 8
+	ElasticityMultiplier     = 2
+
+	// GasPerBlob is the blob gas used by each blob, and MinBlobBaseFee the lowest blob base fee, see EIP-4844.
+	GasPerBlob     = 1 << 17
+	MinBlobBaseFee = 1
+)
+
+// BlobSchedule holds the blob parameters of a fork: the target and maximum number of blobs per block, and the update
+// fraction controlling how fast the blob base fee changes.
+type BlobSchedule struct {
+	Target                uint64
+	Max                   uint64
+	BaseFeeUpdateFraction uint64
+}
+
+var (
+	// BlobScheduleCancun is the blob schedule introduced by EIP-4844 in Cancun.
+	BlobScheduleCancun = BlobSchedule{Target: 3, Max: 6, BaseFeeUpdateFraction: 3338477}
+
+	// BlobSchedulePrague is the blob schedule raised by EIP-7691 in Prague.
+	BlobSchedulePrague = BlobSchedule{Target: 6, Max: 9, BaseFeeUpdateFraction: 5007716}
+)
+
+// TargetBlobGas returns the blob gas a block targets under the schedule.
+func (s BlobSchedule) TargetBlobGas() uint64 {
+	return s.Target * GasPerBlob
+}
+
+// MaxBlobGas returns the most blob gas a block may use under the schedule.
+func (s BlobSchedule) MaxBlobGas() uint64 {
+	return s.Max * GasPerBlob
+}
+
+// CalcNextBaseFee returns the base fee of the block following a parent block with the given gas limit, gas used and
+// base fee, as defined by EIP-1559.
+func CalcNextBaseFee(parentGasLimit, parentGasUsed uint64, parentBaseFee *big.Int) *big.Int {
+	target := parentGasLimit / ElasticityMultiplier
+	if target == 0 || parentGasUsed == target {
+		return new(big.Int).Set(parentBaseFee)
+	}
+
+	delta := new(big.Int)
+	if parentGasUsed > target {
+		delta.SetUint64(parentGasUsed - target)
+		delta.Mul(delta, parentBaseFee)
+		delta.Div(delta, new(big.Int).SetUint64(target))
+		delta.Div(delta, big.NewInt(BaseFeeChangeDenominator))
+		if delta.Sign() == 0 {
+			delta.SetInt64(1)
+		}
+		return delta.Add(parentBaseFee, delta)
+	}
+
+	delta.SetUint64(target - parentGasUsed)
+	delta.Mul(delta, parentBaseFee)
+	delta.Div(delta, new(big.Int).SetUint64(target))
+	delta.Div(delta, big.NewInt(BaseFeeChangeDenominator))
+	next := delta.Sub(parentBaseFee, delta)
+	if next.Sign() < 0 {
+		next.SetInt64(0)
+	}
+	return next
+}
+
+// CalcExcessBlobGas returns the excess blob gas of the block following a parent block with the given excess blob gas
+// and blob gas used, as defined by EIP-4844.
+func CalcExcessBlobGas(parentExcessBlobGas, parentBlobGasUsed uint64, schedule BlobSchedule) uint64 {
+	total := parentExcessBlobGas + parentBlobGasUsed
+	if total < schedule.TargetBlobGas() {
+		return 0
+	}
+
+	return total - schedule.TargetBlobGas()
+}
+
+// CalcBlobBaseFee returns the blob base fee of a block with the given excess blob gas, as defined by EIP-4844.
+func CalcBlobBaseFee(excessBlobGas uint64, schedule BlobSchedule) *big.Int {
+	return FakeExponential(
+		big.NewInt(MinBlobBaseFee),
+		new(big.Int).SetUint64(excessBlobGas),
+		new(big.Int).SetUint64(schedule.BaseFeeUpdateFraction),
+	)
+}
+
+// FakeExponential approximates factor * e ** (numerator / denominator) using a Taylor expansion, as the
+// fake_exponential function of EIP-4844 does.
+func FakeExponential(factor, numerator, denominator *big.Int) *big.Int {
+	output := new(big.Int)
+	accum := new(big.Int).Mul(factor, denominator)
+	for i := int64(1); accum.Sign() > 0; i++ {
+		output.Add(output, accum)
+
+		accum.Mul(accum, numerator)
+		accum.Div(accum, denominator)
+		accum.Div(accum, big.NewInt(i))
+	}
+
+	return output.Div(output, denominator)
+}
+
+// NextBaseFee returns the base fee of the next block, or an error if the block predates EIP-1559.
+func (b *Block) NextBaseFee() (*Quantity, error) {
+	return b.header().nextBaseFee()
+}
+
+// BlobBaseFee returns the blob base fee of the block under the schedule, or an error if the block predates EIP-4844.
+func (b *Block) BlobBaseFee(schedule BlobSchedule) (*Quantity, error) {
+	return b.header().blobBaseFee(schedule)
+}
+
+// NextExcessBlobGas returns the excess blob gas of the next block under the schedule, or an error if the block
+// predates EIP-4844.
+func (b *Block) NextExcessBlobGas(schedule BlobSchedule) (*Quantity, error) {
+	return b.header().nextExcessBlobGas(schedule)
+}
+
+// NextBlobBaseFee returns the blob base fee of the next block under the schedule, which is the blob base fee
+// transactions included in it pay, or an error if the block predates EIP-4844.
+func (b *Block) NextBlobBaseFee(schedule BlobSchedule) (*Quantity, error) {
+	return b.header().nextBlobBaseFee(schedule)
+}
+
+// NextBaseFee returns the base fee of the next block, or an error if the block predates EIP-1559.
+func (nh *NewHeadsResult) NextBaseFee() (*Quantity, error) {
+	return nh.header().nextBaseFee()
+}
+
+// BlobBaseFee returns the blob base fee of the block under the schedule, or an error if the block predates EIP-4844.
+func (nh *NewHeadsResult) BlobBaseFee(schedule BlobSchedule) (*Quantity, error) {
+	return nh.header().blobBaseFee(schedule)
+}
+
+// NextExcessBlobGas returns the excess blob gas of the next block under the schedule, or an error if the block
+// predates EIP-4844.
+func (nh *NewHeadsResult) NextExcessBlobGas(schedule BlobSchedule) (*Quantity, error) {
+	return nh.header().nextExcessBlobGas(schedule)
+}
+
+// NextBlobBaseFee returns the blob base fee of the next block under the schedule, which is the blob base fee
+// transactions included in it pay, or an error if the block predates EIP-4844.
+func (nh *NewHeadsResult) NextBlobBaseFee(schedule BlobSchedule) (*Quantity, error) {
+	return nh.header().nextBlobBaseFee(schedule)
+}
+
+func (h *header) nextBaseFee() (*Quantity, error) {
+	if h.BaseFeePerGas == nil {
+		return nil, errors.New("block has no baseFeePerGas")
+	}
+
+	q := QuantityFromBigInt(CalcNextBaseFee(h.GasLimit.UInt64(), h.GasUsed.UInt64(), h.BaseFeePerGas.Big()))
+	return &q, nil
+}
+
+func (h *header) blobBaseFee(schedule BlobSchedule) (*Quantity, error) {
+	if h.ExcessBlobGas == nil {
+		return nil, errors.New("block has no excessBlobGas")
+	}
+
+	q := QuantityFromBigInt(CalcBlobBaseFee(h.ExcessBlobGas.UInt64(), schedule))
+	return &q, nil
+}
+
+func (h *header) nextExcessBlobGas(schedule BlobSchedule) (*Quantity, error) {
+	if h.ExcessBlobGas == nil || h.BlobGasUsed == nil {
+		return nil, errors.New("block has no excessBlobGas or blobGasUsed")
+	}
+
+	q := QuantityFromUInt64(CalcExcessBlobGas(h.ExcessBlobGas.UInt64(), h.BlobGasUsed.UInt64(), schedule))
+	return &q, nil
+}
+
+func (h *header) nextBlobBaseFee(schedule BlobSchedule) (*Quantity, error) {
+	excess, err := h.nextExcessBlobGas(schedule)
+	if err != nil {
+		return nil, err
+	}
+
+	q := QuantityFromBigInt(CalcBlobBaseFee(excess.UInt64(), schedule))
+	return &q, nil
+}
@@ -0,0 +1,142 @@
+package eth_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/INFURA/go-ethlibs/eth"
+)
+
+func TestCalcNextBaseFee(t *testing.T) {
+	tests := []struct {
+		gasLimit uint64
+		gasUsed  uint64
+		baseFee  int64
+		expected int64
+	}{
+		{gasLimit: 20000000, gasUsed: 10000000, baseFee: 1000000000, expected: 1000000000}, // at target
+		{gasLimit: 20000000, gasUsed: 9000000, baseFee: 1000000000, expected: 987500000},   // below target
+		{gasLimit: 20000000, gasUsed: 11000000, baseFee: 1000000000, expected: 1012500000}, // above target
+		{gasLimit: 10000000, gasUsed: 10000000, baseFee: 1000000000, expected: 1125000000},
+		{gasLimit: 10000000, gasUsed: 0, baseFee: 1000000000, expected: 875000000},
+		{gasLimit: 10000000, gasUsed: 5000001, baseFee: 7, expected: 8}, // increases by at least 1
+		{gasLimit: 10000000, gasUsed: 0, baseFee: 7, expected: 7},
+	}
+
+	for _, tt := range tests {
+		actual := eth.CalcNextBaseFee(tt.gasLimit, tt.gasUsed, big.NewInt(tt.baseFee))
+		require.Equal(t, tt.expected, actual.Int64(), "%+v", tt)
+	}
+}
+
+func TestFakeExponential(t *testing.T) {
+	tests := []struct {
+		factor, numerator, denominator int64
+		expected                       int64
+	}{
+		{1, 0, 1, 1},
+		{38493, 0, 1000, 38493},
+		{0, 1234, 2345, 0},
+		{1, 2, 1, 6},
+		{1, 4, 2, 6},
+		{1, 3, 1, 16},
+		{1, 6, 2, 18},
+		{1, 4, 1, 49},
+		{1, 8, 2, 50},
+		{10, 8, 2, 542},
+		{11, 8, 2, 596},
+		{1, 5, 1, 136},
+		{1, 5, 2, 11},
+		{2, 5, 2, 23},
+		{1, 50000000, 2225652, 5709098764},
+	}
+
+	for _, tt := range tests {
+		actual := eth.FakeExponential(big.NewInt(tt.factor), big.NewInt(tt.numerator), big.NewInt(tt.denominator))
+		require.Equal(t, tt.expected, actual.Int64(), "%+v", tt)
+	}
+}
+
+func TestCalcExcessBlobGas(t *testing.T) {
+	tests := []struct {
+		schedule eth.BlobSchedule
+		excess   uint64
+		blobs    uint64
+		expected uint64
+	}{
+		{eth.BlobScheduleCancun, 0, 0, 0},
+		{eth.BlobScheduleCancun, 0, 3, 0},
+		{eth.BlobScheduleCancun, 0, 6, 3 * eth.GasPerBlob},
+		{eth.BlobScheduleCancun, 2 * eth.GasPerBlob, 0, 0},
+		{eth.BlobScheduleCancun, 10 * eth.GasPerBlob, 1, 8 * eth.GasPerBlob},
+		{eth.BlobSchedulePrague, 0, 6, 0},
+		{eth.BlobSchedulePrague, 0, 9, 3 * eth.GasPerBlob},
+		{eth.BlobSchedulePrague, 10 * eth.GasPerBlob, 1, 5 * eth.GasPerBlob},
+	}
+
+	for _, tt := range tests {
+		actual := eth.CalcExcessBlobGas(tt.excess, tt.blobs*eth.GasPerBlob, tt.schedule)
+		require.Equal(t, tt.expected, actual, "%+v", tt)
+	}
+
+	require.Equal(t, uint64(786432), eth.BlobScheduleCancun.MaxBlobGas())
+	require.Equal(t, uint64(786432), eth.BlobSchedulePrague.TargetBlobGas())
+}
+
+func TestBlock_FeeMarket(t *testing.T) {
+	excess := eth.QuantityFromUInt64(50000000)
+	blobGasUsed := eth.QuantityFromUInt64(6 * eth.GasPerBlob)
+	block := eth.Block{
+		GasLimit:      eth.QuantityFromUInt64(30000000),
+		GasUsed:       eth.QuantityFromUInt64(20000000),
+		BaseFeePerGas: eth.MustQuantity("0x3b9aca00"),
+		ExcessBlobGas: &excess,
+		BlobGasUsed:   &blobGasUsed,
+	}
+
+	baseFee, err := block.NextBaseFee()
+	require.NoError(t, err)
+	require.Equal(t, "1041666666", baseFee.Big().String())
+
+	blobBaseFee, err := block.BlobBaseFee(eth.BlobScheduleCancun)
+	require.NoError(t, err)
+	require.Equal(t, eth.CalcBlobBaseFee(50000000, eth.BlobScheduleCancun), blobBaseFee.Big())
+
+	nextExcess, err := block.NextExcessBlobGas(eth.BlobScheduleCancun)
+	require.NoError(t, err)
+	require.Equal(t, uint64(50000000+3*eth.GasPerBlob), nextExcess.UInt64())
+
+	nextExcess, err = block.NextExcessBlobGas(eth.BlobSchedulePrague)
+	require.NoError(t, err)
+	require.Equal(t, uint64(50000000), nextExcess.UInt64())
+
+	nextBlobBaseFee, err := block.NextBlobBaseFee(eth.BlobSchedulePrague)
+	require.NoError(t, err)
+	require.Equal(t, eth.CalcBlobBaseFee(50000000, eth.BlobSchedulePrague), nextBlobBaseFee.Big())
+
+	// newHeads notifications carry the same fields
+	head := eth.NewHeadsResult{
+		GasLimit:      block.GasLimit,
+		GasUsed:       block.GasUsed,
+		BaseFeePerGas: block.BaseFeePerGas,
+		ExcessBlobGas: block.ExcessBlobGas,
+		BlobGasUsed:   block.BlobGasUsed,
+	}
+
+	headBaseFee, err := head.NextBaseFee()
+	require.NoError(t, err)
+	require.Equal(t, baseFee, headBaseFee)
+
+	headBlobBaseFee, err := head.NextBlobBaseFee(eth.BlobSchedulePrague)
+	require.NoError(t, err)
+	require.Equal(t, nextBlobBaseFee, headBlobBaseFee)
+
+	// blocks from before London and Cancun don't have the fields
+	_, err = (&eth.Block{}).NextBaseFee()
+	require.Error(t, err)
+
+	_, err = (&eth.Block{}).NextBlobBaseFee(eth.BlobScheduleCancun)
+	require.Error(t, err)
+}
@@ -0,0 +1,133 @@
+package eth
+
+import (
+	"github.com/pkg/errors"
+)
+
+const (
+	// TxGas and TxGasContractCreation are the base costs of calls and of contract creations.
+	TxGas                 = 21000
+	TxGasContractCreation = 53000
+
+	// TxDataZeroGas is the cost of zero calldata bytes, and TxDataNonZeroGas the cost of other bytes since EIP-2028,
+	// before which they cost TxDataNonZeroGasFrontier.
+	TxDataZeroGas            = 4
+	TxDataNonZeroGas         = 16
+	TxDataNonZeroGasFrontier = 68
+
+	// TxAccessListAddressGas and TxAccessListStorageKeyGas are the costs of access list entries, see EIP-2930.
+	TxAccessListAddressGas    = 2400
+	TxAccessListStorageKeyGas = 1900
+
+	// InitCodeWordGas is the cost of each 32 byte word of initcode, which may be at most MaxInitCodeSize bytes long,
+	// see EIP-3860.
+	InitCodeWordGas = 2
+	MaxInitCodeSize = 49152
+
+	// TxAuthorizationGas is the cost of each EIP-7702 authorization.
+	TxAuthorizationGas = 25000
+
+	// TxCostFloorPerToken is the cost of each calldata token under the EIP-7623 floor, with zero bytes counting as one
+	// token and other bytes as four.
+	TxCostFloorPerToken = 10
+)
+
+// Rules selects the forks whose gas rules apply when computing intrinsic gas.
+type Rules struct {
+	// Homestead makes contract creations cost TxGasContractCreation, see EIP-2.
+	Homestead bool
+
+	// Istanbul lowers the cost of non-zero calldata bytes, see EIP-2028.
+	Istanbul bool
+
+	// Shanghai charges for and limits the size of initcode, see EIP-3860.
+	Shanghai bool
+
+	// Prague charges at least the calldata floor cost, see EIP-7623.
+	Prague bool
+}
+
+var (
+	// RulesCancun are the gas rules of mainnet since Cancun.
+	RulesCancun = Rules{Homestead: true, Istanbul: true, Shanghai: true}
+
+	// RulesPrague are the gas rules of mainnet since Prague.
+	RulesPrague = Rules{Homestead: true, Istanbul: true, Shanghai: true, Prague: true}
+)
+
+// IntrinsicGas returns the least gas a transaction must provide before executing anything: its base cost and the cost
+// of its calldata, access list, initcode and authorizations.  Under rules with Prague it is at least the EIP-7623
+// calldata floor cost, since that is the least gas the transaction can be included with.  An error is returned when
+// the transaction can't be valid under the rules, e.g. because its initcode is too large.
+func (t *Transaction) IntrinsicGas(rules Rules) (uint64, error) {
+	input := t.calldata()
+	creation := t.To == nil
+
+	gas := uint64(TxGas)
+	if creation && rules.Homestead {
+		gas = TxGasContractCreation
+	}
+
+	zeros := uint64(0)
+	for _, b := range input {
+		if b == 0 {
+			zeros++
+		}
+	}
+	nonZeros := uint64(len(input)) - zeros
+
+	nonZeroGas := uint64(TxDataNonZeroGasFrontier)
+	if rules.Istanbul {
+		nonZeroGas = TxDataNonZeroGas
+	}
+	gas += zeros*TxDataZeroGas + nonZeros*nonZeroGas
+
+	if creation && rules.Shanghai {
+		if len(input) > MaxInitCodeSize {
+			return 0, errors.Errorf("initcode size %d exceeds the maximum of %d", len(input), MaxInitCodeSize)
+		}
+		gas += uint64(len(input)+31) / 32 * InitCodeWordGas
+	}
+
+	if t.AccessList != nil {
+		for _, entry := range *t.AccessList {
+			gas += TxAccessListAddressGas + uint64(len(entry.StorageKeys))*TxAccessListStorageKeyGas
+		}
+	}
+
+	if t.AuthorizationList != nil {
+		gas += uint64(len(*t.AuthorizationList)) * TxAuthorizationGas
+	}
+
+	if rules.Prague {
+		if floor := t.FloorDataGas(); floor > gas {
+			return floor, nil
+		}
+	}
+
+	return gas, nil
+}
+
+// FloorDataGas returns the EIP-7623 calldata floor cost of the transaction, which is the least gas it uses since
+// Prague regardless of how much gas its execution takes.
+func (t *Transaction) FloorDataGas() uint64 {
+	tokens := uint64(0)
+	for _, b := range t.calldata() {
+		if b == 0 {
+			tokens++
+		} else {
+			tokens += 4
+		}
+	}
+
+	return TxGas + tokens*TxCostFloorPerToken
+}
+
+// calldata returns the bytes of the input, treating a missing input like 0x.
+func (t *Transaction) calldata() []byte {
+	if t.Input == "" {
+		return nil
+	}
+
+	return t.Input.Bytes()
+}
@@ -0,0 +1,108 @@
+package eth_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/INFURA/go-ethlibs/eth"
+)
+
+func TestTransaction_IntrinsicGas(t *testing.T) {
+	to := eth.MustAddress("0x000000000000000000000000000000000000aaaa")
+	key := eth.Data32("0x" + strings.Repeat("00", 32))
+
+	tests := []struct {
+		name     string
+		tx       eth.Transaction
+		rules    eth.Rules
+		expected uint64
+	}{
+		{
+			name:     "transfer",
+			tx:       eth.Transaction{To: to},
+			rules:    eth.RulesPrague,
+			expected: 21000,
+		},
+		{
+			name:     "calldata",
+			tx:       eth.Transaction{To: to, Input: eth.Input("0x00ff")},
+			rules:    eth.RulesCancun,
+			expected: 21000 + 4 + 16,
+		},
+		{
+			name:     "calldata before istanbul",
+			tx:       eth.Transaction{To: to, Input: eth.Input("0x00ff")},
+			rules:    eth.Rules{Homestead: true},
+			expected: 21000 + 4 + 68,
+		},
+		{
+			name:     "calldata floor",
+			tx:       eth.Transaction{To: to, Input: eth.Input("0x00ff")},
+			rules:    eth.RulesPrague,
+			expected: 21000 + (1+4)*10,
+		},
+		{
+			name:     "creation",
+			tx:       eth.Transaction{Input: eth.Input("0x" + strings.Repeat("ff", 64))},
+			rules:    eth.RulesPrague,
+			expected: 53000 + 64*16 + 2*2,
+		},
+		{
+			name:     "creation before shanghai",
+			tx:       eth.Transaction{Input: eth.Input("0x" + strings.Repeat("ff", 64))},
+			rules:    eth.Rules{Homestead: true, Istanbul: true},
+			expected: 53000 + 64*16,
+		},
+		{
+			name:     "creation before homestead",
+			tx:       eth.Transaction{},
+			rules:    eth.Rules{},
+			expected: 21000,
+		},
+		{
+			name: "access list",
+			tx: eth.Transaction{To: to, AccessList: &eth.AccessList{
+				{Address: *to, StorageKeys: []eth.Data32{key}},
+				{Address: *to, StorageKeys: []eth.Data32{key, key}},
+			}},
+			rules:    eth.RulesPrague,
+			expected: 21000 + 2*2400 + 3*1900,
+		},
+		{
+			name: "authorizations",
+			tx: eth.Transaction{To: to, AuthorizationList: &eth.AuthorizationList{
+				{ChainID: eth.MustQuantity("0x1"), Address: *to},
+				{ChainID: eth.MustQuantity("0x1"), Address: *to},
+			}},
+			rules:    eth.RulesPrague,
+			expected: 21000 + 2*25000,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gas, err := tt.tx.IntrinsicGas(tt.rules)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, gas)
+		})
+	}
+}
+
+func TestTransaction_IntrinsicGas_InitCodeTooLarge(t *testing.T) {
+	tx := eth.Transaction{Input: eth.Input("0x" + strings.Repeat("ff", eth.MaxInitCodeSize+1))}
+
+	_, err := tx.IntrinsicGas(eth.RulesCancun)
+	require.Error(t, err)
+
+	// initcode size wasn't limited before shanghai
+	gas, err := tx.IntrinsicGas(eth.Rules{Homestead: true, Istanbul: true})
+	require.NoError(t, err)
+	require.Equal(t, uint64(53000+(eth.MaxInitCodeSize+1)*16), gas)
+}
+
+func TestTransaction_FloorDataGas(t *testing.T) {
+	tx := eth.Transaction{Input: eth.Input("0x" + strings.Repeat("00", 10) + strings.Repeat("01", 10))}
+	require.Equal(t, uint64(21000+(10+40)*10), tx.FloorDataGas())
+}
@@ -0,0 +1,63 @@
+// Package kzg verifies the KZG proofs of EIP-4844 blobs with go-kzg-4844.  It is kept apart from the eth package so
+// that only the programs verifying blobs depend on its BLS12-381 implementation.
+package kzg
+
+import (
+	"sync"
+
+	gokzg4844 "github.com/crate-crypto/go-kzg-4844"
+	"github.com/pkg/errors"
+
+	"github.com/INFURA/go-ethlibs/eth"
+)
+
+var _ eth.KZGVerifier = (*Verifier)(nil)
+
+var (
+	mainnetOnce     sync.Once
+	mainnetVerifier *Verifier
+	mainnetErr      error
+)
+
+// Verifier is an eth.KZGVerifier using go-kzg-4844.
+type Verifier struct {
+	ctx *gokzg4844.Context
+}
+
+// MainnetVerifier returns a Verifier using the trusted setup from the Ethereum KZG ceremony embedded in go-kzg-4844,
+// which is the setup used on mainnet.  The setup is loaded on first use, which takes a few seconds.
+func MainnetVerifier() (*Verifier, error) {
+	mainnetOnce.Do(func() {
+		ctx, err := gokzg4844.NewContext4096Secure()
+		if err != nil {
+			mainnetErr = errors.Wrap(err, "could not load the mainnet KZG trusted setup")
+			return
+		}
+
+		mainnetVerifier = &Verifier{ctx: ctx}
+	})
+
+	if mainnetErr != nil {
+		return nil, mainnetErr
+	}
+
+	return mainnetVerifier, nil
+}
+
+// VerifyBlobKZGProof returns an error unless proof is the KZG proof that blob matches commitment.
+func (v *Verifier) VerifyBlobKZGProof(blob []byte, commitment []byte, proof []byte) error {
+	if len(blob) != eth.BlobSize || len(commitment) != eth.KZGCommitmentSize || len(proof) != eth.KZGProofSize {
+		return errors.New("invalid blob, commitment or proof size")
+	}
+
+	var (
+		b gokzg4844.Blob
+		c gokzg4844.KZGCommitment
+		p gokzg4844.KZGProof
+	)
+	copy(b[:], blob)
+	copy(c[:], commitment)
+	copy(p[:], proof)
+
+	return v.ctx.VerifyBlobKZGProof(&b, c, p)
+}
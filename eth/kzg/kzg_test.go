@@ -0,0 +1,28 @@
+package kzg_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/INFURA/go-ethlibs/eth"
+	"github.com/INFURA/go-ethlibs/eth/kzg"
+)
+
+func TestMainnetVerifier(t *testing.T) {
+	verifier, err := kzg.MainnetVerifier()
+	require.NoError(t, err)
+
+	// the commitment and proof of a blob of zeros are the point at infinity
+	blob := make([]byte, eth.BlobSize)
+	infinity := append([]byte{0xc0}, make([]byte, eth.KZGProofSize-1)...)
+	require.NoError(t, verifier.VerifyBlobKZGProof(blob, infinity, infinity))
+
+	// a valid point which isn't the proof of the blob is rejected
+	generator := eth.Data("0x97f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb")
+	require.Error(t, verifier.VerifyBlobKZGProof(blob, infinity, generator.Bytes()))
+
+	// as are inputs of the wrong size
+	require.Error(t, verifier.VerifyBlobKZGProof(blob[:1024], infinity, infinity))
+	require.Error(t, verifier.VerifyBlobKZGProof(blob, infinity[:32], infinity))
+}
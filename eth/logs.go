@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 
 	"github.com/pkg/errors"
+
+	"github.com/INFURA/go-ethlibs/rlp"
 )
 
 type Log struct {
@@ -23,6 +25,53 @@ type Log struct {
 	Type       *string   `json:"type,omitempty"`
 }
 
+// RLP returns the consensus RLP encoding of a Log as found in receipts: [address, [topic, ...], data]
+func (l *Log) RLP() rlp.Value {
+	topics := rlp.Value{List: make([]rlp.Value, len(l.Topics))}
+	for i := range l.Topics {
+		topics.List[i] = l.Topics[i].RLP()
+	}
+
+	return rlp.Value{List: []rlp.Value{
+		l.Address.RLP(),
+		topics,
+		l.Data.RLP(),
+	}}
+}
+
+// NewLogFromRLP decodes the consensus RLP encoding of a Log, see Log.RLP.  Only the address, topics and data fields
+// are populated since the rest aren't part of the encoding.
+func NewLogFromRLP(v rlp.Value) (*Log, error) {
+	if len(v.List) != 3 || !v.List[1].IsList() {
+		return nil, errors.New("log must be a list of address, topics and data")
+	}
+
+	address, err := NewAddress(v.List[0].AsHex())
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid log address")
+	}
+
+	topics := make([]Topic, len(v.List[1].List))
+	for i := range v.List[1].List {
+		topic, err := NewTopic(v.List[1].List[i].AsHex())
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid log topic %d", i)
+		}
+		topics[i] = *topic
+	}
+
+	data, err := NewData(v.List[2].AsHex())
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid log data")
+	}
+
+	return &Log{
+		Address: *address,
+		Topics:  topics,
+		Data:    *data,
+	}, nil
+}
+
 type addrOrArray []Address
 
 func (a *addrOrArray) UnmarshalJSON(data []byte) error {
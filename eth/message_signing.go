@@ -0,0 +1,83 @@
+package eth
+
+import (
+	"encoding/hex"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/sha3"
+)
+
+// HashPersonalMessage returns the EIP-191 version 0x45 hash of a message as signed by personal_sign and eth_sign,
+// which is keccak256("\x19Ethereum Signed Message:\n" + len(message) + message).  The prefix prevents signed
+// messages from being valid transactions.
+func HashPersonalMessage(message []byte) Hash {
+	prefix := "\x19Ethereum Signed Message:\n" + strconv.Itoa(len(message))
+	return keccak256Hash([]byte(prefix), message)
+}
+
+// HashValidatorMessage returns the EIP-191 version 0x00 hash of data with an intended validator, which is
+// keccak256(0x19 || 0x00 || validator || data).  The validator is usually the contract verifying the signature.
+func HashValidatorMessage(validator Address, data []byte) Hash {
+	return keccak256Hash([]byte{0x19, 0x00}, validator.Bytes(), data)
+}
+
+// SignPersonalMessage signs a message with the bytes of a private key as personal_sign does, see HashPersonalMessage.
+// Use Signature.Bytes for the 65 byte [r || s || v] form returned by wallets.
+func SignPersonalMessage(message []byte, privKeyBytes []byte) (*Signature, error) {
+	h := HashPersonalMessage(message)
+	return ECSign(&h, privKeyBytes, QuantityFromInt64(0))
+}
+
+// RecoverPersonalMessage returns the address of the account that signed a message with personal_sign, given the 65
+// byte [r || s || v] signature.
+func RecoverPersonalMessage(message []byte, sig []byte) (*Address, error) {
+	signature, err := NewSignatureFromBytes(sig)
+	if err != nil {
+		return nil, err
+	}
+
+	h := HashPersonalMessage(message)
+	return signature.Recover(&h)
+}
+
+// SignValidatorMessage signs data with an intended validator with the bytes of a private key, see
+// HashValidatorMessage.
+func SignValidatorMessage(validator Address, data []byte, privKeyBytes []byte) (*Signature, error) {
+	h := HashValidatorMessage(validator, data)
+	return ECSign(&h, privKeyBytes, QuantityFromInt64(0))
+}
+
+// RecoverValidatorMessage returns the address of the account that signed data with an intended validator, given the
+// 65 byte [r || s || v] signature.
+func RecoverValidatorMessage(validator Address, data []byte, sig []byte) (*Address, error) {
+	signature, err := NewSignatureFromBytes(sig)
+	if err != nil {
+		return nil, err
+	}
+
+	h := HashValidatorMessage(validator, data)
+	return signature.Recover(&h)
+}
+
+// VerifyPersonalMessage checks that a message was signed with personal_sign by the account at address.
+func VerifyPersonalMessage(address Address, message []byte, sig []byte) error {
+	signer, err := RecoverPersonalMessage(message, sig)
+	if err != nil {
+		return errors.Wrap(err, "could not recover signer")
+	}
+
+	if signer.String() != ToChecksumAddress(address.String()) {
+		return errors.Errorf("message was signed by %s, not %s", signer, address)
+	}
+
+	return nil
+}
+
+func keccak256Hash(data ...[]byte) Hash {
+	hash := sha3.NewLegacyKeccak256()
+	for _, b := range data {
+		hash.Write(b)
+	}
+	return Hash("0x" + hex.EncodeToString(hash.Sum(nil)))
+}
@@ -0,0 +1,100 @@
+package eth_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/sha3"
+
+	"github.com/INFURA/go-ethlibs/eth"
+)
+
+func TestHashPersonalMessage(t *testing.T) {
+	h := eth.HashPersonalMessage([]byte("Hello World"))
+	require.Equal(t, "0xa1de988600a42c4b4ab089b619297c17d53cffae5d5120d82d8a92d0bb3b78f2", h.String())
+}
+
+func TestSignPersonalMessage(t *testing.T) {
+	// the example key of the ethers.js Wallet documentation
+	key := eth.MustData("0x0123456789012345678901234567890123456789012345678901234567890123").Bytes()
+	address := *eth.MustAddress("0x14791697260E4c9A71f18484C9f997B308e59325")
+	message := []byte("Hello World")
+
+	sig, err := eth.SignPersonalMessage(message, key)
+	require.NoError(t, err)
+
+	compact := sig.Bytes()
+	require.Len(t, compact, 65)
+
+	signer, err := eth.RecoverPersonalMessage(message, compact)
+	require.NoError(t, err)
+	require.Equal(t, address.String(), signer.String())
+	require.NoError(t, eth.VerifyPersonalMessage(address, message, compact))
+
+	// v may also be the parity bit
+	parity := append([]byte{}, compact...)
+	parity[64] -= 27
+	signer, err = eth.RecoverPersonalMessage(message, parity)
+	require.NoError(t, err)
+	require.Equal(t, address.String(), signer.String())
+
+	require.Error(t, eth.VerifyPersonalMessage(address, []byte("Hello World!"), compact))
+
+	_, err = eth.RecoverPersonalMessage(message, compact[:64])
+	require.Error(t, err)
+
+	invalid := append([]byte{}, compact...)
+	invalid[64] = 29
+	_, err = eth.RecoverPersonalMessage(message, invalid)
+	require.Error(t, err)
+}
+
+func TestSignValidatorMessage(t *testing.T) {
+	key := eth.MustData("0x0123456789012345678901234567890123456789012345678901234567890123").Bytes()
+	validator := *eth.MustAddress("0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC")
+	data := []byte{0xde, 0xad, 0xbe, 0xef}
+
+	keccak := sha3.NewLegacyKeccak256()
+	keccak.Write([]byte{0x19, 0x00})
+	keccak.Write(validator.Bytes())
+	keccak.Write(data)
+	require.Equal(t, "0x"+hex.EncodeToString(keccak.Sum(nil)), eth.HashValidatorMessage(validator, data).String())
+
+	sig, err := eth.SignValidatorMessage(validator, data, key)
+	require.NoError(t, err)
+
+	signer, err := eth.RecoverValidatorMessage(validator, data, sig.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, "0x14791697260E4c9A71f18484C9f997B308e59325", signer.String())
+
+	// the signature is bound to the validator
+	other, err := eth.RecoverValidatorMessage(*eth.MustAddress("0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB"), data, sig.Bytes())
+	require.NoError(t, err)
+	require.NotEqual(t, signer.String(), other.String())
+}
+
+func TestNewSignatureFromBytes(t *testing.T) {
+	key := eth.MustData("0x0123456789012345678901234567890123456789012345678901234567890123").Bytes()
+	h := eth.HashPersonalMessage([]byte("Hello World"))
+	signed, err := eth.ECSign(&h, key, eth.QuantityFromInt64(0))
+	require.NoError(t, err)
+
+	compact := signed.Bytes()
+	sig, err := eth.NewSignatureFromBytes(compact)
+	require.NoError(t, err)
+
+	r, s, v := sig.EIP2718Values()
+	expectedR, expectedS, expectedV := signed.EIP2718Values()
+	require.Equal(t, "0x"+hex.EncodeToString(compact[:32]), "0x"+hex.EncodeToString(r.Big().FillBytes(make([]byte, 32))))
+	require.Equal(t, expectedR.Big(), r.Big())
+	require.Equal(t, expectedS.Big(), s.Big())
+	require.Equal(t, expectedV.Int64(), v.Int64())
+	require.Equal(t, compact, sig.Bytes())
+
+	_, err = sig.ChainId()
+	require.Error(t, err)
+
+	_, err = eth.NewSignatureFromBytes(compact[1:])
+	require.Error(t, err)
+}
@@ -47,6 +47,9 @@ type NewHeadsResult struct {
 	ExcessBlobGas *Quantity `json:"excessBlobGas,omitempty"`
 	BlobGasUsed   *Quantity `json:"blobGasUsed,omitempty"`
 
+	// EIP-7685 Execution Layer Requests
+	RequestsHash *Hash `json:"requestsHash,omitempty"`
+
 	// Ethhash POW Fields
 	Nonce   *Data8 `json:"nonce"`
 	MixHash *Data  `json:"mixHash"`
@@ -103,6 +106,9 @@ func (nh *NewHeadsResult) FromBlock(block *Block) {
 		ExcessBlobGas: block.ExcessBlobGas,
 		BlobGasUsed:   block.BlobGasUsed,
 
+		// EIP-7685 Execution Layer Requests
+		RequestsHash: block.RequestsHash,
+
 		flavor: block.flavor,
 	}
 
@@ -180,6 +186,9 @@ func (nh NewHeadsResult) MarshalJSON() ([]byte, error) {
 			ExcessBlobGas *Quantity `json:"excessBlobGas,omitempty"`
 			BlobGasUsed   *Quantity `json:"blobGasUsed,omitempty"`
 
+			// EIP-7685 Execution Layer Requests
+			RequestsHash *Hash `json:"requestsHash,omitempty"`
+
 			Nonce   *Data8 `json:"nonce"`
 			MixHash *Data  `json:"mixHash"`
 		}
@@ -206,6 +215,7 @@ func (nh NewHeadsResult) MarshalJSON() ([]byte, error) {
 			ParentBeaconBlockRoot: nh.ParentBeaconBlockRoot,
 			ExcessBlobGas:         nh.ExcessBlobGas,
 			BlobGasUsed:           nh.BlobGasUsed,
+			RequestsHash:          nh.RequestsHash,
 			Nonce:                 nh.Nonce,
 			MixHash:               nh.MixHash,
 		}
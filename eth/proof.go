@@ -0,0 +1,56 @@
+package eth
+
+import (
+	"encoding/hex"
+	"math/big"
+
+	"github.com/pkg/errors"
+
+	"github.com/INFURA/go-ethlibs/rlp"
+)
+
+// AccountProof is the result of eth_getProof, as described by EIP-1186.
+type AccountProof struct {
+	Address      Address        `json:"address"`
+	AccountProof []Data         `json:"accountProof"`
+	Balance      Quantity       `json:"balance"`
+	CodeHash     Hash           `json:"codeHash"`
+	Nonce        Quantity       `json:"nonce"`
+	StorageHash  Hash           `json:"storageHash"`
+	StorageProof []StorageProof `json:"storageProof"`
+}
+
+// StorageProof is the proof of a single storage slot within an AccountProof.
+type StorageProof struct {
+	Key   Data     `json:"key"`
+	Value Quantity `json:"value"`
+	Proof []Data   `json:"proof"`
+}
+
+// RLP returns the RLP encoding of the account as stored in the state trie: [nonce, balance, storageRoot, codeHash]
+func (a *AccountProof) RLP() rlp.Value {
+	return rlp.Value{List: []rlp.Value{
+		a.Nonce.RLP(),
+		a.Balance.RLP(),
+		a.StorageHash.RLP(),
+		a.CodeHash.RLP(),
+	}}
+}
+
+// Slot returns the storage slot of the proof as a 32 byte hash, since clients return keys as they were requested,
+// which may not be zero-padded.
+func (s *StorageProof) Slot() (*Hash, error) {
+	key := s.Key.String()
+	if len(key) < 3 || len(key) > 66 {
+		return nil, errors.Errorf("invalid storage key %s", key)
+	}
+
+	i, ok := new(big.Int).SetString(key[2:], 16)
+	if !ok {
+		return nil, errors.Errorf("invalid storage key %s", key)
+	}
+
+	b := make([]byte, 32)
+	i.FillBytes(b)
+	return NewHash("0x" + hex.EncodeToString(b))
+}
@@ -0,0 +1,65 @@
+package eth_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/INFURA/go-ethlibs/eth"
+)
+
+func TestAccountProof_UnmarshalJSON(t *testing.T) {
+	payload := `{
+		"address": "0x7f0d15c7faae65896648c8273b6d7e43f58fa842",
+		"accountProof": [
+			"0xf90211a0dc30b0a4e19f57bd1ed72b2e0f4d7ea3c7b5a3e7e1d23c4d5e6f708192a3b4c5",
+			"0xe48200a7a040f916999be583c572cc4dd369ec53b0a99f7de95f13880cf203d98f935ed1b3"
+		],
+		"balance": "0x0",
+		"codeHash": "0xc5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470",
+		"nonce": "0x0",
+		"storageHash": "0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421",
+		"storageProof": [
+			{
+				"key": "0x0",
+				"value": "0x0",
+				"proof": []
+			},
+			{
+				"key": "0x290decd9548b62a8d60345a988386fc84ba6bc95484008f6362f93160ef3e563",
+				"value": "0x2a",
+				"proof": ["0xe48200a7a040f916999be583c572cc4dd369ec53b0a99f7de95f13880cf203d98f935ed1b3"]
+			}
+		]
+	}`
+
+	proof := eth.AccountProof{}
+	err := json.Unmarshal([]byte(payload), &proof)
+	require.NoError(t, err)
+	require.Equal(t, *eth.MustAddress("0x7f0d15c7faae65896648c8273b6d7e43f58fa842"), proof.Address)
+	require.Len(t, proof.AccountProof, 2)
+	require.Len(t, proof.StorageProof, 2)
+	require.Equal(t, uint64(42), proof.StorageProof[1].Value.UInt64())
+
+	{
+		// keys are returned as requested, but slots are always 32 bytes
+		slot, err := proof.StorageProof[0].Slot()
+		require.NoError(t, err)
+		require.Equal(t, eth.Hash("0x0000000000000000000000000000000000000000000000000000000000000000"), *slot)
+
+		slot, err = proof.StorageProof[1].Slot()
+		require.NoError(t, err)
+		require.Equal(t, eth.Hash("0x290decd9548b62a8d60345a988386fc84ba6bc95484008f6362f93160ef3e563"), *slot)
+
+		_, err = (&eth.StorageProof{Key: "0x"}).Slot()
+		require.Error(t, err)
+	}
+
+	{
+		// the account as stored in the state trie
+		encoded, err := proof.RLP().Encode()
+		require.NoError(t, err)
+		require.Equal(t, "0xf8448080a056e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421a0c5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470", encoded)
+	}
+}
@@ -63,19 +63,20 @@ func NewQuantityFromRLP(v rlp.Value) (*Quantity, error) {
 		return nil, errors.New("cannot convert RLP list to Quantity")
 	}
 
-	if v.String == "0x" {
+	s := v.AsHex()
+	if s == "0x" {
 		return NewQuantity("0x0")
 	}
 
-	if v.String == "0x00" {
+	if s == "0x00" {
 		return NewQuantity("0x0")
 	}
 
-	if strings.HasPrefix(v.String, "0x0") {
-		return NewQuantity(strings.Replace(v.String, "0x0", "0x", 1))
+	if strings.HasPrefix(s, "0x0") {
+		return NewQuantity(strings.Replace(s, "0x0", "0x", 1))
 	}
 
-	return NewQuantity(v.String)
+	return NewQuantity(s)
 }
 
 func OptionalQuantityFromInt(value int) *Quantity {
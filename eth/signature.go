@@ -2,6 +2,7 @@ package eth
 
 import (
 	"encoding/hex"
+	"math/big"
 
 	"github.com/decred/dcrd/dcrec/secp256k1/v4"
 	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
@@ -9,6 +10,12 @@ import (
 	"golang.org/x/crypto/sha3"
 )
 
+var (
+	// secp256k1N is the order of the secp256k1 curve, and secp256k1HalfN the largest s value allowed by EIP-2.
+	secp256k1N, _  = new(big.Int).SetString("fffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364141", 16)
+	secp256k1HalfN = new(big.Int).Rsh(secp256k1N, 1)
+)
+
 type Signature struct {
 	r       Quantity
 	s       Quantity
@@ -64,6 +71,30 @@ func NewEIP155Signature(r Quantity, s Quantity, v Quantity) (*Signature, error)
 	}
 }
 
+// NewSignatureFromBytes creates a new Signature from its 65 byte [r || s || v] form, as produced by wallets for
+// personal_sign and eth_signTypedData.  V may be either the 0 or 1 parity bit, or 27 or 28.
+func NewSignatureFromBytes(sig []byte) (*Signature, error) {
+	if len(sig) != 65 {
+		return nil, errors.Errorf("signature must be 65 bytes long, not %d", len(sig))
+	}
+
+	v := int64(sig[64])
+	switch v {
+	case 27, 28:
+		v -= 27
+	case 0, 1:
+	default:
+		return nil, errors.Errorf("invalid signature V value %d", v)
+	}
+
+	return &Signature{
+		r:       QuantityFromBigInt(new(big.Int).SetBytes(sig[:32])),
+		s:       QuantityFromBigInt(new(big.Int).SetBytes(sig[32:64])),
+		v:       QuantityFromInt64(v),
+		chainId: QuantityFromInt64(0),
+	}, nil
+}
+
 // ECSign returns the signature values for a given message hash for the given chainId using the bytes of given
 // private key.  Primarily used to sign transactions before submitting them with eth_sendRawTransaction.
 //
@@ -129,6 +160,16 @@ func (s *Signature) EIP2718Values() (R Quantity, S Quantity, V Quantity) {
 	return s.r, s.s, s.v
 }
 
+// Bytes returns the 65 byte [r || s || v] form of the signature, where v is 27 or 28 as expected by wallets and by
+// the ecrecover precompile.
+func (s *Signature) Bytes() []byte {
+	b := make([]byte, 65)
+	s.r.Big().FillBytes(b[:32])
+	s.s.Big().FillBytes(b[32:64])
+	b[64] = byte(s.v.Int64() + 27)
+	return b
+}
+
 // Recover performs ECRecover on the supplied hash using the signatures R, S, and V values,
 // returning the sender Address or an error.
 func (s *Signature) Recover(hash *Hash) (*Address, error) {
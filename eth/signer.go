@@ -0,0 +1,94 @@
+package eth
+
+//go:generate mockgen -source=signer.go -destination=mocks/signer.go -package=mock
+
+import (
+	"encoding/hex"
+	"strings"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/pkg/errors"
+)
+
+// Signer signs hashes on behalf of an account, e.g. with a private key held in memory or by a remote service.
+type Signer interface {
+	// Address returns the address of the account the signer signs for
+	Address() Address
+
+	// SignHash signs a hash, returning a Signature whose V value is the 0 or 1 recovery id
+	SignHash(hash Hash) (*Signature, error)
+}
+
+// TransactionSigner is implemented by signers that sign whole transactions rather than hashes, such as remote signers
+// that need to know what they are signing.  Transaction.SignWith uses it instead of Signer.SignHash when available.
+type TransactionSigner interface {
+	Signer
+
+	// SignTransaction returns the raw signed transaction
+	SignTransaction(tx *Transaction, chainId Quantity) (*Data, error)
+}
+
+// MessageSigner is implemented by signers that sign EIP-191 personal messages themselves rather than their hash.
+// SignPersonalMessageWith uses it instead of Signer.SignHash when available.
+type MessageSigner interface {
+	Signer
+
+	// SignPersonalMessage signs a message as personal_sign does
+	SignPersonalMessage(message []byte) (*Signature, error)
+}
+
+// KeySigner is a Signer using a private key held in memory.
+type KeySigner struct {
+	key     []byte
+	address Address
+}
+
+// NewKeySigner returns a Signer using the bytes of a secp256k1 private key.
+func NewKeySigner(privKeyBytes []byte) (*KeySigner, error) {
+	if len(privKeyBytes) != 32 {
+		return nil, errors.Errorf("private key must be 32 bytes long, not %d", len(privKeyBytes))
+	}
+
+	priv := secp256k1.PrivKeyFromBytes(privKeyBytes)
+	if priv.Key.IsZero() {
+		return nil, errors.New("invalid private key")
+	}
+
+	addr, err := pubKeyBytesToAddress(priv.PubKey().SerializeUncompressed())
+	if err != nil {
+		return nil, errors.Wrap(err, "could not convert key to ethereum address")
+	}
+
+	key := make([]byte, len(privKeyBytes))
+	copy(key, privKeyBytes)
+	return &KeySigner{key: key, address: *addr}, nil
+}
+
+// NewKeySignerFromHex returns a Signer using a hex-encoded private key, with or without the 0x prefix.
+func NewKeySignerFromHex(privateKey string) (*KeySigner, error) {
+	b, err := hex.DecodeString(strings.TrimPrefix(privateKey, "0x"))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decode private key")
+	}
+
+	return NewKeySigner(b)
+}
+
+// Address returns the address of the key.
+func (s *KeySigner) Address() Address {
+	return s.address
+}
+
+// SignHash signs a hash with the key.
+func (s *KeySigner) SignHash(hash Hash) (*Signature, error) {
+	return ECSign(&hash, s.key, QuantityFromInt64(0))
+}
+
+// SignPersonalMessageWith signs a message with the signer as personal_sign does, see HashPersonalMessage.
+func SignPersonalMessageWith(signer Signer, message []byte) (*Signature, error) {
+	if ms, ok := signer.(MessageSigner); ok {
+		return ms.SignPersonalMessage(message)
+	}
+
+	return signer.SignHash(HashPersonalMessage(message))
+}
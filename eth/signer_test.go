@@ -0,0 +1,115 @@
+package eth_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/INFURA/go-ethlibs/eth"
+	mock "github.com/INFURA/go-ethlibs/eth/mocks"
+)
+
+func signerTestTransaction() eth.Transaction {
+	return eth.Transaction{
+		Nonce:    eth.QuantityFromUInt64(146),
+		GasPrice: eth.OptionalQuantityFromInt(3000000000),
+		Gas:      eth.QuantityFromUInt64(22000),
+		To:       eth.MustAddress("0x43700db832E9Ac990D36d6279A846608643c904E"),
+		Value:    eth.QuantityFromUInt64(1000000000),
+		Input:    *eth.MustInput("0x"),
+	}
+}
+
+func TestTransaction_SignWith(t *testing.T) {
+	chainId := eth.QuantityFromInt64(1)
+	signer, err := eth.NewKeySignerFromHex("fad9c8855b740a0b7ed4c221dbad0f33a83a49cad6b3fe8d5817ac83d38b6a19")
+	require.NoError(t, err)
+	require.Equal(t, "0x96216849c49358B10257cb55b28eA603c874b05E", signer.Address().String())
+
+	tx := signerTestTransaction()
+	raw, err := tx.SignWith(signer, chainId)
+	require.NoError(t, err)
+	require.Equal(t, signer.Address().String(), tx.From.String())
+
+	expected := signerTestTransaction()
+	expectedRaw, err := expected.Sign("0xfad9c8855b740a0b7ed4c221dbad0f33a83a49cad6b3fe8d5817ac83d38b6a19", chainId)
+	require.NoError(t, err)
+	require.Equal(t, expectedRaw.String(), raw.String())
+	require.Equal(t, expected.Hash, tx.Hash)
+}
+
+func TestTransaction_SignWith_Mock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	chainId := eth.QuantityFromInt64(1)
+	key, err := eth.NewKeySignerFromHex("fad9c8855b740a0b7ed4c221dbad0f33a83a49cad6b3fe8d5817ac83d38b6a19")
+	require.NoError(t, err)
+
+	tx := signerTestTransaction()
+	hash, err := tx.SigningHash(chainId)
+	require.NoError(t, err)
+
+	signature, err := key.SignHash(*hash)
+	require.NoError(t, err)
+
+	// an HSM backed signer is handed the signing hash
+	signer := mock.NewMockSigner(ctrl)
+	signer.EXPECT().Address().Return(key.Address()).AnyTimes()
+	signer.EXPECT().SignHash(*hash).Return(signature, nil)
+
+	_, err = tx.SignWith(signer, chainId)
+	require.NoError(t, err)
+	require.Equal(t, key.Address().String(), tx.From.String())
+	require.True(t, tx.IsProtected())
+
+	// signatures by another key are rejected
+	other := mock.NewMockSigner(ctrl)
+	other.EXPECT().Address().Return(*eth.MustAddress("0x14791697260E4c9A71f18484C9f997B308e59325")).AnyTimes()
+	other.EXPECT().SignHash(gomock.Any()).Return(signature, nil)
+
+	tx = signerTestTransaction()
+	_, err = tx.SignWith(other, chainId)
+	require.Error(t, err)
+
+	// and signing errors are returned as is
+	failing := mock.NewMockSigner(ctrl)
+	failing.EXPECT().SignHash(gomock.Any()).Return(nil, errors.New("hsm unavailable"))
+
+	_, err = tx.SignWith(failing, chainId)
+	require.EqualError(t, err, "hsm unavailable")
+}
+
+func TestSignPersonalMessageWith(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	key, err := eth.NewKeySignerFromHex("0x0123456789012345678901234567890123456789012345678901234567890123")
+	require.NoError(t, err)
+
+	message := []byte("Hello World")
+	sig, err := eth.SignPersonalMessageWith(key, message)
+	require.NoError(t, err)
+	require.NoError(t, eth.VerifyPersonalMessage(key.Address(), message, sig.Bytes()))
+
+	// message signers sign the message rather than its hash
+	signer := mock.NewMockMessageSigner(ctrl)
+	signer.EXPECT().SignPersonalMessage(message).Return(sig, nil)
+
+	signed, err := eth.SignPersonalMessageWith(signer, message)
+	require.NoError(t, err)
+	require.Equal(t, sig, signed)
+}
+
+func TestNewKeySigner(t *testing.T) {
+	_, err := eth.NewKeySigner(make([]byte, 31))
+	require.Error(t, err)
+
+	_, err = eth.NewKeySigner(make([]byte, 32))
+	require.Error(t, err)
+
+	_, err = eth.NewKeySignerFromHex("0xnothex")
+	require.Error(t, err)
+}
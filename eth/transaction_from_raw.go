@@ -1,6 +1,7 @@
 package eth
 
 import (
+	"encoding/hex"
 	"reflect"
 	"strings"
 
@@ -14,12 +15,43 @@ import (
 // after EIP-2718 the payload format depends on the transaction type included as the first byte.
 // Unsigned transactions where R, S, and V are zero are not currently supported.
 func (t *Transaction) FromRaw(input string) error {
+	return t.FromRawWithOptions(input, rlp.Options{})
+}
+
+// FromRawWithOptions is like FromRaw, but validates the RLP encoding of the transaction according to opts.  With
+// opts.Strict, integers with leading zero bytes are rejected with rlp.ErrCanonInt as well, so that only the encodings
+// accepted by geth are decoded.
+func (t *Transaction) FromRawWithOptions(input string, opts rlp.Options) error {
 	// Code was originally heavily inspired by ethers.js v4 utils.transaction.parse:
 	// https://github.com/ethers-io/ethers.js/blob/v4-legacy/utils/transaction.js#L90
 	// Copyright (c) 2017 Richard Moore
 	//
 	// However it's since been somewhat extensively rewritten to support EIP-2718 and -2930
 
+	if !strings.HasPrefix(input, "0x") {
+		return errors.New("input must start with 0x")
+	}
+
+	if len(input) < 4 {
+		return errors.New("not enough input to decode")
+	}
+
+	raw, err := hex.DecodeString(input[2:])
+	if err != nil {
+		return errors.Wrap(err, "could not decode raw transaction hex")
+	}
+
+	return t.fromRawBytes(raw, opts)
+}
+
+// fromRawBytes populates a Transaction's fields from the raw transaction bytes, see FromRawWithOptions.
+func (t *Transaction) fromRawBytes(input []byte, opts rlp.Options) error {
+	if len(input) == 0 {
+		return errors.New("not enough input to decode")
+	}
+
+	firstByte := input[0]
+
 	var (
 		chainId              Quantity
 		nonce                Quantity
@@ -39,26 +71,11 @@ func (t *Transaction) FromRaw(input string) error {
 		blobVersionedHashes  []Hash
 	)
 
-	if !strings.HasPrefix(input, "0x") {
-		return errors.New("input must start with 0x")
-	}
-
-	if len(input) < 4 {
-		return errors.New("not enough input to decode")
-	}
-
-	var firstByte byte
-	if prefix, err := NewData(input[:4]); err != nil {
-		return errors.Wrap(err, "could not inspect transaction prefix")
-	} else {
-		firstByte = prefix.Bytes()[0]
-	}
-
 	switch {
 	case firstByte == byte(TransactionTypeAccessList):
 		// EIP-2930 transaction
-		payload := "0x" + input[4:]
-		if err := rlpDecodeList(payload, &chainId, &nonce, &gasPrice, &gasLimit, &to, &value, &data, &accessList, &v, &r, &s); err != nil {
+		payload := input[1:]
+		if err := rlpDecodeList(opts, payload, &chainId, &nonce, &gasPrice, &gasLimit, &to, &value, &data, &accessList, &v, &r, &s); err != nil {
 			return errors.Wrap(err, "could not decode RLP components")
 		}
 
@@ -105,9 +122,9 @@ func (t *Transaction) FromRaw(input string) error {
 		return nil
 	case firstByte == byte(TransactionTypeDynamicFee):
 		// EIP-1559 transaction
-		payload := "0x" + input[4:]
+		payload := input[1:]
 		// 0x02 || rlp([chainId, nonce, maxPriorityFeePerGas, maxFeePerGas, gasLimit, to, value, data, access_list, signatureYParity, signatureR, signatureS])
-		if err := rlpDecodeList(payload, &chainId, &nonce, &maxPriorityFeePerGas, &maxFeePerGas, &gasLimit, &to, &value, &data, &accessList, &v, &r, &s); err != nil {
+		if err := rlpDecodeList(opts, payload, &chainId, &nonce, &maxPriorityFeePerGas, &maxFeePerGas, &gasLimit, &to, &value, &data, &accessList, &v, &r, &s); err != nil {
 			return errors.Wrap(err, "could not decode RLP components")
 		}
 
@@ -167,8 +184,11 @@ func (t *Transaction) FromRaw(input string) error {
 		//
 		// Or just the tx payload body:
 		// 0x03 || rlp([chain_id, nonce, max_priority_fee_per_gas, max_fee_per_gas, gas_limit, to, value, data, access_list, max_fee_per_blob_gas, blob_versioned_hashes, y_parity, r, s])
-		payload := "0x" + input[4:]
-		decoded, err := rlp.From(payload)
+		payload := input[1:]
+		decoded, err := rlp.FromBytesWithOptions(payload, opts)
+		if err != nil {
+			return errors.Wrap(err, "could not decode RLP payload")
+		}
 
 		switch len(decoded.List) {
 		case 14:
@@ -177,7 +197,7 @@ func (t *Transaction) FromRaw(input string) error {
 		case 4:
 			// TransactionPayloadBody is itself an RLP list of:
 			// [chain_id, nonce, max_priority_fee_per_gas, max_fee_per_gas, gas_limit, to, value, data, access_list, max_fee_per_blob_gas, blob_versioned_hashes, y_parity, r, s]
-			if err := rlpDecodeList(decoded, &body, &blobs, &commitments, &proofs); err != nil {
+			if err := rlpDecodeList(opts, decoded, &body, &blobs, &commitments, &proofs); err != nil {
 				return err
 			}
 			hasBlobs = true
@@ -185,7 +205,7 @@ func (t *Transaction) FromRaw(input string) error {
 			return errors.New("blob transaction invalid tx RLP length")
 		}
 
-		if err := rlpDecodeList(&body, &chainId, &nonce, &maxPriorityFeePerGas, &maxFeePerGas, &gasLimit, &to, &value, &data, &accessList, &maxFeePerBlobGas, &blobVersionedHashes, &v, &r, &s); err != nil {
+		if err := rlpDecodeList(opts, &body, &chainId, &nonce, &maxPriorityFeePerGas, &maxFeePerGas, &gasLimit, &to, &value, &data, &accessList, &maxFeePerBlobGas, &blobVersionedHashes, &v, &r, &s); err != nil {
 			return errors.Wrap(err, "could not decode RLP components")
 		}
 
@@ -193,30 +213,8 @@ func (t *Transaction) FromRaw(input string) error {
 			return errors.New("mismatched blob field counts")
 		}
 
-		// TODO: at this point we could verify these two constraints
-		//
-		// - blobVersionedHashes[i] = "0x01" + sha256(commitments[i])[4:]
-		// - the KZG commitments match the corresponding blobs and proofs
-		//
-		// However, this all requires additionally implementing a sha256 method
-		// for eth.Data and the use of a KZG proof framework, both of which
-		// feel outside the scope of this package, especially considering
-		// that these fields are not exposed at the JSONRPC layer which is
-		// our primary focus.
-		//
-		// In pseudocode this all would look something like:
-		//
-		//  for i := range blobVersionedHashes {
-		//	  blobHash := commitments[i].Sha256()
-		//	  versionedHash := "0x01" + blobHash[4:]
-		//	  if blobVersionedHashes[i] != versionedHash {
-		//	    return errors.New("incorrect blob versioned hash")
-		//	  }
-		//	  if err := kzg_verify_proofs(commitments[i], blobs[i], proofs[i]); err != nil {
-		//	    return err
-		//	  }
-		//  }
-		//
+		// The versioned hashes and KZG proofs of the blobs aren't checked here since verifying the proofs is expensive,
+		// callers which need to can use Transaction.VerifyBlobBundle.
 
 		if r.Int64() == 0 && s.Int64() == 0 {
 			return errors.New("unsigned transactions not supported")
@@ -275,8 +273,8 @@ func (t *Transaction) FromRaw(input string) error {
 	case firstByte == byte(TransactionTypeSetCode):
 		// EIP-7702 transaction
 		// 0x04 || rlp([chainID, nonce, max_priority_fee_per_gas, max_fee_per_gas, gas_limit, to, value, data, access_list, authorization_list, y_parity, r, s])
-		payload := "0x" + input[4:]
-		decodedErr := rlpDecodeList(payload, &chainId, &nonce, &maxPriorityFeePerGas, &maxFeePerGas, &gasLimit, &to, &value, &data, &accessList, &authorizationList, &v, &r, &s)
+		payload := input[1:]
+		decodedErr := rlpDecodeList(opts, payload, &chainId, &nonce, &maxPriorityFeePerGas, &maxFeePerGas, &gasLimit, &to, &value, &data, &accessList, &authorizationList, &v, &r, &s)
 		if decodedErr != nil {
 			return errors.Wrap(decodedErr, "could not decode RLP components")
 		}
@@ -325,7 +323,7 @@ func (t *Transaction) FromRaw(input string) error {
 		// In EIP-2718 types larger than 0x7f are reserved since they potentially conflict with legacy RLP encoded
 		// transactions.  As such we can attempt to decode any such transactions as legacy format and attempt to
 		// decode the input string as an rlp.Value
-		if err := rlpDecodeList(input, &nonce, &gasPrice, &gasLimit, &to, &value, &data, &v, &r, &s); err != nil {
+		if err := rlpDecodeList(opts, input, &nonce, &gasPrice, &gasLimit, &to, &value, &data, &v, &r, &s); err != nil {
 			return errors.Wrap(err, "could not decode RLP components")
 		}
 
@@ -376,7 +374,9 @@ func (t *Transaction) FromRaw(input string) error {
 // rlpDecodeList decodes an RLP list into the passed in receivers.  Currently only the receiver types needed for
 // legacy and EIP-2930 transactions are implemented, new receivers can easily be added in the for loop.
 //
-// input is either a string or pointer to an rlp.Value, if it's a string then it's assumed to be RLP encoded and is decoded first
+// input is either a byte slice or pointer to an rlp.Value, if it's a byte slice then it's assumed to be RLP encoded and
+// is decoded first, validated according to opts.  With opts.Strict, Quantity receivers and the integers of
+// authorization lists must not have leading zero bytes.
 //
 // Note that when calling this function, the receivers MUST be pointers never values, and for "optional" receivers
 // such as Address a pointer to a pointer must be passed.  For example:
@@ -385,20 +385,22 @@ func (t *Transaction) FromRaw(input string) error {
 //	  addr  *eth.Address
 //	  nonce eth.Quantity
 //	)
-//	err := rlpDecodeList(payload, &addr, &nonce)
+//	err := rlpDecodeList(rlp.Options{}, payload, &addr, &nonce)
 //
 // TODO: Consider making this function public once all receiver types in the eth package are supported.
-func rlpDecodeList(input interface{}, receivers ...interface{}) error {
+func rlpDecodeList(opts rlp.Options, input interface{}, receivers ...interface{}) error {
 	var decoded *rlp.Value
 	switch i := input.(type) {
-	case string:
-		if d, err := rlp.From(i); err != nil {
+	case []byte:
+		if d, err := rlp.FromBytesWithOptions(i, opts); err != nil {
 			return err
 		} else {
 			decoded = d
 		}
 	case *rlp.Value:
 		decoded = i
+	default:
+		return errors.Errorf("unsupported decode input %s", reflect.TypeOf(input).String())
 	}
 
 	if len(decoded.List) < len(receivers) {
@@ -409,29 +411,34 @@ func rlpDecodeList(input interface{}, receivers ...interface{}) error {
 		value := decoded.List[i]
 		switch receiver := receivers[i].(type) {
 		case *Quantity:
+			if opts.Strict {
+				if err := canonicalInt(value); err != nil {
+					return errors.Wrapf(err, "could not decode list item %d to Quantity", i)
+				}
+			}
 			q, err := NewQuantityFromRLP(value)
 			if err != nil {
 				return errors.Wrapf(err, "could not decode list item %d to Quantity", i)
 			}
 			*receiver = *q
 		case **Address:
-			if value.String == "0x" {
+			if value.AsHex() == "0x" {
 				*receiver = nil
 			} else {
-				a, err := NewAddress(value.String)
+				a, err := NewAddress(value.AsHex())
 				if err != nil {
 					return errors.Wrapf(err, "could not decode list item %d to Address", i)
 				}
 				*receiver = a
 			}
 		case *Input:
-			d, err := NewInput(value.String)
+			d, err := NewInput(value.AsHex())
 			if err != nil {
 				return errors.Wrapf(err, "could not decode list item %d to Input", i)
 			}
 			*receiver = *d
 		case *Data:
-			d, err := NewData(value.String)
+			d, err := NewData(value.AsHex())
 			if err != nil {
 				return errors.Wrapf(err, "could not decode list item %d to Data", i)
 			}
@@ -439,7 +446,7 @@ func rlpDecodeList(input interface{}, receivers ...interface{}) error {
 		case *[]Data:
 			*receiver = make([]Data, len(value.List))
 			for j := range value.List {
-				d, err := NewData(value.List[j].String)
+				d, err := NewData(value.List[j].AsHex())
 				if err != nil {
 					return errors.Wrapf(err, "could not decode list item %d %d to Data", i, j)
 				}
@@ -448,7 +455,7 @@ func rlpDecodeList(input interface{}, receivers ...interface{}) error {
 		case *[]Data32:
 			*receiver = make([]Data32, len(value.List))
 			for j := range value.List {
-				d, err := NewData32(value.List[j].String)
+				d, err := NewData32(value.List[j].AsHex())
 				if err != nil {
 					return errors.Wrapf(err, "could not decode list item %d %d to Data", i, j)
 				}
@@ -463,6 +470,18 @@ func rlpDecodeList(input interface{}, receivers ...interface{}) error {
 			}
 			*receiver = accessList
 		case *AuthorizationList:
+			if opts.Strict {
+				for j := range value.List {
+					// chain id, nonce, y parity, r and s are integers, the address isn't
+					for _, k := range []int{0, 2, 3, 4, 5} {
+						if k < len(value.List[j].List) {
+							if err := canonicalInt(value.List[j].List[k]); err != nil {
+								return errors.Wrapf(err, "could not decode list item %d authorization %d", i, j)
+							}
+						}
+					}
+				}
+			}
 			authorizationList, err := NewAuthorizationListFromRLP(value)
 			if err != nil {
 				return errors.Wrapf(err, "could not decode list item %d to AuthorizationList", i)
@@ -475,3 +494,13 @@ func rlpDecodeList(input interface{}, receivers ...interface{}) error {
 
 	return nil
 }
+
+// canonicalInt returns rlp.ErrCanonInt if the RLP string is an integer with leading zero bytes, including zero encoded
+// as 0x00 rather than as the empty string.
+func canonicalInt(value rlp.Value) error {
+	if !value.IsList() && strings.HasPrefix(value.AsHex(), "0x00") {
+		return errors.Wrapf(rlp.ErrCanonInt, "integer %s", value.AsHex())
+	}
+
+	return nil
+}
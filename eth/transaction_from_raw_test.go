@@ -7,6 +7,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 
 	"github.com/INFURA/go-ethlibs/eth"
@@ -216,6 +217,55 @@ func TestTransaction_FromRawEIP7702(t *testing.T) {
 	require.Equal(t, "0x6cf7c3d7939bfdb784373effc0ebb0bd7549691a513f395e3cdabf8602724987", firstAuth.S.String())
 }
 
+func TestTransaction_FromRawWithOptions_Strict(t *testing.T) {
+	// EIP-1559 transaction from TestTransaction_Sign_EIP1559
+	raw := `0x02f86a038085039b2eb2008507365d64008094df0a88b2b68c673713a8ec826003676f272e35730180c080a0f0019f2823699d9c29de7da61088f020dff2014bc542d25082715081cce4d64aa01ee67c1cc8c4063e5cf3d9fbab8abf42a1f653ee41725786365f74784c8e213b`
+	strict := rlp.Options{Strict: true}
+
+	tx := eth.Transaction{}
+	require.NoError(t, tx.FromRawWithOptions(raw, strict))
+	require.Equal(t, "0xd7c478283b7b89becd235f0ae877cb3b39f9e8634ca9466d4d6609b3ea4c82b1", tx.Hash.String())
+
+	tests := []struct {
+		name string
+		raw  string
+	}{
+		{
+			// nonce 0 encoded as 0x00 rather than the empty string
+			name: "zero nonce",
+			raw:  strings.Replace(raw, "0x02f86a0380", "0x02f86a0300", 1),
+		},
+		{
+			// value 1 encoded as 0x0001
+			name: "leading zero value",
+			raw:  strings.Replace(strings.Replace(raw, "0x02f86a", "0x02f86c", 1), "e3573018", "e35738200018", 1),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// geth refuses these, but they can be decoded when not strict
+			tx := eth.Transaction{}
+			require.NoError(t, tx.FromRaw(tt.raw))
+
+			err := tx.FromRawWithOptions(tt.raw, strict)
+			require.Error(t, err)
+			require.Equal(t, rlp.ErrCanonInt, errors.Cause(err))
+		})
+	}
+
+	// the integers of authorizations are checked as well, here the chain id of the second one is encoded as 0x00
+	setCode := `0x04f9012201800285012a05f2008307a1209471562b71999873db5b286df957af199ec94617f78080c0f8b8f85a0194000000000000000000000000000000000000aaaa0101a0f7e3e597fc097e71ed6c26b14b25e5395bc8510d58b9136af439e12715f2d721a06cf7c3d7939bfdb784373effc0ebb0bd7549691a513f395e3cdabf8602724987f85a8094000000000000000000000000000000000000bbbb8001a05011890f198f0356a887b0779bde5afa1ed04e6acb1e3f37f8f18c7b6f521b98a056c3fa3456b103f3ef4a0acb4b647b9cab9ec4bc68fbcdf1e10b49fb2bcbcf6101a06d5ddb9420ce5d9ff7d1bc6fcf1098cc648a68207489c0fcfee54dc61352353aa04ba532c2cfc4a1163e7ffd3d92dd5815b37ddcfa3293e1c4ee86f9604b6b59a2`
+	require.NoError(t, tx.FromRawWithOptions(setCode, strict))
+
+	err := tx.FromRawWithOptions(strings.Replace(setCode, "f85a8094", "f85a0094", 1), strict)
+	require.Equal(t, rlp.ErrCanonInt, errors.Cause(err))
+
+	// and the rules of rlp.Options apply to the encoding itself
+	err = tx.FromRawWithOptions(raw, rlp.Options{MaxSize: 16})
+	require.Equal(t, rlp.ErrMaxSize, errors.Cause(err))
+}
+
 func TestTransaction_FromRaw_BesuBlobs(t *testing.T) {
 	// The tests in this function are derived from:
 	// https://github.com/hyperledger/besu/blob/main/ethereum/core/src/test/java/org/hyperledger/besu/ethereum/core/encoding/BlobTransactionEncodingTest.java#L42
@@ -1,5 +1,13 @@
 package eth
 
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/INFURA/go-ethlibs/rlp"
+)
+
 type TransactionReceipt struct {
 	Type              *Quantity `json:"type,omitempty"`
 	TransactionHash   Hash      `json:"transactionHash"`
@@ -30,3 +38,72 @@ func (t *TransactionReceipt) TransactionType() int64 {
 
 	return t.Type.Int64()
 }
+
+// RLP returns the consensus RLP encoding of the receipt, which is the same for every transaction type:
+// [status or post-state root, cumulativeGasUsed, logsBloom, logs]
+func (t *TransactionReceipt) RLP() rlp.Value {
+	var status rlp.Value
+	switch {
+	case t.Root != nil:
+		// Pre-byzantium receipts contain the intermediate state root instead of a status
+		status = t.Root.RLP()
+	case t.Status != nil:
+		status = t.Status.RLP()
+	default:
+		status = rlp.Value{String: "0x"}
+	}
+
+	logs := rlp.Value{List: make([]rlp.Value, len(t.Logs))}
+	for i := range t.Logs {
+		logs.List[i] = t.Logs[i].RLP()
+	}
+
+	return rlp.Value{List: []rlp.Value{
+		status,
+		t.CumulativeGasUsed.RLP(),
+		t.LogsBloom.RLP(),
+		logs,
+	}}
+}
+
+// RawRepresentation returns the receipt encoded as a raw hexadecimal data string as it is stored in the receipts
+// trie, which for EIP-2718 typed transactions is the transaction type followed by the RLP encoded receipt.
+func (t *TransactionReceipt) RawRepresentation() (*Data, error) {
+	encoded, err := t.RLP().Encode()
+	if err != nil {
+		return nil, err
+	}
+
+	switch t.TransactionType() {
+	case TransactionTypeLegacy:
+		return NewData(encoded)
+	case TransactionTypeAccessList, TransactionTypeDynamicFee, TransactionTypeBlob, TransactionTypeSetCode:
+		typePrefix, err := t.Type.RLP().Encode()
+		if err != nil {
+			return nil, err
+		}
+		return NewData(typePrefix + encoded[2:])
+	default:
+		return nil, errors.New("unsupported transaction type")
+	}
+}
+
+// ComputeLogsBloom returns the bloom filter of the receipt's logs, which should be equal to its .LogsBloom.
+func (t *TransactionReceipt) ComputeLogsBloom() Data256 {
+	b := Bloom{}
+	for i := range t.Logs {
+		b.AddLog(t.Logs[i])
+	}
+
+	return b.Value()
+}
+
+// VerifyLogsBloom returns an error if the receipt's .LogsBloom doesn't match the bloom filter of its logs.
+func (t *TransactionReceipt) VerifyLogsBloom() error {
+	computed := t.ComputeLogsBloom()
+	if !strings.EqualFold(computed.String(), t.LogsBloom.String()) {
+		return errors.Errorf("logs bloom %s does not match the bloom of the receipt logs %s", t.LogsBloom, computed)
+	}
+
+	return nil
+}
@@ -0,0 +1,108 @@
+package eth
+
+import (
+	"encoding/hex"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/INFURA/go-ethlibs/rlp"
+)
+
+// FromRaw populates the consensus fields of a TransactionReceipt from a raw receipt supplied as a hexadecimal encoded
+// string, such as the items returned by debug_getRawReceipts.  For receipts of EIP-2718 typed transactions the
+// first byte is the transaction type, followed by the RLP encoded receipt.
+func (t *TransactionReceipt) FromRaw(input string) error {
+	if !strings.HasPrefix(input, "0x") {
+		return errors.New("input must start with 0x")
+	}
+
+	if len(input) < 4 {
+		return errors.New("not enough input to decode")
+	}
+
+	raw, err := hex.DecodeString(input[2:])
+	if err != nil {
+		return errors.Wrap(err, "could not decode raw receipt hex")
+	}
+
+	return t.fromRawBytes(raw)
+}
+
+// fromRawBytes populates a TransactionReceipt's consensus fields from the raw receipt bytes, see FromRaw.
+func (t *TransactionReceipt) fromRawBytes(input []byte) error {
+	if len(input) == 0 {
+		return errors.New("not enough input to decode")
+	}
+
+	var typ *Quantity
+	switch firstByte := input[0]; firstByte {
+	case byte(TransactionTypeAccessList), byte(TransactionTypeDynamicFee), byte(TransactionTypeBlob), byte(TransactionTypeSetCode):
+		typ = OptionalQuantityFromInt(int(firstByte))
+		input = input[1:]
+	default:
+		if firstByte <= 0x7f {
+			return errors.Errorf("unsupported transaction type %d", firstByte)
+		}
+	}
+
+	decoded, err := rlp.FromBytes(input)
+	if err != nil {
+		return errors.Wrap(err, "could not RLP decode raw receipt")
+	}
+
+	if len(decoded.List) != 4 {
+		return errors.Errorf("expected 4 receipt items but received %d", len(decoded.List))
+	}
+
+	var (
+		root   *Data32
+		status *Quantity
+	)
+
+	// the first item is either the post-state root of pre-byzantium receipts or the status
+	if postState := decoded.List[0].AsHex(); len(postState) == 66 {
+		d, err := NewData32(postState)
+		if err != nil {
+			return errors.Wrap(err, "could not decode receipt post-state root")
+		}
+		root = d
+	} else {
+		q, err := NewQuantityFromRLP(decoded.List[0])
+		if err != nil {
+			return errors.Wrap(err, "could not decode receipt status")
+		}
+		status = q
+	}
+
+	cumulativeGasUsed, err := NewQuantityFromRLP(decoded.List[1])
+	if err != nil {
+		return errors.Wrap(err, "could not decode receipt cumulative gas used")
+	}
+
+	logsBloom, err := NewData256(decoded.List[2].AsHex())
+	if err != nil {
+		return errors.Wrap(err, "could not decode receipt logs bloom")
+	}
+
+	if !decoded.List[3].IsList() {
+		return errors.New("receipt logs must be a list")
+	}
+
+	logs := make([]Log, len(decoded.List[3].List))
+	for i := range decoded.List[3].List {
+		l, err := NewLogFromRLP(decoded.List[3].List[i])
+		if err != nil {
+			return errors.Wrapf(err, "could not decode receipt log %d", i)
+		}
+		logs[i] = *l
+	}
+
+	t.Type = typ
+	t.Root = root
+	t.Status = status
+	t.CumulativeGasUsed = *cumulativeGasUsed
+	t.LogsBloom = *logsBloom
+	t.Logs = logs
+	return nil
+}
@@ -2,6 +2,7 @@ package eth_test
 
 import (
 	"encoding/json"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -240,3 +241,237 @@ func TestTransactionReceipt_4844(t *testing.T) {
 	require.NoError(t, err)
 	require.JSONEq(t, raw, string(b))
 }
+
+func TestTransactionReceipt_FromRaw(t *testing.T) {
+	raw := `{
+    "blockHash": "0xa37f46c4692db33012c105a27b9e4c582e822ed60a54667875fb92def52fd75a",
+    "blockNumber": "0x72991c",
+    "contractAddress": null,
+    "cumulativeGasUsed": "0x7650c2",
+    "from": "0x9e44b7d42125b7bb4e809406ed5e1079ff500969",
+    "gasUsed": "0x5630",
+    "logs": [
+      {
+        "address": "0x21ab6c9fac80c59d401b37cb43f81ea9dde7fe34",
+        "blockHash": "0xa37f46c4692db33012c105a27b9e4c582e822ed60a54667875fb92def52fd75a",
+        "blockNumber": "0x72991c",
+        "data": "0x000000000000000000000000000000000000000000000000000000070560c8c0",
+        "logIndex": "0xb7",
+        "removed": false,
+        "topics": [
+          "0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef",
+          "0x0000000000000000000000009e44b7d42125b7bb4e809406ed5e1079ff500969",
+          "0x000000000000000000000000fe5854255eb1eb921525fa856a3947ed2412a1d7"
+        ],
+        "transactionHash": "0x9d2fb08850a9b38173044ae6a61974fde4eacca504e399ffd9d5c8af567113cc",
+        "transactionIndex": "0x8a"
+      }
+    ],
+    "logsBloom": "0x00000001000000000000000000000000000000000000000000008000000000000000000000010000000000000000000000400000000000000000000000000008000000000000000000000008000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000200000000002000000000000000000000000000000000000000000000000000000000000000008000000000000000000000010000000000000000000000000000000",
+    "status": "0x1",
+    "to": "0x21ab6c9fac80c59d401b37cb43f81ea9dde7fe34",
+    "transactionHash": "0x9d2fb08850a9b38173044ae6a61974fde4eacca504e399ffd9d5c8af567113cc",
+    "transactionIndex": "0x8a"
+  }`
+
+	receipt := eth.TransactionReceipt{}
+	err := json.Unmarshal([]byte(raw), &receipt)
+	require.NoError(t, err)
+	require.NoError(t, receipt.VerifyLogsBloom())
+
+	for _, typ := range []int64{eth.TransactionTypeLegacy, eth.TransactionTypeDynamicFee, eth.TransactionTypeSetCode} {
+		if typ != eth.TransactionTypeLegacy {
+			receipt.Type = eth.OptionalQuantityFromInt(int(typ))
+		}
+
+		encoded, err := receipt.RawRepresentation()
+		require.NoError(t, err)
+
+		decoded := eth.TransactionReceipt{}
+		err = decoded.FromRaw(encoded.String())
+		require.NoError(t, err, "type %d", typ)
+
+		require.Equal(t, receipt.Type, decoded.Type)
+		require.Equal(t, receipt.Status, decoded.Status)
+		require.Nil(t, decoded.Root)
+		require.Equal(t, receipt.CumulativeGasUsed, decoded.CumulativeGasUsed)
+		require.Equal(t, receipt.LogsBloom, decoded.LogsBloom)
+		require.Len(t, decoded.Logs, 1)
+		require.Equal(t, receipt.Logs[0].Address, decoded.Logs[0].Address)
+		require.Equal(t, receipt.Logs[0].Topics, decoded.Logs[0].Topics)
+		require.Equal(t, receipt.Logs[0].Data, decoded.Logs[0].Data)
+		require.NoError(t, decoded.VerifyLogsBloom())
+
+		reencoded, err := decoded.RawRepresentation()
+		require.NoError(t, err)
+		require.Equal(t, encoded.String(), reencoded.String())
+	}
+
+	// pre-byzantium receipts carry a post-state root instead of a status
+	{
+		old := eth.TransactionReceipt{
+			Root:              eth.MustData32("0x57bd5108d8f0b8bad735ab77e2a47b80c166dcf5059b2960e0118b40562c7cf2"),
+			CumulativeGasUsed: eth.QuantityFromInt64(0x1a7a1),
+			LogsBloom:         (&eth.Bloom{}).Value(),
+			Logs:              []eth.Log{},
+		}
+
+		encoded, err := old.RawRepresentation()
+		require.NoError(t, err)
+
+		decoded := eth.TransactionReceipt{}
+		require.NoError(t, decoded.FromRaw(encoded.String()))
+		require.Equal(t, old.Root, decoded.Root)
+		require.Nil(t, decoded.Status)
+		require.Nil(t, decoded.Type)
+	}
+
+	// a tampered bloom must be detected
+	{
+		tampered := receipt
+		tampered.LogsBloom = (&eth.Bloom{}).Value()
+		require.Error(t, tampered.VerifyLogsBloom())
+	}
+
+	// and bad input must be rejected
+	{
+		bad := []string{
+			"",
+			"0x",
+			"c0",
+			"0x7f01",
+			"0x02c0",
+			"0xzz",
+			// logs encoded as a string rather than a list
+			"0xf9010801825208b90100" + strings.Repeat("00", 256) + "01",
+		}
+
+		for _, b := range bad {
+			decoded := eth.TransactionReceipt{}
+			require.Error(t, decoded.FromRaw(b), "input %q", b)
+		}
+	}
+}
+
+func TestTransactionReceipt_FromRaw_Vectors(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		receipt string
+	}{
+		{
+			// the debug_getRawReceipts item of mainnet transaction 0x9d2fb088 at index 0x8a of block 0x72991c
+			name: "legacy",
+			raw:  "0xf901a701837650c2b9010000000001000000000000000000000000000000000000000000008000000000000000000000010000000000000000000000400000000000000000000000000008000000000000000000000008000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000200000000002000000000000000000000000000000000000000000000000000000000000000008000000000000000000000010000000000000000000000000000000f89df89b9421ab6c9fac80c59d401b37cb43f81ea9dde7fe34f863a0ddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3efa00000000000000000000000009e44b7d42125b7bb4e809406ed5e1079ff500969a0000000000000000000000000fe5854255eb1eb921525fa856a3947ed2412a1d7a0000000000000000000000000000000000000000000000000000000070560c8c0",
+			receipt: `{
+				"blockHash": "0xa37f46c4692db33012c105a27b9e4c582e822ed60a54667875fb92def52fd75a",
+				"blockNumber": "0x72991c",
+				"contractAddress": null,
+				"cumulativeGasUsed": "0x7650c2",
+				"from": "0x9e44b7d42125b7bb4e809406ed5e1079ff500969",
+				"gasUsed": "0x5630",
+				"logs": [
+					{
+						"address": "0x21ab6c9fac80c59d401b37cb43f81ea9dde7fe34",
+						"blockHash": "0xa37f46c4692db33012c105a27b9e4c582e822ed60a54667875fb92def52fd75a",
+						"blockNumber": "0x72991c",
+						"data": "0x000000000000000000000000000000000000000000000000000000070560c8c0",
+						"logIndex": "0xb7",
+						"removed": false,
+						"topics": [
+							"0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef",
+							"0x0000000000000000000000009e44b7d42125b7bb4e809406ed5e1079ff500969",
+							"0x000000000000000000000000fe5854255eb1eb921525fa856a3947ed2412a1d7"
+						],
+						"transactionHash": "0x9d2fb08850a9b38173044ae6a61974fde4eacca504e399ffd9d5c8af567113cc",
+						"transactionIndex": "0x8a"
+					}
+				],
+				"logsBloom": "0x00000001000000000000000000000000000000000000000000008000000000000000000000010000000000000000000000400000000000000000000000000008000000000000000000000008000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000200000000002000000000000000000000000000000000000000000000000000000000000000008000000000000000000000010000000000000000000000000000000",
+				"status": "0x1",
+				"to": "0x21ab6c9fac80c59d401b37cb43f81ea9dde7fe34",
+				"transactionHash": "0x9d2fb08850a9b38173044ae6a61974fde4eacca504e399ffd9d5c8af567113cc",
+				"transactionIndex": "0x8a"
+			}`,
+		},
+		{
+			// the debug_getRawReceipts item of dencun-devnet-8 transaction 0x5ceec39b at index 0x0 of block 0x2a1cb
+			name: "blob",
+			raw:  "0x03f9010801825208b9010000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000c0",
+			receipt: `{
+				"blobGasPrice": "0x1",
+				"blobGasUsed": "0x20000",
+				"blockHash": "0xfc2715ff196e23ae613ed6f837abd9035329a720a1f4e8dce3b0694c867ba052",
+				"blockNumber": "0x2a1cb",
+				"contractAddress": null,
+				"cumulativeGasUsed": "0x5208",
+				"effectiveGasPrice": "0x1d1a94a201c",
+				"from": "0xad01b55d7c3448b8899862eb335fbb17075d8de2",
+				"gasUsed": "0x5208",
+				"logs": [],
+				"logsBloom": "0x00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+				"status": "0x1",
+				"to": "0x000000000000000000000000000000000000f1c1",
+				"transactionHash": "0x5ceec39b631763ae0b45a8fb55c373f38b8fab308336ca1dc90ecd2b3cf06d00",
+				"transactionIndex": "0x0",
+				"type": "0x3"
+			}`,
+		},
+		{
+			// the failed dynamic fee receipt encoded by go-ethereum's TestReceiptMarshalBinary
+			name: "dynamic fee",
+			raw:  "0x02f901c58001b9010000000000000010000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000500000000000000000000000000000000000014000000000000000000000000000000000000000000000000000000000000000000000000000010000080000000000000000000004000000000000000000000000000040000000000000000000000000000800000000000000000000000000000000000000000000000000000400000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000f8bef85d940000000000000000000000000000000000000011f842a0000000000000000000000000000000000000000000000000000000000000deada0000000000000000000000000000000000000000000000000000000000000beef830100fff85d940000000000000000000000000000000000000111f842a0000000000000000000000000000000000000000000000000000000000000deada0000000000000000000000000000000000000000000000000000000000000beef830100ff",
+			receipt: `{
+				"cumulativeGasUsed": "0x1",
+				"logs": [
+					{
+						"address": "0x0000000000000000000000000000000000000011",
+						"data": "0x0100ff",
+						"topics": [
+							"0x000000000000000000000000000000000000000000000000000000000000dead",
+							"0x000000000000000000000000000000000000000000000000000000000000beef"
+						]
+					},
+					{
+						"address": "0x0000000000000000000000000000000000000111",
+						"data": "0x0100ff",
+						"topics": [
+							"0x000000000000000000000000000000000000000000000000000000000000dead",
+							"0x000000000000000000000000000000000000000000000000000000000000beef"
+						]
+					}
+				],
+				"logsBloom": "0x00000000000010000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000500000000000000000000000000000000000014000000000000000000000000000000000000000000000000000000000000000000000000000010000080000000000000000000004000000000000000000000000000040000000000000000000000000000800000000000000000000000000000000000000000000000000000400000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000",
+				"status": "0x0",
+				"type": "0x2"
+			}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expected := eth.TransactionReceipt{}
+			require.NoError(t, json.Unmarshal([]byte(tt.receipt), &expected))
+
+			decoded := eth.TransactionReceipt{}
+			require.NoError(t, decoded.FromRaw(tt.raw))
+
+			require.Equal(t, expected.Type, decoded.Type)
+			require.Equal(t, expected.Status, decoded.Status)
+			require.Equal(t, expected.CumulativeGasUsed, decoded.CumulativeGasUsed)
+			require.Equal(t, expected.LogsBloom, decoded.LogsBloom)
+			require.NoError(t, decoded.VerifyLogsBloom())
+
+			require.Len(t, decoded.Logs, len(expected.Logs))
+			for i := range expected.Logs {
+				require.Equal(t, expected.Logs[i].Address, decoded.Logs[i].Address)
+				require.Equal(t, expected.Logs[i].Topics, decoded.Logs[i].Topics)
+				require.Equal(t, expected.Logs[i].Data, decoded.Logs[i].Data)
+			}
+
+			encoded, err := expected.RawRepresentation()
+			require.NoError(t, err)
+			require.Equal(t, tt.raw, encoded.String())
+		})
+	}
+}
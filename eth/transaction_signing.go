@@ -26,17 +26,34 @@ func (t *Transaction) Sign(privateKey string, chainId Quantity) (*Data, error) {
 		return nil, err
 	}
 
+	signer, err := NewKeySigner(pKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.SignWith(signer, chainId)
+}
+
+// SignWith uses the signer and chainId to update the R, S, and V values for a Transaction, and returns the raw signed
+// transaction or an error.  Signers implementing TransactionSigner sign the whole transaction, while other signers
+// sign its SigningHash.
+func (t *Transaction) SignWith(signer Signer, chainId Quantity) (*Data, error) {
+	if ts, ok := signer.(TransactionSigner); ok {
+		return t.signWithTransactionSigner(ts, chainId)
+	}
+
 	// Get the data to sign, which is a hash of the type-dependent fields
 	hash, err := t.SigningHash(chainId)
 	if err != nil {
 		return nil, err
 	}
 
-	// And sign the hash with the key
-	signature, err := ECSign(hash, pKey, chainId)
+	// And sign the hash with the signer
+	signature, err := signer.SignHash(*hash)
 	if err != nil {
 		return nil, err
 	}
+	signature.chainId = chainId
 
 	// Update signature values based on transaction type
 	switch t.TransactionType() {
@@ -60,15 +77,14 @@ func (t *Transaction) Sign(privateKey string, chainId Quantity) (*Data, error) {
 		t.Raw = raw
 	}
 
-	// recover the sender as well
-	signingHash, err := t.SigningHash(chainId)
+	// recover the sender as well, which must be the signer
+	sender, err := signature.Recover(hash)
 	if err != nil {
 		return nil, err
 	}
 
-	sender, err := signature.Recover(signingHash)
-	if err != nil {
-		return nil, err
+	if sender.String() != ToChecksumAddress(signer.Address().String()) {
+		return nil, errors.New("signature was not made by the signer's account")
 	}
 
 	t.From = *sender
@@ -76,6 +92,50 @@ func (t *Transaction) Sign(privateKey string, chainId Quantity) (*Data, error) {
 	return raw, err
 }
 
+// signWithTransactionSigner signs the transaction with a TransactionSigner and updates it from the raw signed
+// transaction, checking that the signer didn't alter it.
+func (t *Transaction) signWithTransactionSigner(signer TransactionSigner, chainId Quantity) (*Data, error) {
+	if t.TransactionType() != TransactionTypeLegacy {
+		t.ChainId = &chainId
+	}
+
+	expected, err := t.SigningHash(chainId)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := signer.SignTransaction(t, chainId)
+	if err != nil {
+		return nil, err
+	}
+
+	signed := Transaction{}
+	if err := signed.FromRaw(raw.String()); err != nil {
+		return nil, err
+	}
+
+	if h, err := signed.SigningHash(chainId); err != nil || h.String() != expected.String() {
+		return nil, errors.New("signer returned a different transaction")
+	}
+
+	if signature, err := signed.Signature(); err != nil || signature.chainId.Big().Cmp(chainId.Big()) != 0 {
+		return nil, errors.New("signer used a different chain id")
+	}
+
+	if signed.From.String() != ToChecksumAddress(signer.Address().String()) {
+		return nil, errors.New("signature was not made by the signer's account")
+	}
+
+	t.From = signed.From
+	t.R, t.S, t.V = signed.R, signed.S, signed.V
+	t.Hash = signed.Hash
+	if t.Raw != nil {
+		t.Raw = raw
+	}
+
+	return raw, nil
+}
+
 // SigningPreimage returns the opaque data preimage that is required for signing a given transaction type
 func (t *Transaction) SigningPreimage(chainId Quantity) (*Data, error) {
 	if err := t.RequiredFields(); err != nil {
@@ -0,0 +1,352 @@
+package eth
+
+import (
+	"math"
+	"math/big"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/INFURA/go-ethlibs/jsonrpc"
+)
+
+// The reasons a TransactionValidator rejects transactions, which use the same messages as geth's transaction pool.
+// Check the reason of a validation error with errors.Cause.
+var (
+	ErrTxTypeNotSupported      = errors.New("transaction type not supported")
+	ErrMissingFields           = errors.New("missing required fields")
+	ErrInvalidFields           = errors.New("invalid transaction fields")
+	ErrInvalidChainId          = errors.New("invalid chain id for signer")
+	ErrUnprotectedTx           = errors.New("only replay-protected (EIP-155) transactions allowed over RPC")
+	ErrInvalidSig              = errors.New("invalid transaction v, r, s values")
+	ErrOversizedData           = errors.New("oversized data")
+	ErrMaxInitCodeSizeExceeded = errors.New("max initcode size exceeded")
+	ErrValueVeryHigh           = errors.New("value higher than 2^256-1")
+	ErrFeeCapVeryHigh          = errors.New("max fee per gas higher than 2^256-1")
+	ErrTipVeryHigh             = errors.New("max priority fee per gas higher than 2^256-1")
+	ErrBlobFeeCapVeryHigh      = errors.New("max fee per blob gas higher than 2^256-1")
+	ErrTipAboveFeeCap          = errors.New("max priority fee per gas higher than max fee per gas")
+	ErrNonceMax                = errors.New("nonce has max value")
+	ErrGasLimit                = errors.New("exceeds block gas limit")
+	ErrIntrinsicGas            = errors.New("intrinsic gas too low")
+	ErrFloorDataGas            = errors.New("insufficient gas for floor data gas cost")
+	ErrBlobTxCreate            = errors.New("blob transaction of type create")
+	ErrMissingBlobHashes       = errors.New("blob transaction missing blob hashes")
+	ErrTooManyBlobs            = errors.New("blob transaction has too many blobs")
+	ErrInvalidBlobHash         = errors.New("blob transaction has invalid blob hash version")
+	ErrInvalidBlobSidecar      = errors.New("blob transaction has invalid blob sidecar")
+	ErrSetCodeTxCreate         = errors.New("EIP-7702 transaction cannot be used to create contract")
+	ErrEmptyAuthList           = errors.New("EIP-7702 transaction with empty auth list")
+)
+
+// DefaultMaxTxSize is the largest encoded transaction accepted by geth's transaction pool, not counting blobs.
+const DefaultMaxTxSize = 4 * 32 * 1024
+
+// ValidationError is returned by TransactionValidator.Validate, with one of the Err* reasons above as its cause and
+// details about the transaction that was rejected.
+type ValidationError struct {
+	Reason error
+	Detail string
+}
+
+func (e *ValidationError) Error() string {
+	if e.Detail == "" {
+		return e.Reason.Error()
+	}
+
+	return e.Reason.Error() + ": " + e.Detail
+}
+
+// Cause returns the reason of the error, for errors.Cause.
+func (e *ValidationError) Cause() error {
+	return e.Reason
+}
+
+// Unwrap returns the reason of the error, for the standard library's errors.Is.
+func (e *ValidationError) Unwrap() error {
+	return e.Reason
+}
+
+// RPCError returns the error as a JSONRPC transaction rejected error, to be returned to the sender of the
+// transaction.
+func (e *ValidationError) RPCError() *jsonrpc.Error {
+	return jsonrpc.TransactionRejected(e.Error())
+}
+
+func invalid(reason error, format string, args ...interface{}) *ValidationError {
+	if format == "" {
+		return &ValidationError{Reason: reason}
+	}
+
+	return &ValidationError{Reason: reason, Detail: errors.Errorf(format, args...).Error()}
+}
+
+// TransactionValidator checks transactions the way a node's transaction pool does before executing them, without any
+// state, so invalid transactions can be rejected before they are sent to a node.
+type TransactionValidator struct {
+	// ChainId is the chain transactions must be signed for.
+	ChainId Quantity
+
+	// Rules are the gas rules and supported transaction types of the chain.
+	Rules Rules
+
+	// BlobSchedule limits the number of blobs of blob transactions.
+	BlobSchedule BlobSchedule
+
+	// MaxSize is the largest encoded transaction allowed, not counting blobs.
+	MaxSize uint64
+
+	// MaxGas is the block gas limit transactions may not exceed, or 0 to not check it.
+	MaxGas uint64
+
+	// AllowUnprotected accepts legacy transactions signed without EIP-155 replay protection.
+	AllowUnprotected bool
+
+	// KZGVerifier verifies the proofs of the blobs of blob transactions decoded with their blobs, such as the verifier
+	// returned by kzg.MainnetVerifier.  Transactions with blobs are rejected if it is nil.
+	KZGVerifier KZGVerifier
+}
+
+// NewTransactionValidator returns a validator for transactions on the chain with chainId, under the rules of Prague.
+func NewTransactionValidator(chainId Quantity) *TransactionValidator {
+	return &TransactionValidator{
+		ChainId:      chainId,
+		Rules:        RulesPrague,
+		BlobSchedule: BlobSchedulePrague,
+		MaxSize:      DefaultMaxTxSize,
+	}
+}
+
+// Validate returns a *ValidationError if the transaction would be rejected by a transaction pool regardless of the
+// state of its sender, e.g. because it is signed for another chain, its signature is malleable or its gas is below its
+// intrinsic gas.
+func (v *TransactionValidator) Validate(t *Transaction) error {
+	txType := t.TransactionType()
+	switch txType {
+	case TransactionTypeLegacy, TransactionTypeAccessList, TransactionTypeDynamicFee, TransactionTypeBlob:
+	case TransactionTypeSetCode:
+		if !v.Rules.Prague {
+			return invalid(ErrTxTypeNotSupported, "type %d", txType)
+		}
+	default:
+		return invalid(ErrTxTypeNotSupported, "type %d", txType)
+	}
+
+	// contract creations and empty authorization lists get their own errors rather than missing field errors
+	if txType == TransactionTypeBlob && t.To == nil {
+		return invalid(ErrBlobTxCreate, "")
+	}
+
+	if txType == TransactionTypeSetCode {
+		if t.To == nil {
+			return invalid(ErrSetCodeTxCreate, "")
+		}
+		if t.AuthorizationList == nil || len(*t.AuthorizationList) == 0 {
+			return invalid(ErrEmptyAuthList, "")
+		}
+	}
+
+	if err := t.RequiredFields(); err != nil {
+		return invalid(ErrMissingFields, "%s", err.Error())
+	}
+
+	if fields := missingFeeFields(t); len(fields) > 0 {
+		return invalid(ErrMissingFields, "missing required field(s) %s for transaction type %d", strings.Join(fields, ","), txType)
+	}
+
+	if err := v.validateSize(t); err != nil {
+		return err
+	}
+
+	if err := v.validateValues(t); err != nil {
+		return err
+	}
+
+	if err := v.validateSignature(t); err != nil {
+		return err
+	}
+
+	if err := v.validateGas(t); err != nil {
+		return err
+	}
+
+	if txType == TransactionTypeBlob {
+		return v.validateBlobs(t)
+	}
+
+	return nil
+}
+
+// missingFeeFields returns the fee fields of the transaction's type which are missing, since RequiredFields doesn't
+// check all of the fields needed to encode the transaction.
+func missingFeeFields(t *Transaction) []string {
+	var fields []string
+	switch t.TransactionType() {
+	case TransactionTypeLegacy, TransactionTypeAccessList:
+		if t.GasPrice == nil {
+			fields = append(fields, "gasPrice")
+		}
+	default:
+		if t.MaxFeePerGas == nil {
+			fields = append(fields, "maxFeePerGas")
+		}
+		if t.MaxPriorityFeePerGas == nil {
+			fields = append(fields, "maxPriorityFeePerGas")
+		}
+	}
+
+	return fields
+}
+
+func (v *TransactionValidator) validateSize(t *Transaction) error {
+	// blobs are limited by the blob schedule rather than by the size of the transaction
+	withoutBlobs := *t
+	withoutBlobs.BlobBundle = nil
+	if withoutBlobs.YParity == nil {
+		// blob and set code transactions are only encoded with their yParity, which is the same as V
+		withoutBlobs.YParity = &withoutBlobs.V
+	}
+
+	// the transaction must be encoded before looking at its input, which may not be valid hex
+	raw, err := withoutBlobs.RawRepresentation()
+	if err != nil {
+		return invalid(ErrInvalidFields, "%s", err.Error())
+	}
+
+	if size := uint64(len(raw.Bytes())); v.MaxSize > 0 && size > v.MaxSize {
+		return invalid(ErrOversizedData, "size %d, limit %d", size, v.MaxSize)
+	}
+
+	if t.To == nil && v.Rules.Shanghai && len(t.calldata()) > MaxInitCodeSize {
+		return invalid(ErrMaxInitCodeSizeExceeded, "code size %d, limit %d", len(t.calldata()), MaxInitCodeSize)
+	}
+
+	return nil
+}
+
+func (v *TransactionValidator) validateValues(t *Transaction) error {
+	if t.Value.Big().BitLen() > 256 {
+		return invalid(ErrValueVeryHigh, "")
+	}
+
+	if !t.Nonce.Big().IsUint64() || t.Nonce.Big().Uint64() == math.MaxUint64 {
+		return invalid(ErrNonceMax, "nonce %s", t.Nonce.String())
+	}
+
+	feeCap, tip := t.GasPrice, t.GasPrice
+	if t.TransactionType() != TransactionTypeLegacy && t.TransactionType() != TransactionTypeAccessList {
+		feeCap, tip = t.MaxFeePerGas, t.MaxPriorityFeePerGas
+	}
+
+	if feeCap.Big().BitLen() > 256 {
+		return invalid(ErrFeeCapVeryHigh, "")
+	}
+
+	if tip.Big().BitLen() > 256 {
+		return invalid(ErrTipVeryHigh, "")
+	}
+
+	if tip.Big().Cmp(feeCap.Big()) > 0 {
+		return invalid(ErrTipAboveFeeCap, "maxPriorityFeePerGas %s, maxFeePerGas %s", tip.String(), feeCap.String())
+	}
+
+	if t.MaxFeePerBlobGas != nil && t.MaxFeePerBlobGas.Big().BitLen() > 256 {
+		return invalid(ErrBlobFeeCapVeryHigh, "")
+	}
+
+	return nil
+}
+
+func (v *TransactionValidator) validateSignature(t *Transaction) error {
+	var chainId *big.Int
+	if t.TransactionType() == TransactionTypeLegacy {
+		switch vi := t.V.Big(); {
+		case vi.Cmp(big.NewInt(27)) == 0 || vi.Cmp(big.NewInt(28)) == 0:
+			if !v.AllowUnprotected {
+				return invalid(ErrUnprotectedTx, "")
+			}
+		case vi.Cmp(big.NewInt(35)) >= 0:
+			// v = chainId * 2 + 35 + yParity
+			chainId = new(big.Int).Sub(vi, big.NewInt(35))
+			chainId.Rsh(chainId, 1)
+		default:
+			return invalid(ErrInvalidSig, "v %s", t.V.String())
+		}
+	} else {
+		yParity := t.V
+		if t.YParity != nil {
+			yParity = *t.YParity
+		}
+
+		chainId = t.ChainId.Big()
+		if yParity.Big().Cmp(big.NewInt(1)) > 0 {
+			return invalid(ErrInvalidSig, "yParity %s", yParity.String())
+		}
+	}
+
+	if chainId != nil && chainId.Cmp(v.ChainId.Big()) != 0 {
+		return invalid(ErrInvalidChainId, "have %s, want %s", chainId.String(), v.ChainId.Big().String())
+	}
+
+	r, s := t.R.Big(), t.S.Big()
+	if r.Sign() <= 0 || r.Cmp(secp256k1N) >= 0 || s.Sign() <= 0 || s.Cmp(secp256k1HalfN) > 0 {
+		return invalid(ErrInvalidSig, "")
+	}
+
+	return nil
+}
+
+func (v *TransactionValidator) validateGas(t *Transaction) error {
+	if !t.Gas.Big().IsUint64() {
+		return invalid(ErrGasLimit, "gas %s", t.Gas.String())
+	}
+
+	gas := t.Gas.Big().Uint64()
+	if v.MaxGas > 0 && gas > v.MaxGas {
+		return invalid(ErrGasLimit, "gas %d, limit %d", gas, v.MaxGas)
+	}
+
+	// IntrinsicGas includes the floor cost under Prague, which has its own error
+	rules := v.Rules
+	rules.Prague = false
+	intrinsic, err := t.IntrinsicGas(rules)
+	if err != nil {
+		return invalid(ErrMaxInitCodeSizeExceeded, "%s", err.Error())
+	}
+
+	if gas < intrinsic {
+		return invalid(ErrIntrinsicGas, "gas %d, minimum needed %d", gas, intrinsic)
+	}
+
+	if v.Rules.Prague {
+		if floor := t.FloorDataGas(); gas < floor {
+			return invalid(ErrFloorDataGas, "gas %d, minimum needed %d", gas, floor)
+		}
+	}
+
+	return nil
+}
+
+func (v *TransactionValidator) validateBlobs(t *Transaction) error {
+	if len(t.BlobVersionedHashes) == 0 {
+		return invalid(ErrMissingBlobHashes, "")
+	}
+
+	if blobs := uint64(len(t.BlobVersionedHashes)); blobs > v.BlobSchedule.Max {
+		return invalid(ErrTooManyBlobs, "%d blobs, limit %d", blobs, v.BlobSchedule.Max)
+	}
+
+	for i, h := range t.BlobVersionedHashes {
+		if b := h.Bytes(); len(b) != 32 || b[0] != VersionedHashVersionKZG {
+			return invalid(ErrInvalidBlobHash, "hash %d", i)
+		}
+	}
+
+	if t.BlobBundle != nil {
+		if err := t.VerifyBlobBundle(v.KZGVerifier); err != nil {
+			return invalid(ErrInvalidBlobSidecar, "%s", err.Error())
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,289 @@
+package eth_test
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/INFURA/go-ethlibs/eth"
+	"github.com/INFURA/go-ethlibs/eth/kzg"
+	"github.com/INFURA/go-ethlibs/jsonrpc"
+)
+
+const validationKey = "0xfad9c8855b740a0b7ed4c221dbad0f33a83a49cad6b3fe8d5817ac83d38b6a19"
+
+func signedDynamicFeeTx(t *testing.T, chainId eth.Quantity, modify func(tx *eth.Transaction)) *eth.Transaction {
+	tx := eth.Transaction{
+		Type:                 eth.MustQuantity("0x2"),
+		ChainId:              &chainId,
+		MaxFeePerGas:         eth.OptionalQuantityFromInt(2000000000),
+		MaxPriorityFeePerGas: eth.OptionalQuantityFromInt(1000000000),
+		Gas:                  eth.QuantityFromUInt64(21000),
+		Input:                eth.Input("0x"),
+		Nonce:                eth.QuantityFromInt64(0),
+		To:                   eth.MustAddress("0xdf0a88b2b68c673713a8ec826003676f272e3573"),
+		Value:                eth.QuantityFromInt64(0x1),
+	}
+
+	if modify != nil {
+		modify(&tx)
+	}
+
+	_, err := tx.Sign(validationKey, chainId)
+	require.NoError(t, err)
+
+	// signing only updates V, which blob transactions are encoded with as their yParity
+	if tx.YParity != nil {
+		yParity := tx.V
+		tx.YParity = &yParity
+	}
+
+	return &tx
+}
+
+func TestTransactionValidator_Validate(t *testing.T) {
+	chainId := eth.QuantityFromInt64(1)
+	validator := eth.NewTransactionValidator(chainId)
+
+	tx := signedDynamicFeeTx(t, chainId, nil)
+	require.NoError(t, validator.Validate(tx))
+
+	tests := []struct {
+		name     string
+		tx       *eth.Transaction
+		expected error
+	}{
+		{
+			name:     "wrong chain",
+			tx:       signedDynamicFeeTx(t, eth.QuantityFromInt64(5), nil),
+			expected: eth.ErrInvalidChainId,
+		},
+		{
+			name: "tip above fee cap",
+			tx: signedDynamicFeeTx(t, chainId, func(tx *eth.Transaction) {
+				tx.MaxPriorityFeePerGas = eth.OptionalQuantityFromInt(3000000000)
+			}),
+			expected: eth.ErrTipAboveFeeCap,
+		},
+		{
+			name: "intrinsic gas",
+			tx: signedDynamicFeeTx(t, chainId, func(tx *eth.Transaction) {
+				tx.Gas = eth.QuantityFromUInt64(20999)
+			}),
+			expected: eth.ErrIntrinsicGas,
+		},
+		{
+			name: "floor data gas",
+			tx: signedDynamicFeeTx(t, chainId, func(tx *eth.Transaction) {
+				tx.Input = eth.Input("0x" + strings.Repeat("ff", 100))
+				tx.Gas = eth.QuantityFromUInt64(21000 + 100*16)
+			}),
+			expected: eth.ErrFloorDataGas,
+		},
+		{
+			name: "value above 256 bits",
+			tx: signedDynamicFeeTx(t, chainId, func(tx *eth.Transaction) {
+				tx.Value = eth.QuantityFromBigInt(new(big.Int).Lsh(big.NewInt(1), 256))
+			}),
+			expected: eth.ErrValueVeryHigh,
+		},
+		{
+			name: "oversized",
+			tx: signedDynamicFeeTx(t, chainId, func(tx *eth.Transaction) {
+				tx.Input = eth.Input("0x" + strings.Repeat("ff", eth.DefaultMaxTxSize))
+				tx.Gas = eth.QuantityFromUInt64(30000000)
+			}),
+			expected: eth.ErrOversizedData,
+		},
+		{
+			name: "set code without authorizations",
+			tx: func() *eth.Transaction {
+				tx := signedDynamicFeeTx(t, chainId, nil)
+				tx.Type = eth.MustQuantity("0x4")
+				tx.AuthorizationList = &eth.AuthorizationList{}
+				return tx
+			}(),
+			expected: eth.ErrEmptyAuthList,
+		},
+		{
+			name: "set code creating a contract",
+			tx: func() *eth.Transaction {
+				tx := signedDynamicFeeTx(t, chainId, nil)
+				tx.Type = eth.MustQuantity("0x4")
+				tx.AuthorizationList = &eth.AuthorizationList{{ChainID: &chainId, Address: *tx.To}}
+				tx.To = nil
+				return tx
+			}(),
+			expected: eth.ErrSetCodeTxCreate,
+		},
+		{
+			name: "access list without gas price",
+			tx: &eth.Transaction{
+				Type:    eth.MustQuantity("0x1"),
+				ChainId: &chainId,
+				Gas:     eth.QuantityFromUInt64(21000),
+				To:      eth.MustAddress("0xdf0a88b2b68c673713a8ec826003676f272e3573"),
+			},
+			expected: eth.ErrMissingFields,
+		},
+		{
+			name: "blob without max fee per gas",
+			tx: &eth.Transaction{
+				Type:                eth.MustQuantity("0x3"),
+				ChainId:             &chainId,
+				Gas:                 eth.QuantityFromUInt64(21000),
+				To:                  eth.MustAddress("0xdf0a88b2b68c673713a8ec826003676f272e3573"),
+				MaxFeePerBlobGas:    eth.OptionalQuantityFromInt(1),
+				BlobVersionedHashes: eth.Hashes{eth.Data32("0x01" + strings.Repeat("00", 31))},
+			},
+			expected: eth.ErrMissingFields,
+		},
+		{
+			name: "invalid input",
+			tx: func() *eth.Transaction {
+				tx := signedDynamicFeeTx(t, chainId, nil)
+				tx.Input = eth.Input("0xzz")
+				return tx
+			}(),
+			expected: eth.ErrInvalidFields,
+		},
+		{
+			name: "unsupported type",
+			tx: func() *eth.Transaction {
+				tx := signedDynamicFeeTx(t, chainId, nil)
+				tx.Type = eth.MustQuantity("0x5")
+				return tx
+			}(),
+			expected: eth.ErrTxTypeNotSupported,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.Validate(tt.tx)
+			require.Error(t, err)
+			require.Equal(t, tt.expected, errors.Cause(err), err.Error())
+		})
+	}
+}
+
+func TestTransactionValidator_Validate_Signature(t *testing.T) {
+	chainId := eth.QuantityFromInt64(1)
+	validator := eth.NewTransactionValidator(chainId)
+
+	// flipping s to the upper half of the curve order keeps the signature valid but makes it malleable
+	n, _ := new(big.Int).SetString("fffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364141", 16)
+	tx := signedDynamicFeeTx(t, chainId, nil)
+	tx.S = eth.QuantityFromBigInt(new(big.Int).Sub(n, tx.S.Big()))
+	tx.V = eth.QuantityFromInt64(1 - tx.V.Int64())
+
+	err := validator.Validate(tx)
+	require.Equal(t, eth.ErrInvalidSig, errors.Cause(err))
+
+	tx = signedDynamicFeeTx(t, chainId, nil)
+	tx.V = eth.QuantityFromInt64(27)
+	err = validator.Validate(tx)
+	require.Equal(t, eth.ErrInvalidSig, errors.Cause(err))
+
+	// legacy transactions must be signed with EIP-155 replay protection for the chain
+	legacy := eth.Transaction{
+		GasPrice: eth.OptionalQuantityFromInt(1000000000),
+		Gas:      eth.QuantityFromUInt64(21000),
+		Input:    eth.Input("0x"),
+		To:       eth.MustAddress("0xdf0a88b2b68c673713a8ec826003676f272e3573"),
+	}
+
+	_, err = legacy.Sign(validationKey, chainId)
+	require.NoError(t, err)
+	require.NoError(t, validator.Validate(&legacy))
+
+	_, err = legacy.Sign(validationKey, eth.QuantityFromInt64(5))
+	require.NoError(t, err)
+	require.Equal(t, eth.ErrInvalidChainId, errors.Cause(validator.Validate(&legacy)))
+
+	_, err = legacy.Sign(validationKey, eth.QuantityFromInt64(0))
+	require.NoError(t, err)
+	require.Equal(t, eth.ErrUnprotectedTx, errors.Cause(validator.Validate(&legacy)))
+
+	validator.AllowUnprotected = true
+	require.NoError(t, validator.Validate(&legacy))
+}
+
+func TestTransactionValidator_Validate_Blobs(t *testing.T) {
+	chainId := eth.QuantityFromInt64(1)
+	validator := eth.NewTransactionValidator(chainId)
+
+	hash := eth.Data32("0x01" + strings.Repeat("00", 31))
+	blobTx := func(hashes ...eth.Data32) *eth.Transaction {
+		return signedDynamicFeeTx(t, chainId, func(tx *eth.Transaction) {
+			tx.Type = eth.MustQuantity("0x3")
+			tx.MaxFeePerBlobGas = eth.OptionalQuantityFromInt(1)
+			tx.YParity = eth.OptionalQuantityFromInt(0)
+			tx.BlobVersionedHashes = append(eth.Hashes{}, hashes...)
+		})
+	}
+
+	require.NoError(t, validator.Validate(blobTx(hash)))
+
+	err := validator.Validate(blobTx())
+	require.Equal(t, eth.ErrMissingBlobHashes, errors.Cause(err))
+
+	err = validator.Validate(blobTx(eth.Data32("0x02" + strings.Repeat("00", 31))))
+	require.Equal(t, eth.ErrInvalidBlobHash, errors.Cause(err))
+
+	err = validator.Validate(blobTx(hash, hash, hash, hash, hash, hash, hash, hash, hash, hash))
+	require.Equal(t, eth.ErrTooManyBlobs, errors.Cause(err))
+
+	validator.BlobSchedule = eth.BlobScheduleCancun
+	err = validator.Validate(blobTx(hash, hash, hash, hash, hash, hash, hash))
+	require.Equal(t, eth.ErrTooManyBlobs, errors.Cause(err))
+}
+
+func TestTransactionValidator_Validate_BlobSidecar(t *testing.T) {
+	chainId := eth.QuantityFromInt64(1)
+	validator := eth.NewTransactionValidator(chainId)
+
+	bundle := zeroBlobTransaction(t).BlobBundle
+	tx := signedDynamicFeeTx(t, chainId, func(tx *eth.Transaction) {
+		tx.Type = eth.MustQuantity("0x3")
+		tx.MaxFeePerBlobGas = eth.OptionalQuantityFromInt(1)
+		tx.YParity = eth.OptionalQuantityFromInt(0)
+		tx.BlobVersionedHashes, _ = bundle.VersionedHashes()
+	})
+	tx.BlobBundle = bundle
+
+	// the sidecar can't be verified without a KZG verifier
+	require.Equal(t, eth.ErrInvalidBlobSidecar, errors.Cause(validator.Validate(tx)))
+
+	mainnet, err := kzg.MainnetVerifier()
+	require.NoError(t, err)
+	validator.KZGVerifier = mainnet
+	require.NoError(t, validator.Validate(tx))
+
+	// the proofs of the sidecar are verified with the validator's verifier
+	tx.BlobBundle.Proofs[0] = eth.Data("0x97f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb")
+	require.Equal(t, eth.ErrInvalidBlobSidecar, errors.Cause(validator.Validate(tx)))
+
+	validator.KZGVerifier = kzgVerifierFunc(func(blob, commitment, proof []byte) error {
+		return nil
+	})
+	require.NoError(t, validator.Validate(tx))
+}
+
+func TestValidationError_RPCError(t *testing.T) {
+	chainId := eth.QuantityFromInt64(1)
+	tx := signedDynamicFeeTx(t, chainId, func(tx *eth.Transaction) {
+		tx.Gas = eth.QuantityFromUInt64(20000)
+	})
+
+	err := eth.NewTransactionValidator(chainId).Validate(tx)
+	require.Error(t, err)
+
+	verr, ok := err.(*eth.ValidationError)
+	require.True(t, ok)
+	require.Equal(t, "intrinsic gas too low: gas 20000, minimum needed 21000", verr.Error())
+	require.Equal(t, jsonrpc.TransactionRejected(verr.Error()), verr.RPCError())
+}
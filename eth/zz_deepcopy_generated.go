@@ -138,6 +138,11 @@ func (in *Block) DeepCopyInto(out *Block) {
 		in, out := &in.BlobGasUsed, &out.BlobGasUsed
 		*out = (*in).DeepCopy()
 	}
+	if in.RequestsHash != nil {
+		in, out := &in.RequestsHash, &out.RequestsHash
+		*out = new(Data32)
+		**out = **in
+	}
 	if in.Nonce != nil {
 		in, out := &in.Nonce, &out.Nonce
 		*out = new(Data8)
@@ -167,6 +172,11 @@ func (in *Block) DeepCopyInto(out *Block) {
 			copy(*out, *in)
 		}
 	}
+	if in.uncleHeaders != nil {
+		in, out := &in.uncleHeaders, &out.uncleHeaders
+		*out = make([]Data, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
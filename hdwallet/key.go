@@ -0,0 +1,357 @@
+package hdwallet
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/ripemd160"
+	"golang.org/x/crypto/sha3"
+
+	"github.com/INFURA/go-ethlibs/eth"
+)
+
+// HardenedOffset is added to child indexes to derive hardened children, written with a ' in derivation paths.
+const HardenedOffset uint32 = 0x80000000
+
+var (
+	// version bytes of mainnet extended keys, which serialize as xprv... and xpub...
+	versionPrivate = []byte{0x04, 0x88, 0xad, 0xe4}
+	versionPublic  = []byte{0x04, 0x88, 0xb2, 0x1e}
+
+	masterKeyHMACKey = []byte("Bitcoin seed")
+)
+
+// ExtendedKey is a BIP-32 extended secp256k1 key, i.e. a private or public key with the chain code needed to derive
+// its children.
+type ExtendedKey struct {
+	privateKey        []byte
+	publicKey         []byte
+	chainCode         []byte
+	depth             uint8
+	parentFingerprint []byte
+	childIndex        uint32
+}
+
+// NewMasterKey returns the master extended private key of a seed, which is usually the seed of a mnemonic returned by
+// NewSeed.
+func NewMasterKey(seed []byte) (*ExtendedKey, error) {
+	if len(seed) < 16 || len(seed) > 64 {
+		return nil, errors.Errorf("seed must be between 16 and 64 bytes long, not %d", len(seed))
+	}
+
+	mac := hmac.New(sha512.New, masterKeyHMACKey)
+	mac.Write(seed)
+	sum := mac.Sum(nil)
+
+	key := secp256k1.ModNScalar{}
+	if overflow := key.SetByteSlice(sum[:32]); overflow || key.IsZero() {
+		return nil, errors.New("seed derives an invalid master key")
+	}
+
+	return newPrivateKey(sum[:32], sum[32:], 0, make([]byte, 4), 0), nil
+}
+
+// ParseExtendedKey parses a base58 serialized mainnet extended key, i.e. an xprv... or xpub... string.
+func ParseExtendedKey(s string) (*ExtendedKey, error) {
+	b, err := base58Decode(s)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(b) != 82 {
+		return nil, errors.Errorf("extended key must be 82 bytes long, not %d", len(b))
+	}
+
+	payload, checksum := b[:78], b[78:]
+	if !bytes.Equal(doubleSHA256(payload)[:4], checksum) {
+		return nil, errors.New("invalid extended key checksum")
+	}
+
+	version, keyData := payload[:4], payload[45:]
+	depth := payload[4]
+	parentFingerprint := append([]byte{}, payload[5:9]...)
+	childIndex := binary.BigEndian.Uint32(payload[9:13])
+	chainCode := append([]byte{}, payload[13:45]...)
+
+	switch {
+	case bytes.Equal(version, versionPrivate):
+		if keyData[0] != 0 {
+			return nil, errors.New("invalid extended private key")
+		}
+
+		key := secp256k1.ModNScalar{}
+		if overflow := key.SetByteSlice(keyData[1:]); overflow || key.IsZero() {
+			return nil, errors.New("invalid extended private key")
+		}
+
+		return newPrivateKey(keyData[1:], chainCode, depth, parentFingerprint, childIndex), nil
+	case bytes.Equal(version, versionPublic):
+		if _, err := secp256k1.ParsePubKey(keyData); err != nil {
+			return nil, errors.Wrap(err, "invalid extended public key")
+		}
+
+		return &ExtendedKey{
+			publicKey:         append([]byte{}, keyData...),
+			chainCode:         chainCode,
+			depth:             depth,
+			parentFingerprint: parentFingerprint,
+			childIndex:        childIndex,
+		}, nil
+	default:
+		return nil, errors.Errorf("unsupported extended key version %x", version)
+	}
+}
+
+func newPrivateKey(key, chainCode []byte, depth uint8, parentFingerprint []byte, childIndex uint32) *ExtendedKey {
+	priv := secp256k1.PrivKeyFromBytes(key)
+	return &ExtendedKey{
+		privateKey:        append([]byte{}, key...),
+		publicKey:         priv.PubKey().SerializeCompressed(),
+		chainCode:         append([]byte{}, chainCode...),
+		depth:             depth,
+		parentFingerprint: parentFingerprint,
+		childIndex:        childIndex,
+	}
+}
+
+// IsPrivate returns true if the key is an extended private key, which can derive hardened children and sign.
+func (k *ExtendedKey) IsPrivate() bool {
+	return k.privateKey != nil
+}
+
+// Depth returns the number of derivations from the master key to the key.
+func (k *ExtendedKey) Depth() uint8 {
+	return k.depth
+}
+
+// ChildIndex returns the index the key was derived with from its parent, including HardenedOffset for hardened keys.
+func (k *ExtendedKey) ChildIndex() uint32 {
+	return k.childIndex
+}
+
+// PrivateKey returns the 32 bytes of the private key, or an error for extended public keys.
+func (k *ExtendedKey) PrivateKey() ([]byte, error) {
+	if !k.IsPrivate() {
+		return nil, errors.New("extended public keys have no private key")
+	}
+
+	return append([]byte{}, k.privateKey...), nil
+}
+
+// PublicKey returns the 33 bytes of the compressed public key.
+func (k *ExtendedKey) PublicKey() []byte {
+	return append([]byte{}, k.publicKey...)
+}
+
+// Address returns the Ethereum address of the key.
+func (k *ExtendedKey) Address() eth.Address {
+	// the compressed key has already been validated, so parsing it can't fail
+	pub, _ := secp256k1.ParsePubKey(k.publicKey)
+
+	hash := sha3.NewLegacyKeccak256()
+	hash.Write(pub.SerializeUncompressed()[1:])
+	sum := hash.Sum(nil)
+
+	return *eth.MustAddress(fmt.Sprintf("0x%x", sum[12:]))
+}
+
+// Signer returns an eth.Signer using the private key, e.g. for Transaction.SignWith.
+func (k *ExtendedKey) Signer() (*eth.KeySigner, error) {
+	key, err := k.PrivateKey()
+	if err != nil {
+		return nil, err
+	}
+
+	return eth.NewKeySigner(key)
+}
+
+// Neuter returns the extended public key of the key, which can derive the public keys and addresses of non-hardened
+// children without holding any private key.
+func (k *ExtendedKey) Neuter() *ExtendedKey {
+	return &ExtendedKey{
+		publicKey:         k.publicKey,
+		chainCode:         k.chainCode,
+		depth:             k.depth,
+		parentFingerprint: k.parentFingerprint,
+		childIndex:        k.childIndex,
+	}
+}
+
+// Child derives the child of the key at index, which is a hardened child when index is at least HardenedOffset.
+// Extended public keys can only derive non-hardened children.
+func (k *ExtendedKey) Child(index uint32) (*ExtendedKey, error) {
+	if k.depth == 255 {
+		return nil, errors.New("cannot derive beyond depth 255")
+	}
+
+	data := make([]byte, 0, 37)
+	if index >= HardenedOffset {
+		if !k.IsPrivate() {
+			return nil, errors.New("cannot derive a hardened child of an extended public key")
+		}
+		data = append(data, 0)
+		data = append(data, k.privateKey...)
+	} else {
+		data = append(data, k.publicKey...)
+	}
+	data = appendUint32(data, index)
+
+	mac := hmac.New(sha512.New, k.chainCode)
+	mac.Write(data)
+	sum := mac.Sum(nil)
+
+	// in the astronomically unlikely case of an invalid child BIP-32 says to move on to the next index, but like most
+	// implementations we leave that to the caller
+	tweak := secp256k1.ModNScalar{}
+	if overflow := tweak.SetByteSlice(sum[:32]); overflow {
+		return nil, errors.Errorf("child %d is invalid", index)
+	}
+
+	fingerprint := hash160(k.publicKey)[:4]
+	if k.IsPrivate() {
+		key := secp256k1.ModNScalar{}
+		key.SetByteSlice(k.privateKey)
+		key.Add(&tweak)
+		if key.IsZero() {
+			return nil, errors.Errorf("child %d is invalid", index)
+		}
+
+		b := key.Bytes()
+		return newPrivateKey(b[:], sum[32:], k.depth+1, fingerprint, index), nil
+	}
+
+	parent, err := secp256k1.ParsePubKey(k.publicKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid public key")
+	}
+
+	var point, parentPoint secp256k1.JacobianPoint
+	secp256k1.ScalarBaseMultNonConst(&tweak, &point)
+	parent.AsJacobian(&parentPoint)
+	secp256k1.AddNonConst(&point, &parentPoint, &point)
+	if (point.X.IsZero() && point.Y.IsZero()) || point.Z.IsZero() {
+		return nil, errors.Errorf("child %d is invalid", index)
+	}
+	point.ToAffine()
+
+	return &ExtendedKey{
+		publicKey:         secp256k1.NewPublicKey(&point.X, &point.Y).SerializeCompressed(),
+		chainCode:         append([]byte{}, sum[32:]...),
+		depth:             k.depth + 1,
+		parentFingerprint: fingerprint,
+		childIndex:        index,
+	}, nil
+}
+
+// Derive derives the descendant of the key at a derivation path such as "m/44'/60'/0'/0/0", relative to the key.
+func (k *ExtendedKey) Derive(path string) (*ExtendedKey, error) {
+	indexes, err := ParsePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	key := k
+	for _, index := range indexes {
+		if key, err = key.Child(index); err != nil {
+			return nil, err
+		}
+	}
+
+	return key, nil
+}
+
+// String returns the base58 serialization of the key, i.e. xprv... for private keys and xpub... for public keys.
+func (k *ExtendedKey) String() string {
+	b := make([]byte, 0, 82)
+	if k.IsPrivate() {
+		b = append(b, versionPrivate...)
+	} else {
+		b = append(b, versionPublic...)
+	}
+	b = append(b, k.depth)
+	b = append(b, k.parentFingerprint...)
+	b = appendUint32(b, k.childIndex)
+	b = append(b, k.chainCode...)
+	if k.IsPrivate() {
+		b = append(b, 0)
+		b = append(b, k.privateKey...)
+	} else {
+		b = append(b, k.publicKey...)
+	}
+	b = append(b, doubleSHA256(b)[:4]...)
+
+	return base58Encode(b)
+}
+
+func appendUint32(b []byte, v uint32) []byte {
+	return append(b, byte(v>>24), byte(v>>16), byte(v>>8), byte(v))
+}
+
+func hash160(b []byte) []byte {
+	sum := sha256.Sum256(b)
+	h := ripemd160.New()
+	h.Write(sum[:])
+	return h.Sum(nil)
+}
+
+func doubleSHA256(b []byte) []byte {
+	first := sha256.Sum256(b)
+	second := sha256.Sum256(first[:])
+	return second[:]
+}
+
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+func base58Encode(b []byte) string {
+	x := new(big.Int).SetBytes(b)
+	base := big.NewInt(58)
+	mod := new(big.Int)
+
+	out := make([]byte, 0, len(b)*138/100+1)
+	for x.Sign() > 0 {
+		x.DivMod(x, base, mod)
+		out = append(out, base58Alphabet[mod.Int64()])
+	}
+
+	// leading zero bytes are encoded as leading 1s
+	for _, c := range b {
+		if c != 0 {
+			break
+		}
+		out = append(out, base58Alphabet[0])
+	}
+
+	for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+		out[i], out[j] = out[j], out[i]
+	}
+
+	return string(out)
+}
+
+func base58Decode(s string) ([]byte, error) {
+	x := new(big.Int)
+	base := big.NewInt(58)
+	for _, c := range []byte(s) {
+		digit := bytes.IndexByte([]byte(base58Alphabet), c)
+		if digit < 0 {
+			return nil, errors.Errorf("invalid base58 character %q", c)
+		}
+
+		x.Mul(x, base)
+		x.Add(x, big.NewInt(int64(digit)))
+	}
+
+	zeros := 0
+	for zeros < len(s) && s[zeros] == base58Alphabet[0] {
+		zeros++
+	}
+
+	return append(make([]byte, zeros), x.Bytes()...), nil
+}
@@ -0,0 +1,131 @@
+package hdwallet
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	_ "embed"
+	"io"
+	"math/big"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+//go:embed english.txt
+var englishWords string
+
+var (
+	// wordList is the BIP-39 English word list, and wordIndex maps each word to its index in it.
+	wordList  = strings.Fields(englishWords)
+	wordIndex = func() map[string]int {
+		index := make(map[string]int, len(wordList))
+		for i, word := range wordList {
+			index[word] = i
+		}
+		return index
+	}()
+)
+
+// ErrInvalidChecksum is returned when the checksum of a mnemonic doesn't match its entropy, usually because of a typo.
+var ErrInvalidChecksum = errors.New("invalid mnemonic checksum")
+
+// NewMnemonic returns a random English mnemonic with bits of entropy, which must be a multiple of 32 between 128 and
+// 256, i.e. between 12 and 24 words.
+func NewMnemonic(bits int) (string, error) {
+	if err := validateEntropyBits(bits); err != nil {
+		return "", err
+	}
+
+	entropy := make([]byte, bits/8)
+	if _, err := io.ReadFull(rand.Reader, entropy); err != nil {
+		return "", errors.Wrap(err, "could not generate entropy")
+	}
+
+	return NewMnemonicFromEntropy(entropy)
+}
+
+// NewMnemonicFromEntropy returns the English mnemonic encoding entropy, which must be 16, 20, 24, 28 or 32 bytes long.
+func NewMnemonicFromEntropy(entropy []byte) (string, error) {
+	bits := len(entropy) * 8
+	if err := validateEntropyBits(bits); err != nil {
+		return "", err
+	}
+
+	// the entropy is followed by the first bits/32 bits of its sha256 hash, and split into 11 bit word indexes
+	checksumBits := bits / 32
+	sum := sha256.Sum256(entropy)
+	b := new(big.Int).SetBytes(entropy)
+	b.Lsh(b, uint(checksumBits))
+	b.Or(b, big.NewInt(int64(sum[0]>>(8-checksumBits))))
+
+	words := make([]string, (bits+checksumBits)/11)
+	mask := big.NewInt(2047)
+	for i := len(words) - 1; i >= 0; i-- {
+		words[i] = wordList[new(big.Int).And(b, mask).Int64()]
+		b.Rsh(b, 11)
+	}
+
+	return strings.Join(words, " "), nil
+}
+
+// MnemonicToEntropy returns the entropy encoded by an English mnemonic, checking its checksum.
+func MnemonicToEntropy(mnemonic string) ([]byte, error) {
+	words := strings.Fields(mnemonic)
+	switch len(words) {
+	case 12, 15, 18, 21, 24:
+	default:
+		return nil, errors.Errorf("mnemonic must have 12, 15, 18, 21 or 24 words, not %d", len(words))
+	}
+
+	b := new(big.Int)
+	for _, word := range words {
+		index, ok := wordIndex[word]
+		if !ok {
+			return nil, errors.Errorf("invalid mnemonic word %q", word)
+		}
+
+		b.Lsh(b, 11)
+		b.Or(b, big.NewInt(int64(index)))
+	}
+
+	checksumBits := len(words) * 11 / 33
+	checksum := new(big.Int).And(b, big.NewInt(1<<checksumBits-1)).Int64()
+	b.Rsh(b, uint(checksumBits))
+
+	entropy := make([]byte, checksumBits*4)
+	b.FillBytes(entropy)
+
+	sum := sha256.Sum256(entropy)
+	if int64(sum[0]>>(8-checksumBits)) != checksum {
+		return nil, ErrInvalidChecksum
+	}
+
+	return entropy, nil
+}
+
+// ValidateMnemonic returns an error if mnemonic isn't a valid English mnemonic.
+func ValidateMnemonic(mnemonic string) error {
+	_, err := MnemonicToEntropy(mnemonic)
+	return err
+}
+
+// NewSeed returns the 64 byte seed of a mnemonic and an optional passphrase, checking the mnemonic first.  The mnemonic
+// and passphrase are used as is, so non-ASCII passphrases must already be NFKD normalized.
+func NewSeed(mnemonic string, passphrase string) ([]byte, error) {
+	if err := ValidateMnemonic(mnemonic); err != nil {
+		return nil, err
+	}
+
+	mnemonic = strings.Join(strings.Fields(mnemonic), " ")
+	return pbkdf2.Key([]byte(mnemonic), []byte("mnemonic"+passphrase), 2048, 64, sha512.New), nil
+}
+
+func validateEntropyBits(bits int) error {
+	if bits < 128 || bits > 256 || bits%32 != 0 {
+		return errors.Errorf("entropy must be a multiple of 32 bits between 128 and 256, not %d", bits)
+	}
+
+	return nil
+}
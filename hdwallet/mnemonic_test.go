@@ -0,0 +1,98 @@
+package hdwallet_test
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/INFURA/go-ethlibs/hdwallet"
+)
+
+// a selection of the BIP-39 test vectors, whose seeds use the passphrase "TREZOR"
+var mnemonicVectors = []struct {
+	entropy  string
+	mnemonic string
+	seed     string
+}{
+	{
+		entropy:  "00000000000000000000000000000000",
+		mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+		seed:     "c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04",
+	},
+	{
+		entropy:  "7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f",
+		mnemonic: "legal winner thank year wave sausage worth useful legal winner thank yellow",
+		seed:     "2e8905819b8723fe2c1d161860e5ee1830318dbf49a83bd451cfb8440c28bd6fa457fe1296106559a3c80937a1c1069be3a3a5bd381ee6260e8d9739fce1f607",
+	},
+	{
+		entropy:  "808080808080808080808080808080808080808080808080",
+		mnemonic: "letter advice cage absurd amount doctor acoustic avoid letter advice cage absurd amount doctor acoustic avoid letter always",
+		seed:     "107d7c02a5aa6f38c58083ff74f04c607c2d2c0ecc55501dadd72d025b751bc27fe913ffb796f841c49b1d33b610cf0e91d3aa239027f5e99fe4ce9e5088cd65",
+	},
+	{
+		entropy:  "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+		mnemonic: "zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo vote",
+		seed:     "dd48c104698c30cfe2b6142103248622fb7bb0ff692eebb00089b32d22484e1613912f0a5b694407be899ffd31ed3992c456cdf60f5d4564b8ba3f05a69890ad",
+	},
+	{
+		entropy:  "77c2b00716cec7213839159e404db50d",
+		mnemonic: "jelly better achieve collect unaware mountain thought cargo oxygen act hood bridge",
+		seed:     "b5b6d0127db1a9d2226af0c3346031d77af31e918dba64287a1b44b8ebf63cdd52676f672a290aae502472cf2d602c051f3e6f18055e84e4c43897fc4e51a6ff",
+	},
+}
+
+func TestNewMnemonicFromEntropy(t *testing.T) {
+	for _, v := range mnemonicVectors {
+		entropy, err := hex.DecodeString(v.entropy)
+		require.NoError(t, err)
+
+		mnemonic, err := hdwallet.NewMnemonicFromEntropy(entropy)
+		require.NoError(t, err)
+		require.Equal(t, v.mnemonic, mnemonic)
+
+		decoded, err := hdwallet.MnemonicToEntropy(mnemonic)
+		require.NoError(t, err)
+		require.Equal(t, entropy, decoded)
+
+		seed, err := hdwallet.NewSeed(mnemonic, "TREZOR")
+		require.NoError(t, err)
+		require.Equal(t, v.seed, hex.EncodeToString(seed))
+	}
+
+	_, err := hdwallet.NewMnemonicFromEntropy(make([]byte, 15))
+	require.Error(t, err)
+}
+
+func TestNewMnemonic(t *testing.T) {
+	for _, bits := range []int{128, 160, 192, 224, 256} {
+		mnemonic, err := hdwallet.NewMnemonic(bits)
+		require.NoError(t, err)
+		require.Len(t, strings.Fields(mnemonic), bits*3/32)
+		require.NoError(t, hdwallet.ValidateMnemonic(mnemonic))
+	}
+
+	_, err := hdwallet.NewMnemonic(100)
+	require.Error(t, err)
+}
+
+func TestValidateMnemonic(t *testing.T) {
+	invalid := []string{
+		"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon",
+		"legal winner thank year wave sausage worth useful legal winner thank yellow yellow",
+		"letter advice cage absurd amount doctor acoustic avoid letter advice caged above",
+		"zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo, wrong",
+	}
+
+	for _, mnemonic := range invalid {
+		require.Error(t, hdwallet.ValidateMnemonic(mnemonic), mnemonic)
+	}
+
+	err := hdwallet.ValidateMnemonic("zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo")
+	require.Equal(t, hdwallet.ErrInvalidChecksum, errors.Cause(err))
+
+	_, err = hdwallet.NewSeed("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon", "")
+	require.Equal(t, hdwallet.ErrInvalidChecksum, errors.Cause(err))
+}
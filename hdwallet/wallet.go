@@ -0,0 +1,133 @@
+// Package hdwallet derives Ethereum accounts from BIP-39 mnemonics with BIP-32 hierarchical deterministic keys, along
+// the BIP-44 path m/44'/60'/0'/0/i used by MetaMask, Ledger, hardhat and most other wallets.
+package hdwallet
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/INFURA/go-ethlibs/eth"
+)
+
+// DefaultBasePath is the BIP-44 path of Ethereum accounts, whose children are the accounts at index 0, 1, 2...
+const DefaultBasePath = "m/44'/60'/0'/0"
+
+// AccountPath returns the derivation path of the account at index under DefaultBasePath.
+func AccountPath(index uint32) string {
+	return fmt.Sprintf("%s/%d", DefaultBasePath, index)
+}
+
+// ParsePath parses a derivation path such as "m/44'/60'/0'/0/0" into child indexes, adding HardenedOffset to
+// hardened indexes, which are suffixed with ' or h.  The leading "m" is optional.
+func ParsePath(path string) ([]uint32, error) {
+	parts := strings.Split(strings.TrimSpace(path), "/")
+	if parts[0] == "m" {
+		parts = parts[1:]
+	}
+
+	indexes := make([]uint32, 0, len(parts))
+	for _, part := range parts {
+		offset := uint32(0)
+		if strings.HasSuffix(part, "'") || strings.HasSuffix(part, "h") {
+			offset = HardenedOffset
+			part = part[:len(part)-1]
+		}
+
+		index, err := strconv.ParseUint(part, 10, 32)
+		if err != nil || uint32(index) >= HardenedOffset {
+			return nil, errors.Errorf("invalid derivation path %q", path)
+		}
+
+		indexes = append(indexes, uint32(index)+offset)
+	}
+
+	return indexes, nil
+}
+
+// Wallet derives the accounts of a seed along a base path, DefaultBasePath unless another is given.  The key at the
+// base path is derived once, so deriving each account only takes a single non-hardened derivation.
+type Wallet struct {
+	base *ExtendedKey
+}
+
+// NewWallet returns the wallet of a mnemonic and optional passphrase, deriving accounts under DefaultBasePath.
+func NewWallet(mnemonic string, passphrase string) (*Wallet, error) {
+	seed, err := NewSeed(mnemonic, passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	master, err := NewMasterKey(seed)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewWalletFromKey(master, DefaultBasePath)
+}
+
+// NewWalletFromKey returns a wallet deriving accounts under basePath relative to key, which is usually a master key.
+// Wallets of extended public keys only derive addresses.
+func NewWalletFromKey(key *ExtendedKey, basePath string) (*Wallet, error) {
+	base, err := key.Derive(basePath)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not derive base path")
+	}
+
+	return &Wallet{base: base}, nil
+}
+
+// Key returns the extended key of the account at index, which must be below HardenedOffset since accounts are
+// non-hardened children of the base path.
+func (w *Wallet) Key(index uint32) (*ExtendedKey, error) {
+	if index >= HardenedOffset {
+		return nil, errors.Errorf("account index %d is out of range", index)
+	}
+
+	return w.base.Child(index)
+}
+
+// Address returns the address of the account at index.
+func (w *Wallet) Address(index uint32) (eth.Address, error) {
+	key, err := w.Key(index)
+	if err != nil {
+		return "", err
+	}
+
+	return key.Address(), nil
+}
+
+// Signer returns an eth.Signer for the account at index.
+func (w *Wallet) Signer(index uint32) (*eth.KeySigner, error) {
+	key, err := w.Key(index)
+	if err != nil {
+		return nil, err
+	}
+
+	return key.Signer()
+}
+
+// Signers returns signers for the count accounts starting at index start.
+func (w *Wallet) Signers(start uint32, count int) ([]*eth.KeySigner, error) {
+	if count < 0 {
+		return nil, errors.Errorf("invalid account count %d", count)
+	}
+
+	if uint64(start)+uint64(count) > uint64(HardenedOffset) {
+		return nil, errors.Errorf("accounts %d to %d are out of range", start, uint64(start)+uint64(count)-1)
+	}
+
+	signers := make([]*eth.KeySigner, 0, count)
+	for i := 0; i < count; i++ {
+		signer, err := w.Signer(start + uint32(i))
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not derive account %d", start+uint32(i))
+		}
+
+		signers = append(signers, signer)
+	}
+
+	return signers, nil
+}
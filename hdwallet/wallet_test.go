@@ -0,0 +1,191 @@
+package hdwallet_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/INFURA/go-ethlibs/eth"
+	"github.com/INFURA/go-ethlibs/hdwallet"
+)
+
+func TestExtendedKey_Vector1(t *testing.T) {
+	// BIP-32 test vector 1
+	seed, err := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	require.NoError(t, err)
+
+	master, err := hdwallet.NewMasterKey(seed)
+	require.NoError(t, err)
+
+	tests := []struct {
+		path string
+		xprv string
+		xpub string
+	}{
+		{
+			path: "m",
+			xprv: "xprv9s21ZrQH143K3QTDL4LXw2F7HEK3wJUD2nW2nRk4stbPy6cq3jPPqjiChkVvvNKmPGJxWUtg6LnF5kejMRNNU3TGtRBeJgk33yuGBxrMPHi",
+			xpub: "xpub661MyMwAqRbcFtXgS5sYJABqqG9YLmC4Q1Rdap9gSE8NqtwybGhePY2gZ29ESFjqJoCu1Rupje8YtGqsefD265TMg7usUDFdp6W1EGMcet8",
+		},
+		{
+			path: "m/0'",
+			xprv: "xprv9uHRZZhk6KAJC1avXpDAp4MDc3sQKNxDiPvvkX8Br5ngLNv1TxvUxt4cV1rGL5hj6KCesnDYUhd7oWgT11eZG7XnxHrnYeSvkzY7d2bhkJ7",
+			xpub: "xpub68Gmy5EdvgibQVfPdqkBBCHxA5htiqg55crXYuXoQRKfDBFA1WEjWgP6LHhwBZeNK1VTsfTFUHCdrfp1bgwQ9xv5ski8PX9rL2dZXvgGDnw",
+		},
+		{
+			path: "m/0'/1",
+			xprv: "xprv9wTYmMFdV23N2TdNG573QoEsfRrWKQgWeibmLntzniatZvR9BmLnvSxqu53Kw1UmYPxLgboyZQaXwTCg8MSY3H2EU4pWcQDnRnrVA1xe8fs",
+			xpub: "xpub6ASuArnXKPbfEwhqN6e3mwBcDTgzisQN1wXN9BJcM47sSikHjJf3UFHKkNAWbWMiGj7Wf5uMash7SyYq527Hqck2AxYysAA7xmALppuCkwQ",
+		},
+		{
+			path: "m/0'/1/2'",
+			xprv: "xprv9z4pot5VBttmtdRTWfWQmoH1taj2axGVzFqSb8C9xaxKymcFzXBDptWmT7FwuEzG3ryjH4ktypQSAewRiNMjANTtpgP4mLTj34bhnZX7UiM",
+			xpub: "xpub6D4BDPcP2GT577Vvch3R8wDkScZWzQzMMUm3PWbmWvVJrZwQY4VUNgqFJPMM3No2dFDFGTsxxpG5uJh7n7epu4trkrX7x7DogT5Uv6fcLW5",
+		},
+		{
+			path: "m/0'/1/2'/2",
+			xprv: "xprvA2JDeKCSNNZky6uBCviVfJSKyQ1mDYahRjijr5idH2WwLsEd4Hsb2Tyh8RfQMuPh7f7RtyzTtdrbdqqsunu5Mm3wDvUAKRHSC34sJ7in334",
+			xpub: "xpub6FHa3pjLCk84BayeJxFW2SP4XRrFd1JYnxeLeU8EqN3vDfZmbqBqaGJAyiLjTAwm6ZLRQUMv1ZACTj37sR62cfN7fe5JnJ7dh8zL4fiyLHV",
+		},
+		{
+			path: "m/0'/1/2'/2/1000000000",
+			xprv: "xprvA41z7zogVVwxVSgdKUHDy1SKmdb533PjDz7J6N6mV6uS3ze1ai8FHa8kmHScGpWmj4WggLyQjgPie1rFSruoUihUZREPSL39UNdE3BBDu76",
+			xpub: "xpub6H1LXWLaKsWFhvm6RVpEL9P4KfRZSW7abD2ttkWP3SSQvnyA8FSVqNTEcYFgJS2UaFcxupHiYkro49S8yGasTvXEYBVPamhGW6cFJodrTHy",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			key, err := master.Derive(tt.path)
+			require.NoError(t, err)
+			require.Equal(t, tt.xprv, key.String())
+			require.Equal(t, tt.xpub, key.Neuter().String())
+
+			parsed, err := hdwallet.ParseExtendedKey(tt.xprv)
+			require.NoError(t, err)
+			require.Equal(t, key, parsed)
+
+			parsed, err = hdwallet.ParseExtendedKey(tt.xpub)
+			require.NoError(t, err)
+			require.Equal(t, key.Neuter(), parsed)
+		})
+	}
+}
+
+func TestExtendedKey_PublicDerivation(t *testing.T) {
+	xprv, err := hdwallet.ParseExtendedKey("xprv9wTYmMFdV23N2TdNG573QoEsfRrWKQgWeibmLntzniatZvR9BmLnvSxqu53Kw1UmYPxLgboyZQaXwTCg8MSY3H2EU4pWcQDnRnrVA1xe8fs")
+	require.NoError(t, err)
+
+	xpub := xprv.Neuter()
+	require.False(t, xpub.IsPrivate())
+
+	for _, index := range []uint32{0, 1, 2, 1000000000} {
+		priv, err := xprv.Child(index)
+		require.NoError(t, err)
+
+		pub, err := xpub.Child(index)
+		require.NoError(t, err)
+		require.Equal(t, priv.Neuter(), pub)
+		require.Equal(t, priv.Address(), pub.Address())
+	}
+
+	_, err = xpub.Child(hdwallet.HardenedOffset)
+	require.Error(t, err)
+
+	_, err = xpub.Signer()
+	require.Error(t, err)
+}
+
+func TestParsePath(t *testing.T) {
+	indexes, err := hdwallet.ParsePath("m/44'/60'/0'/0/7")
+	require.NoError(t, err)
+	require.Equal(t, []uint32{44 + hdwallet.HardenedOffset, 60 + hdwallet.HardenedOffset, hdwallet.HardenedOffset, 0, 7}, indexes)
+
+	indexes, err = hdwallet.ParsePath("0h/1")
+	require.NoError(t, err)
+	require.Equal(t, []uint32{hdwallet.HardenedOffset, 1}, indexes)
+
+	require.Equal(t, "m/44'/60'/0'/0/3", hdwallet.AccountPath(3))
+
+	for _, path := range []string{"", "m/", "m/x", "m/-1", "m/2147483648", "m/0''"} {
+		_, err := hdwallet.ParsePath(path)
+		require.Error(t, err, path)
+	}
+}
+
+func TestWallet(t *testing.T) {
+	// the default hardhat and anvil accounts
+	wallet, err := hdwallet.NewWallet("test test test test test test test test test test test junk", "")
+	require.NoError(t, err)
+
+	expected := []string{
+		"0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266",
+		"0x70997970C51812dc3A010C7d01b50e0d17dc79C8",
+		"0x3C44CdDdB6a900fa2b585dd299e03d12FA4293BC",
+	}
+
+	signers, err := wallet.Signers(0, len(expected))
+	require.NoError(t, err)
+
+	for i, addr := range expected {
+		actual, err := wallet.Address(uint32(i))
+		require.NoError(t, err)
+		require.Equal(t, *eth.MustAddress(addr), actual)
+		require.Equal(t, actual, signers[i].Address())
+	}
+
+	master, err := hdwallet.NewMasterKey(mustSeed(t, "test test test test test test test test test test test junk"))
+	require.NoError(t, err)
+
+	key, err := master.Derive(hdwallet.AccountPath(0))
+	require.NoError(t, err)
+	require.Equal(t, "0xac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80", "0x"+hex.EncodeToString(mustPrivateKey(t, key)))
+
+	// a wallet of the extended public key at the base path derives the same addresses
+	base, err := master.Derive(hdwallet.DefaultBasePath)
+	require.NoError(t, err)
+
+	watcher, err := hdwallet.NewWalletFromKey(base.Neuter(), "m")
+	require.NoError(t, err)
+
+	actual, err := watcher.Address(1)
+	require.NoError(t, err)
+	require.Equal(t, *eth.MustAddress(expected[1]), actual)
+
+	// accounts are non-hardened children of the base path
+	_, err = wallet.Key(hdwallet.HardenedOffset)
+	require.Error(t, err)
+
+	signers, err = wallet.Signers(hdwallet.HardenedOffset-1, 1)
+	require.NoError(t, err)
+	require.Len(t, signers, 1)
+
+	_, err = wallet.Signers(hdwallet.HardenedOffset-1, 2)
+	require.Error(t, err)
+
+	_, err = wallet.Signers(^uint32(0), 1)
+	require.Error(t, err)
+
+	_, err = wallet.Signers(0, -1)
+	require.Error(t, err)
+
+	signers, err = wallet.Signers(0, 0)
+	require.NoError(t, err)
+	require.Empty(t, signers)
+
+	_, err = hdwallet.NewWallet("test test test test test test test test test test test test", "")
+	require.Error(t, err)
+}
+
+func mustSeed(t *testing.T, mnemonic string) []byte {
+	seed, err := hdwallet.NewSeed(mnemonic, "")
+	require.NoError(t, err)
+	return seed
+}
+
+func mustPrivateKey(t *testing.T, key *hdwallet.ExtendedKey) []byte {
+	b, err := key.PrivateKey()
+	require.NoError(t, err)
+	return b
+}
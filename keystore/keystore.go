@@ -0,0 +1,325 @@
+// Package keystore reads and writes private keys in the Web3 Secret Storage format (keystore v3) used by geth, Parity
+// and most wallets, see https://ethereum.org/en/developers/docs/data-structures-and-encoding/web3-secret-storage/
+package keystore
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/crypto/scrypt"
+	"golang.org/x/crypto/sha3"
+
+	"github.com/INFURA/go-ethlibs/eth"
+)
+
+const (
+	version = 3
+
+	// KDFScrypt and KDFPBKDF2 are the supported key derivation functions.
+	KDFScrypt = "scrypt"
+	KDFPBKDF2 = "pbkdf2"
+
+	cipherAES128CTR = "aes-128-ctr"
+	prfHMACSHA256   = "hmac-sha256"
+	derivedKeyLen   = 32
+)
+
+// ErrDecrypt is returned when the MAC of a keystore file doesn't match, usually because the password is wrong.
+var ErrDecrypt = errors.New("could not decrypt key with given password")
+
+// Options are the key derivation parameters used to encrypt keys.  Higher costs make brute forcing the password
+// slower, at the expense of slower encryption and decryption.
+type Options struct {
+	// KDF is either KDFScrypt or KDFPBKDF2
+	KDF string
+
+	// ScryptN, ScryptR and ScryptP are the CPU/memory cost, block size and parallelization parameters of scrypt
+	ScryptN int
+	ScryptR int
+	ScryptP int
+
+	// PBKDF2Iterations is the iteration count of PBKDF2
+	PBKDF2Iterations int
+}
+
+var (
+	// StandardOptions are the scrypt parameters used by geth, which take about a second to derive the key.
+	StandardOptions = Options{KDF: KDFScrypt, ScryptN: 1 << 18, ScryptR: 8, ScryptP: 1}
+
+	// LightOptions are the scrypt parameters geth uses with --lightkdf, for devices with little memory.
+	LightOptions = Options{KDF: KDFScrypt, ScryptN: 1 << 12, ScryptR: 8, ScryptP: 6}
+)
+
+// Key is a decrypted private key.
+type Key struct {
+	ID         string
+	Address    eth.Address
+	PrivateKey []byte
+}
+
+type keyJSON struct {
+	Address string     `json:"address,omitempty"`
+	Crypto  cryptoJSON `json:"crypto"`
+	ID      string     `json:"id"`
+	Version int        `json:"version"`
+}
+
+type cryptoJSON struct {
+	Cipher       string           `json:"cipher"`
+	CipherText   string           `json:"ciphertext"`
+	CipherParams cipherParamsJSON `json:"cipherparams"`
+	KDF          string           `json:"kdf"`
+	KDFParams    kdfParamsJSON    `json:"kdfparams"`
+	MAC          string           `json:"mac"`
+}
+
+type cipherParamsJSON struct {
+	IV string `json:"iv"`
+}
+
+type kdfParamsJSON struct {
+	DKLen int    `json:"dklen"`
+	Salt  string `json:"salt"`
+
+	// scrypt
+	N int `json:"n,omitempty"`
+	R int `json:"r,omitempty"`
+	P int `json:"p,omitempty"`
+
+	// pbkdf2
+	C   int    `json:"c,omitempty"`
+	PRF string `json:"prf,omitempty"`
+}
+
+// NewKey returns a Key for the bytes of a private key, with a random ID.
+func NewKey(privKeyBytes []byte) (*Key, error) {
+	signer, err := eth.NewKeySigner(privKeyBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := newUUID()
+	if err != nil {
+		return nil, err
+	}
+
+	key := make([]byte, len(privKeyBytes))
+	copy(key, privKeyBytes)
+	return &Key{ID: id, Address: signer.Address(), PrivateKey: key}, nil
+}
+
+// Signer returns an eth.Signer using the key, e.g. for Transaction.SignWith.
+func (k *Key) Signer() (*eth.KeySigner, error) {
+	return eth.NewKeySigner(k.PrivateKey)
+}
+
+// Decrypt decrypts a keystore file with its password.  ErrDecrypt is returned when the password is wrong.
+func Decrypt(data []byte, password string) (*Key, error) {
+	k := keyJSON{}
+	if err := json.Unmarshal(data, &k); err != nil {
+		return nil, errors.Wrap(err, "could not parse keystore")
+	}
+
+	if k.Version != version {
+		return nil, errors.Errorf("unsupported keystore version %d", k.Version)
+	}
+
+	c := &k.Crypto
+	if c.Cipher != cipherAES128CTR {
+		return nil, errors.Errorf("unsupported cipher %s", c.Cipher)
+	}
+
+	cipherText, err := decodeHex(c.CipherText, "ciphertext")
+	if err != nil {
+		return nil, err
+	}
+
+	iv, err := decodeHex(c.CipherParams.IV, "iv")
+	if err != nil {
+		return nil, err
+	}
+
+	mac, err := decodeHex(c.MAC, "mac")
+	if err != nil {
+		return nil, err
+	}
+
+	derived, err := deriveKey(c.KDF, &c.KDFParams, password)
+	if err != nil {
+		return nil, err
+	}
+
+	if subtle.ConstantTimeCompare(computeMAC(derived, cipherText), mac) != 1 {
+		return nil, ErrDecrypt
+	}
+
+	privKey, err := aesCTR(derived[:16], iv, cipherText)
+	if err != nil {
+		return nil, err
+	}
+
+	signer, err := eth.NewKeySigner(privKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "keystore holds an invalid private key")
+	}
+
+	if k.Address != "" {
+		address, err := eth.NewAddress(ensureHexPrefix(k.Address))
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid address")
+		}
+
+		if !strings.EqualFold(address.String(), signer.Address().String()) {
+			return nil, errors.Errorf("keystore address %s does not match key address %s", address, signer.Address())
+		}
+	}
+
+	return &Key{ID: k.ID, Address: signer.Address(), PrivateKey: privKey}, nil
+}
+
+// Encrypt encrypts the key with a password as a keystore file, deriving the encryption key as described by the
+// options.
+func Encrypt(key *Key, password string, opts Options) ([]byte, error) {
+	salt := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, errors.Wrap(err, "could not generate salt")
+	}
+
+	iv := make([]byte, aes.BlockSize)
+	if _, err := io.ReadFull(rand.Reader, iv); err != nil {
+		return nil, errors.Wrap(err, "could not generate iv")
+	}
+
+	params := kdfParamsJSON{DKLen: derivedKeyLen, Salt: hex.EncodeToString(salt)}
+	switch opts.KDF {
+	case KDFScrypt:
+		params.N, params.R, params.P = opts.ScryptN, opts.ScryptR, opts.ScryptP
+	case KDFPBKDF2:
+		params.C, params.PRF = opts.PBKDF2Iterations, prfHMACSHA256
+	}
+
+	derived, err := deriveKey(opts.KDF, &params, password)
+	if err != nil {
+		return nil, err
+	}
+
+	cipherText, err := aesCTR(derived[:16], iv, key.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	id := key.ID
+	if id == "" {
+		if id, err = newUUID(); err != nil {
+			return nil, err
+		}
+	}
+
+	return json.Marshal(&keyJSON{
+		Address: strings.ToLower(strings.TrimPrefix(key.Address.String(), "0x")),
+		Crypto: cryptoJSON{
+			Cipher:       cipherAES128CTR,
+			CipherText:   hex.EncodeToString(cipherText),
+			CipherParams: cipherParamsJSON{IV: hex.EncodeToString(iv)},
+			KDF:          opts.KDF,
+			KDFParams:    params,
+			MAC:          hex.EncodeToString(computeMAC(derived, cipherText)),
+		},
+		ID:      id,
+		Version: version,
+	})
+}
+
+// deriveKey derives the encryption key from the password.
+func deriveKey(kdf string, params *kdfParamsJSON, password string) ([]byte, error) {
+	salt, err := decodeHex(params.Salt, "salt")
+	if err != nil {
+		return nil, err
+	}
+
+	if params.DKLen < derivedKeyLen {
+		return nil, errors.Errorf("derived key length %d is too short", params.DKLen)
+	}
+
+	switch kdf {
+	case KDFScrypt:
+		derived, err := scrypt.Key([]byte(password), salt, params.N, params.R, params.P, params.DKLen)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid scrypt parameters")
+		}
+		return derived, nil
+	case KDFPBKDF2:
+		if params.PRF != prfHMACSHA256 {
+			return nil, errors.Errorf("unsupported pbkdf2 prf %s", params.PRF)
+		}
+		if params.C <= 0 {
+			return nil, errors.Errorf("invalid pbkdf2 iteration count %d", params.C)
+		}
+		return pbkdf2.Key([]byte(password), salt, params.C, params.DKLen, sha256.New), nil
+	default:
+		return nil, errors.Errorf("unsupported kdf %s", kdf)
+	}
+}
+
+// computeMAC returns keccak256(derivedKey[16:32] || ciphertext).
+func computeMAC(derived []byte, cipherText []byte) []byte {
+	hash := sha3.NewLegacyKeccak256()
+	hash.Write(derived[16:32])
+	hash.Write(cipherText)
+	return hash.Sum(nil)
+}
+
+// aesCTR encrypts or decrypts with AES-128 in CTR mode.
+func aesCTR(key, iv, in []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(iv) != aes.BlockSize {
+		return nil, errors.Errorf("iv must be %d bytes long, not %d", aes.BlockSize, len(iv))
+	}
+
+	out := make([]byte, len(in))
+	cipher.NewCTR(block, iv).XORKeyStream(out, in)
+	return out, nil
+}
+
+func decodeHex(s string, name string) ([]byte, error) {
+	b, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid %s", name)
+	}
+
+	return b, nil
+}
+
+func ensureHexPrefix(s string) string {
+	if strings.HasPrefix(s, "0x") {
+		return s
+	}
+
+	return "0x" + s
+}
+
+// newUUID returns a random version 4 UUID.
+func newUUID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := io.ReadFull(rand.Reader, b); err != nil {
+		return "", errors.Wrap(err, "could not generate id")
+	}
+
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:]), nil
+}
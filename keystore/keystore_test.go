@@ -0,0 +1,138 @@
+package keystore_test
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/INFURA/go-ethlibs/eth"
+	"github.com/INFURA/go-ethlibs/keystore"
+)
+
+// The test vectors of the Web3 Secret Storage definition, whose password is "testpassword"
+const (
+	pbkdf2Vector = `{"crypto":{"cipher":"aes-128-ctr","cipherparams":{"iv":"6087dab2f9fdbbfaddc31a909735c1e6"},"ciphertext":"5318b4d5bcd28de64ee5559e671353e16f075ecae9f99c7a79a38af5f869aa46","kdf":"pbkdf2","kdfparams":{"c":262144,"dklen":32,"prf":"hmac-sha256","salt":"ae3cd4e7013836a3df6bd7241b12db061dbe2c6785853cce422d148a624ce0bd"},"mac":"517ead924a9d0dc3124507e3393d175ce3ff7c1e96529c6c555ce9e51205e9b2"},"id":"3198bc9c-6672-5ab3-d995-4942343ae5b6","version":3}`
+	scryptVector = `{"crypto":{"cipher":"aes-128-ctr","cipherparams":{"iv":"83dbcc02d8ccb40e466191a123791e0e"},"ciphertext":"d172bf743a674da9cdad04534d56926ef8358534d458fffccd4e6ad2fbde479c","kdf":"scrypt","kdfparams":{"dklen":32,"n":262144,"r":1,"p":8,"salt":"ab0c7876052600dd703518d6fc3fe8984592145b591fc8fb5c6d43190334ba19"},"mac":"2103ac29920d71da29f15d75b4a16dbe95cfd7ff8faea1056c33131d846e3097"},"id":"3198bc9c-6672-5ab3-d995-4942343ae5b6","version":3}`
+	vectorKey    = "7a28b5ba57c53603b0b07b56bba752f7784bf506fa95edc395f5cf6c7514fe9d"
+)
+
+func TestDecrypt(t *testing.T) {
+	for name, vector := range map[string]string{"pbkdf2": pbkdf2Vector, "scrypt": scryptVector} {
+		t.Run(name, func(t *testing.T) {
+			key, err := keystore.Decrypt([]byte(vector), "testpassword")
+			require.NoError(t, err)
+			require.Equal(t, vectorKey, hex.EncodeToString(key.PrivateKey))
+			require.Equal(t, "3198bc9c-6672-5ab3-d995-4942343ae5b6", key.ID)
+
+			_, err = keystore.Decrypt([]byte(vector), "wrongpassword")
+			require.Equal(t, keystore.ErrDecrypt, errors.Cause(err))
+		})
+	}
+}
+
+func TestEncrypt(t *testing.T) {
+	privKey, err := hex.DecodeString(vectorKey)
+	require.NoError(t, err)
+
+	key, err := keystore.NewKey(privKey)
+	require.NoError(t, err)
+
+	for _, opts := range []keystore.Options{
+		{KDF: keystore.KDFScrypt, ScryptN: 1 << 10, ScryptR: 8, ScryptP: 1},
+		{KDF: keystore.KDFPBKDF2, PBKDF2Iterations: 1024},
+	} {
+		t.Run(opts.KDF, func(t *testing.T) {
+			encrypted, err := keystore.Encrypt(key, "secret", opts)
+			require.NoError(t, err)
+
+			parsed := map[string]interface{}{}
+			require.NoError(t, json.Unmarshal(encrypted, &parsed))
+			require.Equal(t, float64(3), parsed["version"])
+			require.Equal(t, key.ID, parsed["id"])
+
+			decrypted, err := keystore.Decrypt(encrypted, "secret")
+			require.NoError(t, err)
+			require.Equal(t, key, decrypted)
+
+			_, err = keystore.Decrypt(encrypted, "Secret")
+			require.Equal(t, keystore.ErrDecrypt, errors.Cause(err))
+		})
+	}
+
+	_, err = keystore.Encrypt(key, "secret", keystore.Options{KDF: "argon2"})
+	require.Error(t, err)
+}
+
+func TestKey_Signer(t *testing.T) {
+	privKey, err := hex.DecodeString("fad9c8855b740a0b7ed4c221dbad0f33a83a49cad6b3fe8d5817ac83d38b6a19")
+	require.NoError(t, err)
+
+	key, err := keystore.NewKey(privKey)
+	require.NoError(t, err)
+	require.Equal(t, "0x96216849c49358B10257cb55b28eA603c874b05E", key.Address.String())
+
+	encrypted, err := keystore.Encrypt(key, "secret", keystore.LightOptions)
+	require.NoError(t, err)
+
+	decrypted, err := keystore.Decrypt(encrypted, "secret")
+	require.NoError(t, err)
+
+	signer, err := decrypted.Signer()
+	require.NoError(t, err)
+
+	tx := eth.Transaction{
+		Nonce:    eth.QuantityFromUInt64(0),
+		GasPrice: eth.OptionalQuantityFromInt(21488430592),
+		Gas:      eth.QuantityFromUInt64(90000),
+		To:       eth.MustAddress("0xc149Be1bcDFa69a94384b46A1F91350E5f81c1AB"),
+		Value:    eth.QuantityFromUInt64(950000000000000000),
+		Input:    *eth.MustInput("0x"),
+	}
+	_, err = tx.SignWith(signer, eth.QuantityFromInt64(1))
+	require.NoError(t, err)
+	require.Equal(t, key.Address.String(), tx.From.String())
+}
+
+func TestDecrypt_Invalid(t *testing.T) {
+	privKey, err := hex.DecodeString(vectorKey)
+	require.NoError(t, err)
+
+	key, err := keystore.NewKey(privKey)
+	require.NoError(t, err)
+
+	encrypted, err := keystore.Encrypt(key, "secret", keystore.Options{KDF: keystore.KDFPBKDF2, PBKDF2Iterations: 16})
+	require.NoError(t, err)
+
+	tests := map[string]func(k map[string]interface{}){
+		"version": func(k map[string]interface{}) { k["version"] = 1 },
+		"cipher": func(k map[string]interface{}) {
+			k["crypto"].(map[string]interface{})["cipher"] = "aes-128-cbc"
+		},
+		"kdf": func(k map[string]interface{}) {
+			k["crypto"].(map[string]interface{})["kdf"] = "argon2"
+		},
+		"address": func(k map[string]interface{}) {
+			k["address"] = "0000000000000000000000000000000000000000"
+		},
+		"mac": func(k map[string]interface{}) {
+			k["crypto"].(map[string]interface{})["mac"] = "00"
+		},
+	}
+
+	for name, mutate := range tests {
+		t.Run(name, func(t *testing.T) {
+			k := map[string]interface{}{}
+			require.NoError(t, json.Unmarshal(encrypted, &k))
+			mutate(k)
+
+			modified, err := json.Marshal(k)
+			require.NoError(t, err)
+
+			_, err = keystore.Decrypt(modified, "secret")
+			require.Error(t, err)
+		})
+	}
+}
@@ -208,14 +208,9 @@ func (c *client) BlockByNumber(ctx context.Context, number uint64, full bool) (*
 }
 
 func (c *client) EstimateGas(ctx context.Context, msg eth.Transaction) (uint64, error) {
-	arg := map[string]interface{}{
-		"from":  msg.From,
-		"to":    msg.To,
-		"value": msg.Value.String(),
-	}
-	if len(msg.Input) > 0 {
-		arg["data"] = msg.Input
-	}
+	// unlike eth_call the sender has always been sent for eth_estimateGas, even when it's empty
+	arg := callArg(&msg)
+	arg["from"] = msg.From
 
 	request := jsonrpc.Request{
 		ID:     jsonrpc.ID{Num: 1},
@@ -229,7 +224,7 @@ func (c *client) EstimateGas(ctx context.Context, msg eth.Transaction) (uint64,
 	}
 
 	if response.Error != nil {
-		return 0, errors.New(string(*response.Error))
+		return 0, newRPCError(*response.Error)
 	}
 
 	q := eth.Quantity{}
@@ -240,6 +235,48 @@ func (c *client) EstimateGas(ctx context.Context, msg eth.Transaction) (uint64,
 	return q.UInt64(), err
 }
 
+func (c *client) Call(ctx context.Context, msg eth.Transaction, numberOrTag eth.BlockNumberOrTag) (*eth.Data, error) {
+	request := jsonrpc.Request{
+		ID:     jsonrpc.ID{Num: 1},
+		Method: "eth_call",
+		Params: jsonrpc.MustParams(callArg(&msg), &numberOrTag),
+	}
+
+	applyContext(ctx, &request)
+	response, err := c.Request(ctx, &request)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not make request")
+	}
+
+	if response.Error != nil {
+		return nil, newRPCError(*response.Error)
+	}
+
+	d := eth.Data("")
+	err = json.Unmarshal(response.Result, &d)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decode result")
+	}
+
+	return &d, nil
+}
+
+// callArg returns the call object of eth_call and eth_estimateGas requests for a transaction.
+func callArg(msg *eth.Transaction) map[string]interface{} {
+	arg := map[string]interface{}{
+		"to":    msg.To,
+		"value": msg.Value.String(),
+	}
+	if msg.From != "" {
+		arg["from"] = msg.From
+	}
+	if len(msg.Input) > 0 {
+		arg["data"] = msg.Input
+	}
+
+	return arg
+}
+
 func (c *client) SendRawTransaction(ctx context.Context, msg string) (string, error) {
 	request := jsonrpc.Request{
 		ID:     jsonrpc.ID{Num: 1},
@@ -423,6 +460,36 @@ func (c *client) Logs(ctx context.Context, filter eth.LogFilter) ([]eth.Log, err
 	return _logs, nil
 }
 
+func (c *client) GetProof(ctx context.Context, address eth.Address, storageKeys []eth.Hash, numberOrTag eth.BlockNumberOrTag) (*eth.AccountProof, error) {
+	if storageKeys == nil {
+		storageKeys = []eth.Hash{}
+	}
+
+	request := jsonrpc.Request{
+		ID:     jsonrpc.ID{Num: 1},
+		Method: "eth_getProof",
+		Params: jsonrpc.MustParams(address, storageKeys, &numberOrTag),
+	}
+
+	applyContext(ctx, &request)
+	response, err := c.Request(ctx, &request)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not make request")
+	}
+
+	if response.Error != nil {
+		return nil, errors.New(string(*response.Error))
+	}
+
+	proof := eth.AccountProof{}
+	err = json.Unmarshal(response.Result, &proof)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal result")
+	}
+
+	return &proof, nil
+}
+
 func (c *client) TransactionByHash(ctx context.Context, hash string) (*eth.Transaction, error) {
 	h, err := eth.NewHash(hash)
 	if err != nil {
@@ -0,0 +1,67 @@
+package node
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/INFURA/go-ethlibs/abi"
+	"github.com/INFURA/go-ethlibs/eth"
+)
+
+// RPCError is a JSON-RPC error returned by the node.  Unlike the message, the error data is kept as is, which for
+// reverted eth_call and eth_estimateGas requests is the revert data as a hexadecimal string.
+type RPCError struct {
+	Code    int             `json:"code"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data,omitempty"`
+}
+
+func (e *RPCError) Error() string {
+	if len(e.Data) > 0 {
+		return fmt.Sprintf("%s (code %d, data %s)", e.Message, e.Code, e.Data)
+	}
+
+	return fmt.Sprintf("%s (code %d)", e.Message, e.Code)
+}
+
+// RevertData returns the data of a reverted call, if the error data holds any.
+func (e *RPCError) RevertData() (*eth.Data, bool) {
+	if len(e.Data) == 0 {
+		return nil, false
+	}
+
+	var s string
+	if err := json.Unmarshal(e.Data, &s); err != nil {
+		return nil, false
+	}
+
+	d, err := eth.NewData(s)
+	if err != nil || len(s)%2 != 0 {
+		return nil, false
+	}
+
+	return d, true
+}
+
+// Revert decodes the revert data of a reverted call, including custom errors defined by the ABIs, see
+// abi.DecodeRevert.
+func (e *RPCError) Revert(abis ...*abi.ABI) (*abi.Revert, error) {
+	data, ok := e.RevertData()
+	if !ok {
+		return nil, errors.New("error has no revert data")
+	}
+
+	return abi.DecodeRevert(data.Bytes(), abis...)
+}
+
+// newRPCError parses the error of a JSON-RPC response, falling back to an error with the raw JSON as its message.
+func newRPCError(raw json.RawMessage) error {
+	e := RPCError{}
+	if err := json.Unmarshal(raw, &e); err != nil || e.Message == "" {
+		return errors.New(string(raw))
+	}
+
+	return &e
+}
@@ -0,0 +1,122 @@
+package node_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/INFURA/go-ethlibs/eth"
+	"github.com/INFURA/go-ethlibs/jsonrpc"
+	"github.com/INFURA/go-ethlibs/node"
+)
+
+type requesterFunc func(ctx context.Context, r *jsonrpc.Request) (*jsonrpc.RawResponse, error)
+
+func (f requesterFunc) Request(ctx context.Context, r *jsonrpc.Request) (*jsonrpc.RawResponse, error) {
+	return f(ctx, r)
+}
+
+func respondWith(t *testing.T, method string, result string, rpcErr string) node.Client {
+	client, err := node.NewCustomClient(requesterFunc(func(ctx context.Context, r *jsonrpc.Request) (*jsonrpc.RawResponse, error) {
+		require.Equal(t, method, r.Method)
+		response := jsonrpc.RawResponse{ID: r.ID}
+		if rpcErr != "" {
+			raw := json.RawMessage(rpcErr)
+			response.Error = &raw
+		} else {
+			response.Result = json.RawMessage(result)
+		}
+		return &response, nil
+	}), nil)
+	require.NoError(t, err)
+
+	return client
+}
+
+func TestClient_Call(t *testing.T) {
+	ctx := context.Background()
+	msg := eth.Transaction{
+		To:    eth.MustAddress("0x21ab6c9fac80c59d401b37cb43f81ea9dde7fe34"),
+		Input: *eth.MustInput("0x70a08231000000000000000000000000fe5854255eb1eb921525fa856a3947ed2412a1d7"),
+	}
+
+	{
+		client := respondWith(t, "eth_call", `"0x000000000000000000000000000000000000000000000000000000070560c8c0"`, "")
+		result, err := client.Call(ctx, msg, *eth.MustBlockNumberOrTag("latest"))
+		require.NoError(t, err)
+		require.Equal(t, "0x000000000000000000000000000000000000000000000000000000070560c8c0", result.String())
+	}
+
+	{
+		client := respondWith(t, "eth_call", "", `{"code":3,"message":"execution reverted: Not enough Ether provided.","data":"0x08c379a00000000000000000000000000000000000000000000000000000000000000020000000000000000000000000000000000000000000000000000000000000001a4e6f7420656e6f7567682045746865722070726f76696465642e000000000000"}`)
+		_, err := client.Call(ctx, msg, *eth.MustBlockNumberOrTag("latest"))
+		require.EqualError(t, err, `execution reverted: Not enough Ether provided. (code 3, data "0x08c379a00000000000000000000000000000000000000000000000000000000000000020000000000000000000000000000000000000000000000000000000000000001a4e6f7420656e6f7567682045746865722070726f76696465642e000000000000")`)
+
+		rpcErr, ok := err.(*node.RPCError)
+		require.True(t, ok)
+		require.Equal(t, 3, rpcErr.Code)
+
+		revert, err := rpcErr.Revert()
+		require.NoError(t, err)
+		require.Equal(t, "Not enough Ether provided.", revert.Reason)
+	}
+
+	{
+		client := respondWith(t, "eth_estimateGas", "", `{"code":3,"message":"execution reverted","data":"0x4e487b710000000000000000000000000000000000000000000000000000000000000012"}`)
+		_, err := client.EstimateGas(ctx, msg)
+		rpcErr, ok := err.(*node.RPCError)
+		require.True(t, ok)
+
+		revert, err := rpcErr.Revert()
+		require.NoError(t, err)
+		require.EqualError(t, revert, "execution reverted: panic: division or modulo by zero (0x12)")
+	}
+
+	{
+		client := respondWith(t, "eth_estimateGas", "", `{"code":-32000,"message":"insufficient funds for transfer"}`)
+		_, err := client.EstimateGas(ctx, msg)
+		rpcErr, ok := err.(*node.RPCError)
+		require.True(t, ok)
+
+		_, ok = rpcErr.RevertData()
+		require.False(t, ok)
+		_, err = rpcErr.Revert()
+		require.Error(t, err)
+	}
+}
+
+func TestClient_CallArg(t *testing.T) {
+	ctx := context.Background()
+	msg := eth.Transaction{
+		To:    eth.MustAddress("0x21ab6c9fac80c59d401b37cb43f81ea9dde7fe34"),
+		Input: *eth.MustInput("0x70a08231"),
+		Value: eth.QuantityFromInt64(1),
+	}
+
+	var params []string
+	client, err := node.NewCustomClient(requesterFunc(func(ctx context.Context, r *jsonrpc.Request) (*jsonrpc.RawResponse, error) {
+		b, err := json.Marshal(r.Params)
+		require.NoError(t, err)
+		params = append(params, string(b))
+		return &jsonrpc.RawResponse{ID: r.ID, Result: json.RawMessage(`"0x5208"`)}, nil
+	}), nil)
+	require.NoError(t, err)
+
+	// eth_estimateGas sends the sender even when it's empty, while eth_call leaves it out
+	_, err = client.EstimateGas(ctx, msg)
+	require.NoError(t, err)
+	_, err = client.Call(ctx, msg, *eth.MustBlockNumberOrTag("latest"))
+	require.NoError(t, err)
+
+	msg.From = *eth.MustAddress("0xfe5854255eb1eb921525fa856a3947ed2412a1d7")
+	_, err = client.Call(ctx, msg, *eth.MustBlockNumberOrTag("latest"))
+	require.NoError(t, err)
+
+	require.Equal(t, []string{
+		`[{"data":"0x70a08231","from":"","to":"0x21ab6c9fac80c59d401b37cb43f81ea9dde7fe34","value":"0x1"}]`,
+		`[{"data":"0x70a08231","to":"0x21ab6c9fac80c59d401b37cb43f81ea9dde7fe34","value":"0x1"},"latest"]`,
+		`[{"data":"0x70a08231","from":"0xfe5854255eb1eb921525fa856a3947ed2412a1d7","to":"0x21ab6c9fac80c59d401b37cb43f81ea9dde7fe34","value":"0x1"},"latest"]`,
+	}, params)
+}
@@ -37,9 +37,13 @@ type Client interface {
 	// ChainId returns the chain id
 	ChainId(ctx context.Context) (string, error)
 
-	// EstimateGas returns the estimate gas
+	// EstimateGas returns the estimate gas, when the estimation fails the error is an *RPCError
 	EstimateGas(ctx context.Context, msg eth.Transaction) (uint64, error)
 
+	// Call executes a message call without creating a transaction and returns its output.  When the call reverts
+	// the error is an *RPCError holding the revert data.
+	Call(ctx context.Context, msg eth.Transaction, numberOrTag eth.BlockNumberOrTag) (*eth.Data, error)
+
 	// MaxPriorityFeePerGas (EIP1559) returns the suggested tip for block
 	MaxPriorityFeePerGas(ctx context.Context) (uint64, error)
 
@@ -76,6 +80,9 @@ type Client interface {
 	// Logs returns an array of Logs matching the passed in filter
 	Logs(ctx context.Context, filter eth.LogFilter) ([]eth.Log, error)
 
+	// GetProof returns the EIP-1186 account and storage proofs for an address at a particular block
+	GetProof(ctx context.Context, address eth.Address, storageKeys []eth.Hash, numberOrTag eth.BlockNumberOrTag) (*eth.AccountProof, error)
+
 	// IsBidirectional returns true if the under laying transport supports bidirectional features such as subscriptions
 	IsBidirectional() bool
 }
@@ -173,6 +173,21 @@ func (mr *MockClientMockRecorder) BlockNumber(ctx interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockNumber", reflect.TypeOf((*MockClient)(nil).BlockNumber), ctx)
 }
 
+// Call mocks base method.
+func (m *MockClient) Call(ctx context.Context, msg eth.Transaction, numberOrTag eth.BlockNumberOrTag) (*eth.Data, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Call", ctx, msg, numberOrTag)
+	ret0, _ := ret[0].(*eth.Data)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Call indicates an expected call of Call.
+func (mr *MockClientMockRecorder) Call(ctx, msg, numberOrTag interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Call", reflect.TypeOf((*MockClient)(nil).Call), ctx, msg, numberOrTag)
+}
+
 // ChainId mocks base method.
 func (m *MockClient) ChainId(ctx context.Context) (string, error) {
 	m.ctrl.T.Helper()
@@ -218,6 +233,21 @@ func (mr *MockClientMockRecorder) GasPrice(ctx interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GasPrice", reflect.TypeOf((*MockClient)(nil).GasPrice), ctx)
 }
 
+// GetProof mocks base method.
+func (m *MockClient) GetProof(ctx context.Context, address eth.Address, storageKeys []eth.Hash, numberOrTag eth.BlockNumberOrTag) (*eth.AccountProof, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProof", ctx, address, storageKeys, numberOrTag)
+	ret0, _ := ret[0].(*eth.AccountProof)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProof indicates an expected call of GetProof.
+func (mr *MockClientMockRecorder) GetProof(ctx, address, storageKeys, numberOrTag interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProof", reflect.TypeOf((*MockClient)(nil).GetProof), ctx, address, storageKeys, numberOrTag)
+}
+
 // GetTransactionCount mocks base method.
 func (m *MockClient) GetTransactionCount(ctx context.Context, address eth.Address, numberOrTag eth.BlockNumberOrTag) (uint64, error) {
 	m.ctrl.T.Helper()
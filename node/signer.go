@@ -0,0 +1,157 @@
+package node
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/INFURA/go-ethlibs/eth"
+	"github.com/INFURA/go-ethlibs/jsonrpc"
+)
+
+// RemoteSigner is an eth.Signer for an account managed by a remote JSON-RPC signer, such as a node with unlocked
+// accounts, Clef or a signing service.  Transactions are signed with eth_signTransaction and personal messages with
+// eth_sign, so the signer always knows what it is signing.  Remote signers can't sign arbitrary hashes.
+type RemoteSigner struct {
+	requester Requester
+	address   eth.Address
+	ctx       context.Context
+}
+
+var (
+	_ eth.TransactionSigner = (*RemoteSigner)(nil)
+	_ eth.MessageSigner     = (*RemoteSigner)(nil)
+)
+
+// NewRemoteSigner returns a signer for the account at address, sending requests with the requester, which is usually
+// a Client.
+func NewRemoteSigner(requester Requester, address eth.Address) *RemoteSigner {
+	return &RemoteSigner{requester: requester, address: address, ctx: context.Background()}
+}
+
+// WithContext returns a copy of the signer which makes its requests with ctx, since the eth.Signer methods don't take
+// a context.
+func (s *RemoteSigner) WithContext(ctx context.Context) *RemoteSigner {
+	c := *s
+	c.ctx = ctx
+	return &c
+}
+
+// Address returns the address of the account.
+func (s *RemoteSigner) Address() eth.Address {
+	return s.address
+}
+
+// SignHash always fails, since eth_sign signs the EIP-191 personal message hash of its data instead of the data.
+func (s *RemoteSigner) SignHash(hash eth.Hash) (*eth.Signature, error) {
+	return nil, errors.New("remote signers can only sign transactions and personal messages")
+}
+
+// SignTransaction signs the transaction with eth_signTransaction, returning the raw signed transaction.
+func (s *RemoteSigner) SignTransaction(tx *eth.Transaction, chainId eth.Quantity) (*eth.Data, error) {
+	request := jsonrpc.Request{
+		ID:     jsonrpc.ID{Num: 1},
+		Method: "eth_signTransaction",
+		Params: jsonrpc.MustParams(transactionArg(s.address, tx, chainId)),
+	}
+
+	response, err := s.request(&request)
+	if err != nil {
+		return nil, err
+	}
+
+	// nodes return {"raw": ..., "tx": ...} while some signers only return the raw transaction
+	signed := struct {
+		Raw eth.Data `json:"raw"`
+	}{}
+	if err := json.Unmarshal(response.Result, &signed); err != nil {
+		if err := json.Unmarshal(response.Result, &signed.Raw); err != nil {
+			return nil, errors.Wrap(err, "could not decode result")
+		}
+	}
+
+	if len(signed.Raw) <= 2 {
+		return nil, errors.New("signer did not return a raw transaction")
+	}
+
+	return &signed.Raw, nil
+}
+
+// SignPersonalMessage signs the message with eth_sign, which signs its EIP-191 personal message hash.
+func (s *RemoteSigner) SignPersonalMessage(message []byte) (*eth.Signature, error) {
+	request := jsonrpc.Request{
+		ID:     jsonrpc.ID{Num: 1},
+		Method: "eth_sign",
+		Params: jsonrpc.MustParams(s.address, fmt.Sprintf("0x%x", message)),
+	}
+
+	response, err := s.request(&request)
+	if err != nil {
+		return nil, err
+	}
+
+	sig := eth.Data("")
+	if err := json.Unmarshal(response.Result, &sig); err != nil {
+		return nil, errors.Wrap(err, "could not decode result")
+	}
+
+	return eth.NewSignatureFromBytes(sig.Bytes())
+}
+
+func (s *RemoteSigner) request(request *jsonrpc.Request) (*jsonrpc.RawResponse, error) {
+	applyContext(s.ctx, request)
+	response, err := s.requester.Request(s.ctx, request)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not make request")
+	}
+
+	if response.Error != nil {
+		return nil, newRPCError(*response.Error)
+	}
+
+	return response, nil
+}
+
+// transactionArg returns the transaction object of eth_signTransaction requests.
+func transactionArg(from eth.Address, tx *eth.Transaction, chainId eth.Quantity) map[string]interface{} {
+	arg := map[string]interface{}{
+		"from":    from,
+		"gas":     tx.Gas,
+		"value":   tx.Value,
+		"nonce":   tx.Nonce,
+		"input":   tx.Input,
+		"chainId": chainId,
+	}
+
+	if tx.To != nil {
+		arg["to"] = tx.To
+	}
+	if tx.Type != nil {
+		arg["type"] = tx.Type
+	}
+	if tx.GasPrice != nil {
+		arg["gasPrice"] = tx.GasPrice
+	}
+	if tx.MaxFeePerGas != nil {
+		arg["maxFeePerGas"] = tx.MaxFeePerGas
+	}
+	if tx.MaxPriorityFeePerGas != nil {
+		arg["maxPriorityFeePerGas"] = tx.MaxPriorityFeePerGas
+	}
+	if tx.AccessList != nil {
+		arg["accessList"] = tx.AccessList
+	}
+	if tx.MaxFeePerBlobGas != nil {
+		arg["maxFeePerBlobGas"] = tx.MaxFeePerBlobGas
+	}
+	if len(tx.BlobVersionedHashes) > 0 {
+		arg["blobVersionedHashes"] = tx.BlobVersionedHashes
+	}
+	if tx.AuthorizationList != nil {
+		arg["authorizationList"] = tx.AuthorizationList
+	}
+
+	return arg
+}
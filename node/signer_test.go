@@ -0,0 +1,140 @@
+package node_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/INFURA/go-ethlibs/eth"
+	"github.com/INFURA/go-ethlibs/jsonrpc"
+	"github.com/INFURA/go-ethlibs/node"
+)
+
+// remoteSigner returns a Requester acting as a node with an unlocked account for the key, which signs transactions
+// with it regardless of their from address.
+func remoteSigner(t *testing.T, key *eth.KeySigner, tamper func(tx *eth.Transaction)) node.Requester {
+	return requesterFunc(func(ctx context.Context, r *jsonrpc.Request) (*jsonrpc.RawResponse, error) {
+		response := jsonrpc.RawResponse{ID: r.ID}
+		switch r.Method {
+		case "eth_signTransaction":
+			arg := struct {
+				To      *eth.Address  `json:"to"`
+				Type    *eth.Quantity `json:"type"`
+				Nonce   eth.Quantity  `json:"nonce"`
+				Gas     eth.Quantity  `json:"gas"`
+				Value   eth.Quantity  `json:"value"`
+				Input   eth.Input     `json:"input"`
+				ChainId eth.Quantity  `json:"chainId"`
+
+				MaxFeePerGas         *eth.Quantity `json:"maxFeePerGas"`
+				MaxPriorityFeePerGas *eth.Quantity `json:"maxPriorityFeePerGas"`
+			}{}
+			require.NoError(t, json.Unmarshal(r.Params[0], &arg))
+
+			tx := eth.Transaction{
+				Type:                 arg.Type,
+				To:                   arg.To,
+				Nonce:                arg.Nonce,
+				Gas:                  arg.Gas,
+				Value:                arg.Value,
+				Input:                arg.Input,
+				MaxFeePerGas:         arg.MaxFeePerGas,
+				MaxPriorityFeePerGas: arg.MaxPriorityFeePerGas,
+				ChainId:              &arg.ChainId,
+			}
+			if tamper != nil {
+				tamper(&tx)
+			}
+
+			raw, err := tx.SignWith(key, arg.ChainId)
+			require.NoError(t, err)
+			response.Result, _ = json.Marshal(map[string]interface{}{"raw": raw, "tx": tx})
+		case "eth_sign":
+			var (
+				address eth.Address
+				message eth.Data
+			)
+			require.NoError(t, json.Unmarshal(r.Params[0], &address))
+			require.NoError(t, json.Unmarshal(r.Params[1], &message))
+			require.Equal(t, key.Address().String(), address.String())
+
+			sig, err := eth.SignPersonalMessageWith(key, message.Bytes())
+			require.NoError(t, err)
+			response.Result, _ = json.Marshal(fmt.Sprintf("0x%x", sig.Bytes()))
+		default:
+			raw := json.RawMessage(`{"code":-32601,"message":"the method does not exist"}`)
+			response.Error = &raw
+		}
+		return &response, nil
+	})
+}
+
+func TestRemoteSigner_SignTransaction(t *testing.T) {
+	key, err := eth.NewKeySignerFromHex("0xfad9c8855b740a0b7ed4c221dbad0f33a83a49cad6b3fe8d5817ac83d38b6a19")
+	require.NoError(t, err)
+
+	signer := node.NewRemoteSigner(remoteSigner(t, key, nil), key.Address()).WithContext(context.Background())
+	require.Equal(t, key.Address(), signer.Address())
+
+	chainId := eth.QuantityFromInt64(1)
+	tx := eth.Transaction{
+		Type:                 eth.OptionalQuantityFromInt(int(eth.TransactionTypeDynamicFee)),
+		Nonce:                eth.QuantityFromUInt64(1),
+		MaxFeePerGas:         eth.OptionalQuantityFromInt(30000000000),
+		MaxPriorityFeePerGas: eth.OptionalQuantityFromInt(1000000000),
+		Gas:                  eth.QuantityFromUInt64(21000),
+		To:                   eth.MustAddress("0xc149Be1bcDFa69a94384b46A1F91350E5f81c1AB"),
+		Value:                eth.QuantityFromUInt64(1000),
+		Input:                *eth.MustInput("0x"),
+		ChainId:              &chainId,
+	}
+
+	local := tx
+	expected, err := local.SignWith(key, chainId)
+	require.NoError(t, err)
+
+	raw, err := tx.SignWith(signer, chainId)
+	require.NoError(t, err)
+	require.Equal(t, expected.String(), raw.String())
+	require.Equal(t, key.Address().String(), tx.From.String())
+	require.Equal(t, local.Hash, tx.Hash)
+	require.Equal(t, local.R, tx.R)
+
+	// a signer returning a different transaction is rejected
+	tampering := node.NewRemoteSigner(remoteSigner(t, key, func(tx *eth.Transaction) {
+		tx.Value = eth.QuantityFromUInt64(1000000)
+	}), key.Address())
+	_, err = tx.SignWith(tampering, chainId)
+	require.Error(t, err)
+
+	// as is a signature by another account
+	other, err := eth.NewKeySignerFromHex("0x0123456789012345678901234567890123456789012345678901234567890123")
+	require.NoError(t, err)
+	_, err = tx.SignWith(node.NewRemoteSigner(remoteSigner(t, key, nil), other.Address()), chainId)
+	require.Error(t, err)
+
+	_, err = signer.SignHash(eth.HashPersonalMessage([]byte("hash")))
+	require.Error(t, err)
+}
+
+func TestRemoteSigner_SignPersonalMessage(t *testing.T) {
+	key, err := eth.NewKeySignerFromHex("0xfad9c8855b740a0b7ed4c221dbad0f33a83a49cad6b3fe8d5817ac83d38b6a19")
+	require.NoError(t, err)
+
+	signer := node.NewRemoteSigner(remoteSigner(t, key, nil), key.Address())
+	message := []byte("Sign in to example.com")
+
+	sig, err := eth.SignPersonalMessageWith(signer, message)
+	require.NoError(t, err)
+	require.NoError(t, eth.VerifyPersonalMessage(key.Address(), message, sig.Bytes()))
+
+	failing := node.NewRemoteSigner(requesterFunc(func(ctx context.Context, r *jsonrpc.Request) (*jsonrpc.RawResponse, error) {
+		raw := json.RawMessage(`{"code":-32000,"message":"authentication needed: password or unlock"}`)
+		return &jsonrpc.RawResponse{ID: r.ID, Error: &raw}, nil
+	}), key.Address())
+	_, err = eth.SignPersonalMessageWith(failing, message)
+	require.EqualError(t, err, "authentication needed: password or unlock (code -32000)")
+}
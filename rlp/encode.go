@@ -2,72 +2,87 @@ package rlp
 
 import (
 	"encoding/hex"
-	"math/big"
-	"strings"
 
 	"github.com/pkg/errors"
 )
 
 // Encode returns the 0x prefixed hex string of the RLP value
 func (v Value) Encode() (string, error) {
-	// If String is value encode that
+	b, err := v.EncodeToBytes()
+	if err != nil {
+		return "", err
+	}
+
+	return "0x" + hex.EncodeToString(b), nil
+}
+
+// EncodeToBytes returns the RLP encoding of the value as a byte slice
+func (v Value) EncodeToBytes() ([]byte, error) {
+	return appendEncoded(make([]byte, 0, 64), &v)
+}
+
+// appendEncoded appends the RLP encoding of v to dst and returns the extended slice
+func appendEncoded(dst []byte, v *Value) ([]byte, error) {
 	if v.IsString() {
-		if !strings.HasPrefix(v.String, "0x") {
-			return "", errors.New("invalid string value before encoding")
+		if v.Bytes == nil && (len(v.String) < 2 || v.String[0:2] != "0x") {
+			return nil, errors.New("invalid string value before encoding")
 		}
 
-		b, err := hex.DecodeString(v.String[2:])
+		b, err := v.AsBytes()
 		if err != nil {
-			return "", errors.Wrap(err, "could not decode string value")
+			return nil, err
 		}
 
-		switch {
-		case len(b) == 1 && b[0] <= 0x7f:
+		if len(b) == 1 && b[0] <= 0x7f {
 			// then the string is it's own encoding
-			return v.String, nil
-		case len(b) < 56:
-			return "0x" + asUnprefixedHex(uint64(0x80+len(b))) + v.String[2:], nil
-		default:
-			size := asUnprefixedHex(uint64(len(b)))
-			sizeSize := uint64(len(size) / 2)
-			return "0x" + asUnprefixedHex(0xb7+sizeSize) + size + v.String[2:], nil
-		}
-	} else {
-		// Otherwise encode the list, even if empty
-		count := len(v.List)
-		if count == 0 {
-			// return the empty list
-			return "0xc0", nil
+			return append(dst, b[0]), nil
 		}
 
-		data := make([]string, len(v.List))
-		for i, item := range v.List {
-			encoded, err := item.Encode()
-			if err != nil {
-				return "", errors.Wrap(err, "could not encode child item")
-			}
+		dst = appendHeader(dst, 0x80, uint64(len(b)))
+		return append(dst, b...), nil
+	}
 
-			// Discard the 0x prefix
-			data[i] = encoded[2:]
+	// Otherwise encode the list, even if empty.  Since the header depends on the size of the encoded items, reserve
+	// space for the largest possible header and then move the items back once the actual header size is known.
+	const maxHeader = 9
+	start := len(dst)
+	dst = append(dst, make([]byte, maxHeader)...)
+	for i := range v.List {
+		var err error
+		dst, err = appendEncoded(dst, &v.List[i])
+		if err != nil {
+			return nil, errors.Wrap(err, "could not encode child item")
 		}
+	}
 
-		body := strings.Join(data, "")
-		bodySize := uint64(len(body) / 2)
-		if bodySize < 56 {
-			// 0xc0 + bodySize
-			return "0x" + asUnprefixedHex(bodySize+0xc0) + body, nil
-		} else {
-			bodySizeHex := asUnprefixedHex(bodySize)
-			bodySizeSize := uint64(len(bodySizeHex) / 2)
-			return "0x" + asUnprefixedHex(bodySizeSize+0xf7) + bodySizeHex + body, nil
-		}
+	bodySize := uint64(len(dst) - start - maxHeader)
+	header := appendHeader(make([]byte, 0, maxHeader), 0xc0, bodySize)
+	copy(dst[start:], header)
+	copy(dst[start+len(header):], dst[start+maxHeader:])
+	return dst[:len(dst)-maxHeader+len(header)], nil
+}
+
+// appendHeader appends the RLP string (offset 0x80) or list (offset 0xc0) prefix for a payload of the given size
+func appendHeader(dst []byte, offset byte, size uint64) []byte {
+	if size < 56 {
+		return append(dst, offset+byte(size))
 	}
+
+	sizeBytes := sizeToBytes(size)
+	// 0xb7 or 0xf7 plus the length of the size
+	dst = append(dst, offset+55+byte(len(sizeBytes)))
+	return append(dst, sizeBytes...)
 }
 
-// asUnprefixedHex converts a uint64 to a hex string WITHOUT the 0x prefix
-func asUnprefixedHex(i uint64) string {
-	bn := big.NewInt(0).SetUint64(i)
-	b := bn.Bytes()
-	h := hex.EncodeToString(b)
-	return h
+// sizeToBytes converts a size to its minimal big-endian representation
+func sizeToBytes(size uint64) []byte {
+	b := make([]byte, 0, 8)
+	for shift := 56; shift >= 0; shift -= 8 {
+		c := byte(size >> uint(shift))
+		if c == 0 && len(b) == 0 {
+			continue
+		}
+		b = append(b, c)
+	}
+	return b
 }
@@ -66,3 +66,65 @@ func TestValue_Encode(t *testing.T) {
 		require.Equal(t, "0x820400", encoded)
 	}
 }
+
+func TestValue_EncodeToBytes(t *testing.T) {
+	{
+		// The list [ "cat", "dog" ] = [ 0xc8, 0x83, 'c', 'a', 't', 0x83, 'd', 'o', 'g' ]
+		encoded, err := rlp.Value{
+			List: []rlp.Value{
+				{Bytes: []byte("cat")},
+				{String: "0x646f67"},
+			},
+		}.EncodeToBytes()
+		require.NoError(t, err)
+		require.Equal(t, []byte{0xc8, 0x83, 'c', 'a', 't', 0x83, 'd', 'o', 'g'}, encoded)
+	}
+
+	{
+		// The empty string ('null') = [ 0x80 ]
+		encoded, err := rlp.Value{Bytes: []byte{}}.EncodeToBytes()
+		require.NoError(t, err)
+		require.Equal(t, []byte{0x80}, encoded)
+	}
+
+	{
+		// A single byte below 0x80 is its own encoding, above it's a short string
+		encoded, err := rlp.Value{Bytes: []byte{0x7f}}.EncodeToBytes()
+		require.NoError(t, err)
+		require.Equal(t, []byte{0x7f}, encoded)
+
+		encoded, err = rlp.Value{Bytes: []byte{0x80}}.EncodeToBytes()
+		require.NoError(t, err)
+		require.Equal(t, []byte{0x81, 0x80}, encoded)
+	}
+
+	{
+		// Long strings and lists, with nested long lists
+		long := make([]byte, 1024)
+		encoded, err := rlp.Value{Bytes: long}.EncodeToBytes()
+		require.NoError(t, err)
+		require.Equal(t, []byte{0xb9, 0x04, 0x00}, encoded[:3])
+		require.Len(t, encoded, 1027)
+
+		list := rlp.Value{List: []rlp.Value{
+			{List: []rlp.Value{{Bytes: long[:60]}}},
+			{Bytes: long[:2]},
+		}}
+		encoded, err = list.EncodeToBytes()
+		require.NoError(t, err)
+		require.Equal(t, []byte{0xf8, 0x43, 0xf8, 0x3e, 0xb8, 0x3c}, encoded[:6])
+		require.Equal(t, []byte{0x82, 0x00, 0x00}, encoded[len(encoded)-3:])
+
+		decoded, err := rlp.FromBytes(encoded)
+		require.NoError(t, err)
+		require.Equal(t, long[:60], decoded.List[0].List[0].Bytes)
+	}
+
+	{
+		// Invalid hex strings are still rejected
+		_, err := rlp.Value{String: "646f67"}.EncodeToBytes()
+		require.Error(t, err)
+		_, err = rlp.Value{String: "0xzz"}.EncodeToBytes()
+		require.Error(t, err)
+	}
+}
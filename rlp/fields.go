@@ -0,0 +1,92 @@
+package rlp
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// field describes a single exported struct field that takes part in RLP encoding and decoding.
+type field struct {
+	index    int
+	name     string
+	optional bool
+	tail     bool
+	nilOK    bool
+}
+
+// fieldCache maps a reflect.Type to its parsed []field so struct tags are only inspected once per type.
+var fieldCache sync.Map
+
+// structFields returns the RLP relevant fields of a struct type, validating the `rlp:"..."` struct tags:
+//
+//   - `rlp:"-"` skips the field entirely
+//   - `rlp:"optional"` allows the field to be missing from the end of the list, and omits it (and any following
+//     optional fields) while encoding if it is the zero value.  All fields after an optional field must also be optional.
+//   - `rlp:"tail"` may only be used on the last field, which must be a slice, and collects any remaining list items.
+//   - `rlp:"nil"` may only be used on pointer fields, and decodes an empty string or list as a nil pointer.
+func structFields(typ reflect.Type) ([]field, error) {
+	if cached, ok := fieldCache.Load(typ); ok {
+		return cached.([]field), nil
+	}
+
+	fields := make([]field, 0, typ.NumField())
+	for i := 0; i < typ.NumField(); i++ {
+		sf := typ.Field(i)
+		if sf.PkgPath != "" {
+			// unexported field
+			continue
+		}
+
+		f := field{index: i, name: sf.Name}
+		skip := false
+		for _, tag := range strings.Split(sf.Tag.Get("rlp"), ",") {
+			switch strings.TrimSpace(tag) {
+			case "":
+			case "-":
+				skip = true
+			case "optional":
+				f.optional = true
+			case "tail":
+				f.tail = true
+			case "nil":
+				if sf.Type.Kind() != reflect.Ptr {
+					return nil, errors.Errorf("invalid rlp struct tag \"nil\" for %s.%s, field is not a pointer", typ, sf.Name)
+				}
+				f.nilOK = true
+			default:
+				return nil, errors.Errorf("unknown rlp struct tag %q on %s.%s", tag, typ, sf.Name)
+			}
+		}
+
+		if skip {
+			continue
+		}
+
+		if f.tail {
+			if f.optional {
+				return nil, errors.Errorf("invalid rlp struct tags on %s.%s, \"tail\" cannot be combined with \"optional\"", typ, sf.Name)
+			}
+			if sf.Type.Kind() != reflect.Slice {
+				return nil, errors.Errorf("invalid rlp struct tag \"tail\" for %s.%s, field is not a slice", typ, sf.Name)
+			}
+		}
+
+		if n := len(fields); n > 0 {
+			previous := fields[n-1]
+			if previous.tail {
+				return nil, errors.Errorf("invalid rlp struct tag \"tail\" for %s.%s, must be the last field", typ, previous.name)
+			}
+			if previous.optional && !f.optional {
+				return nil, errors.Errorf("rlp struct field %s.%s must be \"optional\" since it follows an optional field", typ, sf.Name)
+			}
+		}
+
+		fields = append(fields, f)
+	}
+
+	fieldCache.Store(typ, fields)
+	return fields, nil
+}
@@ -1,79 +1,146 @@
 package rlp
 
 import (
-	"strconv"
+	"encoding/hex"
 	"strings"
 
 	"github.com/pkg/errors"
 )
 
-// From parses a 0x prefixed hex string into an rlp.Value
+// From parses a 0x prefixed hex string into an rlp.Value whose strings are held in the String field.
 func From(input string) (*Value, error) {
 	if !strings.HasPrefix(input, "0x") {
 		return nil, errors.New("invalid hex input")
 	}
 
-	value, remainder, err := from(input[2:])
+	b, err := hex.DecodeString(input[2:])
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid hex input")
+	}
+
+	value, err := FromBytes(b)
 	if err != nil {
 		return nil, err
 	}
-	if remainder != "" {
-		return nil, errors.New("extra data at end")
+
+	toHex(value)
+	return value, nil
+}
+
+// FromBytes parses RLP encoded bytes into an rlp.Value whose strings are held in the Bytes field.  Like From, empty
+// input is treated as the empty string.
+//
+// Decoding does not copy the input, the Bytes of every string in the returned value are sub-slices of input, so
+// input must not be modified while the returned value is in use.
+func FromBytes(input []byte) (*Value, error) {
+	return FromBytesWithOptions(input, Options{})
+}
+
+// FromWithOptions is like From, but validates the input according to opts.
+func FromWithOptions(input string, opts Options) (*Value, error) {
+	if !strings.HasPrefix(input, "0x") {
+		return nil, errors.New("invalid hex input")
 	}
+
+	b, err := hex.DecodeString(input[2:])
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid hex input")
+	}
+
+	value, err := FromBytesWithOptions(b, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	toHex(value)
 	return value, nil
 }
 
-// from parses the input string and returns an rlp.Value and any remaining unparsed text
-func from(input string) (*Value, string, error) {
+// FromBytesWithOptions is like FromBytes, but validates the input according to opts.
+func FromBytesWithOptions(input []byte, opts Options) (*Value, error) {
+	if opts.MaxSize > 0 && len(input) > opts.MaxSize {
+		return nil, &DecodeError{Rule: ErrMaxSize, Offset: 0}
+	}
+
+	if len(input) == 0 {
+		if opts.Strict {
+			return nil, &DecodeError{Rule: ErrEmptyInput, Offset: 0}
+		}
+		return &Value{Bytes: []byte{}}, nil
+	}
+
+	d := decoder{opts: opts, input: input}
+	value, remainder, err := d.from(input, 0)
+	if err != nil {
+		return nil, err
+	}
+	if len(remainder) != 0 {
+		return nil, errors.New("extra data at end")
+	}
+	return &value, nil
+}
+
+// toHex converts all Bytes strings in the value to their String representation
+func toHex(v *Value) {
+	if v.Bytes != nil {
+		v.String = v.AsHex()
+		v.Bytes = nil
+		return
+	}
+
+	for i := range v.List {
+		toHex(&v.List[i])
+	}
+}
+
+// decoder holds the state of a single call to FromBytesWithOptions
+type decoder struct {
+	opts     Options
+	input    []byte
+	elements int
+}
+
+// from parses the input bytes, which are nested inside depth lists, and returns an rlp.Value and any remaining
+// unparsed input
+func (d *decoder) from(input []byte, depth int) (Value, []byte, error) {
 
 	// This code was heavily assisted by this series of articles:
 	//   https://medium.com/coinmonks/ethereum-under-the-hood-part-3-rlp-decoding-c0c07f5c0714
 	// And of course the RLP wiki spec:
 	//   https://github.com/ethereum/wiki/wiki/RLP
 
-	if input == "" {
-		return &Value{String: "0x"}, "", nil
+	if len(input) == 0 {
+		return Value{}, nil, errors.New("insufficient remaining input for prefix")
 	}
 
-	for _, r := range input {
-		if (r < 'a' || 'f' < r) && (r < 'A' || 'F' < r) && (r < '0' || '9' < r) {
-			return nil, "", errors.New("invalid rune in input")
-		}
+	offset := len(d.input) - len(input)
+	d.elements++
+	if d.opts.MaxElements > 0 && d.elements > d.opts.MaxElements {
+		return Value{}, nil, &DecodeError{Rule: ErrMaxElements, Offset: offset}
 	}
 
-	remainder := input
-	if len(remainder) < 2 {
-		return nil, "", errors.New("insufficient remaining input for prefix")
-	}
-	b := remainder[0:2]
-	p, err := strconv.ParseUint(b, 16, 8)
-	if err != nil {
-		return nil, "", errors.Wrap(err, "could not decode prefix")
-	}
-
-	prefix := byte(p)
+	prefix := input[0]
+	remainder := input[1:]
 
 	switch {
 	// 0x00 - 0x7f - For a single byte whose value is in the [0x00, 0x7f] range, that byte is its own RLP encoding.
 	case /*0x00 <= prefix &&*/ prefix <= 0x7f:
-		// single byte value, append it
-		s := remainder[0:2]
-		remainder = remainder[2:]
-		return &Value{String: "0x" + s}, remainder, nil
+		// single byte value
+		return Value{Bytes: input[0:1]}, remainder, nil
 
 	// 0x80 - 0xb7 - Otherwise, if a string is 0-55 bytes long, the RLP encoding consists of a single byte with value
 	//               0x80 plus the length of the string followed by the string. The range of the first byte is thus
 	//               [0x80, 0xb7]
 	case 0x80 <= prefix && prefix <= 0xb7:
 		// short string
-		size := uint64((prefix - 0x80) * 2)
-		if size > uint64(len(remainder)-2) {
-			return nil, "", errors.New("insufficient remaining input for short string")
+		size := uint64(prefix - 0x80)
+		if size > uint64(len(remainder)) {
+			return Value{}, nil, errors.New("insufficient remaining input for short string")
+		}
+		if d.opts.Strict && size == 1 && remainder[0] <= 0x7f {
+			return Value{}, nil, &DecodeError{Rule: ErrCanonByte, Offset: offset}
 		}
-		remainder = remainder[2:]
-		s := remainder[0:size]
-		remainder = remainder[size:]
-		return &Value{String: "0x" + s}, remainder, nil
+		return Value{Bytes: remainder[:size:size]}, remainder[size:], nil
 
 	// 0xbb - 0xbf - If a string is more than 55 bytes long, the RLP encoding consists of a single byte with value
 	//               0xb7 plus the length in bytes of the length of the string in binary form, followed by the length
@@ -81,26 +148,20 @@ func from(input string) (*Value, string, error) {
 	//               as \xb9\x04\x00 followed by the string. The range of the first byte is thus [0xb8, 0xbf].
 	case 0xb8 <= prefix && prefix <= 0xbf:
 		// long string
-		sizeSize := int((prefix - 0xb7) * 2)
-		if sizeSize > len(remainder)-2 {
-			return nil, "", errors.New("insufficient remaining input for size of long string")
+		sizeSize := int(prefix - 0xb7)
+		if sizeSize > len(remainder) {
+			return Value{}, nil, errors.New("insufficient remaining input for size of long string")
 		}
-		remainder = remainder[2:]
 
-		size, err := strconv.ParseUint(remainder[0:sizeSize], 16, 64)
-		if err != nil {
-			return nil, "", errors.Wrap(err, "could not decode long string size")
+		size := readSize(remainder[:sizeSize])
+		if d.opts.Strict && (remainder[0] == 0 || size <= 55) {
+			return Value{}, nil, &DecodeError{Rule: ErrCanonSize, Offset: offset}
 		}
-		size *= 2
 		remainder = remainder[sizeSize:]
-
 		if size > uint64(len(remainder)) {
-			return nil, "", errors.New("insufficient remaining input for long string")
+			return Value{}, nil, errors.New("insufficient remaining input for long string")
 		}
-
-		s := remainder[0:size]
-		remainder = remainder[size:]
-		return &Value{String: "0x" + s}, remainder, nil
+		return Value{Bytes: remainder[:size:size]}, remainder[size:], nil
 
 	// 0xc0 - 0xf7 - If the total payload of a list (i.e. the combined length of all its items being RLP encoded) is
 	//               0-55 bytes long, the RLP encoding consists of a single byte with value 0xc0 plus the length of the
@@ -108,18 +169,15 @@ func from(input string) (*Value, string, error) {
 	//               is thus [0xc0, 0xf7]
 	case 0xc0 <= prefix && prefix <= 0xf7:
 		// short list
-		size := uint64((prefix - 0xc0) * 2)
-		// copy the list as is
-		if size > uint64(len(remainder)-2) {
-			return nil, "", errors.New("insufficient remaining input for short list")
+		size := uint64(prefix - 0xc0)
+		if size > uint64(len(remainder)) {
+			return Value{}, nil, errors.New("insufficient remaining input for short list")
 		}
-		remainder = remainder[2:]
-		l, err := parseListItems(remainder[0:size])
+		l, err := d.parseListItems(remainder[:size], depth+1)
 		if err != nil {
-			return nil, "", err
+			return Value{}, nil, err
 		}
-		remainder = remainder[size:]
-		return &Value{List: l}, remainder, nil
+		return Value{List: l}, remainder[size:], nil
 
 	// 0xf8 - 0xff - If the total payload of a list is more than 55 bytes long, the RLP encoding consists of a single
 	//               byte with value 0xf7 plus the length in bytes of the length of the payload in binary form,
@@ -127,53 +185,53 @@ func from(input string) (*Value, string, error) {
 	//               items. The range of the first byte is thus [0xf8, 0xff]
 	case 0xf8 <= prefix /*&& prefix <= 0xff*/ :
 		// long list
-		sizeSize := int((prefix - 0xf7) * 2)
-		if sizeSize > len(remainder)-2 {
-			return nil, "", errors.New("insufficient remaining input for size of long list")
-		}
-		remainder = remainder[2:]
-		size, err := strconv.ParseInt(remainder[0:sizeSize], 16, 64)
-		if err != nil {
-			return nil, "", errors.Wrap(err, "could not decode long list size")
+		sizeSize := int(prefix - 0xf7)
+		if sizeSize > len(remainder) {
+			return Value{}, nil, errors.New("insufficient remaining input for size of long list")
 		}
 
-		maxsize := int64(^uint64(0) >> 2)
-		if size > maxsize || size < 0 {
-			return nil, "", errors.New("invalid list size")
+		size := readSize(remainder[:sizeSize])
+		if d.opts.Strict && (remainder[0] == 0 || size <= 55) {
+			return Value{}, nil, &DecodeError{Rule: ErrCanonSize, Offset: offset}
 		}
-		size *= 2
 		remainder = remainder[sizeSize:]
-
-		// copy the list as is
-		if size > int64(len(remainder)) {
-			return nil, "", errors.New("insufficient remaining input for short list")
+		if size > uint64(len(remainder)) {
+			return Value{}, nil, errors.New("insufficient remaining input for long list")
 		}
-		l, err := parseListItems(remainder[0:size])
+		l, err := d.parseListItems(remainder[:size], depth+1)
 		if err != nil {
-			return nil, "", err
+			return Value{}, nil, err
 		}
-		remainder = remainder[size:]
-		return &Value{List: l}, remainder, nil
+		return Value{List: l}, remainder[size:], nil
 	}
 
 	// The golang compiler should recognize that the above switch is exhaustive but doesn't
 	panic("unreachable")
 }
 
-// parseListItems breaks an RLP text string into an []rlp.Value slice
-func parseListItems(input string) ([]Value, error) {
-	l := make([]Value, 0)
-	for {
-		if input == "" {
-			break
-		}
+// readSize converts the big-endian length bytes of a long string or list to an integer
+func readSize(b []byte) uint64 {
+	size := uint64(0)
+	for _, c := range b {
+		size = size<<8 | uint64(c)
+	}
+	return size
+}
 
-		v, remainder, err := from(input)
+// parseListItems breaks the RLP encoded payload of a list at the given depth into an []rlp.Value slice
+func (d *decoder) parseListItems(input []byte, depth int) ([]Value, error) {
+	if d.opts.MaxDepth > 0 && depth > d.opts.MaxDepth {
+		return nil, &DecodeError{Rule: ErrMaxDepth, Offset: len(d.input) - len(input)}
+	}
+
+	l := make([]Value, 0)
+	for len(input) > 0 {
+		v, remainder, err := d.from(input, depth)
 		if err != nil {
 			return nil, err
 		}
 
-		l = append(l, *v)
+		l = append(l, v)
 		input = remainder
 	}
 
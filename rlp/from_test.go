@@ -1,6 +1,7 @@
 package rlp_test
 
 import (
+	"encoding/hex"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -133,3 +134,81 @@ func TestFrom(t *testing.T) {
 		require.Equal(t, "0x", decoded.String)
 	}
 }
+
+func TestFromBytes(t *testing.T) {
+	{
+		// multilist: [ "zw", [ 4 ], 1 ]
+		input := []byte{0xc6, 0x82, 0x7a, 0x77, 0xc1, 0x04, 0x01}
+		decoded, err := rlp.FromBytes(input)
+		require.NoError(t, err)
+		require.Equal(t, 3, len(decoded.List))
+		require.Equal(t, []byte("zw"), decoded.List[0].Bytes)
+		require.Equal(t, []byte{0x04}, decoded.List[1].List[0].Bytes)
+		require.Equal(t, []byte{0x01}, decoded.List[2].Bytes)
+		require.Equal(t, "0x7a77", decoded.List[0].AsHex())
+
+		// decoded strings are sub-slices of the input, not copies
+		input[2] = 'Z'
+		require.Equal(t, []byte("Zw"), decoded.List[0].Bytes)
+
+		encoded, err := decoded.EncodeToBytes()
+		require.NoError(t, err)
+		require.Equal(t, input, encoded)
+	}
+
+	{
+		// the same value can be decoded from hex or bytes
+		input := "0xf86d820144843b9aca0082520894b78777860637d56543da23312c7865024833f7d188016345785d8a0000802ba0e2539a5d9f056d7095bd19d6b77b850910eeafb71534ebd45159915fab202e91a007484420f3968697974413fc55d1142dc76285d30b1b9231ccb71ed1e720faae"
+		fromHex, err := rlp.From(input)
+		require.NoError(t, err)
+
+		b, err := hex.DecodeString(input[2:])
+		require.NoError(t, err)
+		fromBytes, err := rlp.FromBytes(b)
+		require.NoError(t, err)
+
+		require.Equal(t, len(fromHex.List), len(fromBytes.List))
+		for i := range fromHex.List {
+			require.Equal(t, fromHex.List[i].String, fromBytes.List[i].AsHex())
+
+			asBytes, err := fromHex.List[i].AsBytes()
+			require.NoError(t, err)
+			require.Equal(t, fromBytes.List[i].Bytes, asBytes)
+		}
+
+		h1, err := fromHex.Hash()
+		require.NoError(t, err)
+		h2, err := fromBytes.Hash()
+		require.NoError(t, err)
+		require.Equal(t, h1, h2)
+	}
+
+	{
+		// empty string and empty list
+		decoded, err := rlp.FromBytes([]byte{0x80})
+		require.NoError(t, err)
+		require.True(t, decoded.IsString())
+		require.Equal(t, []byte{}, decoded.Bytes)
+
+		decoded, err = rlp.FromBytes([]byte{0xc0})
+		require.NoError(t, err)
+		require.True(t, decoded.IsList())
+		require.Len(t, decoded.List, 0)
+	}
+
+	{
+		// truncated and trailing input
+		for _, input := range [][]byte{
+			{0x83, 0x64, 0x6f},
+			{0xb8},
+			{0xb8, 0x38},
+			{0xc8, 0x83, 0x63, 0x61, 0x74},
+			{0xf8},
+			{0xf8, 0x38, 0xc0},
+			{0x83, 0x64, 0x6f, 0x67, 0x00},
+		} {
+			_, err := rlp.FromBytes(input)
+			require.Error(t, err, "input %x", input)
+		}
+	}
+}
@@ -2,7 +2,6 @@ package rlp
 
 import (
 	"encoding/hex"
-	"strings"
 
 	"github.com/pkg/errors"
 	"golang.org/x/crypto/sha3"
@@ -23,21 +22,12 @@ func (v Value) Hash() (string, error) {
 
 // HashToBytes returns the keccak256 hash of the encoded RLP as a byte slice
 func (v Value) HashToBytes() ([]byte, error) {
-	// TODO: Consider operating on the already encoded string vs. encoding inside this function
-	// Encode the value back to a hex string
 	var temp []byte
-	encoded, err := v.Encode()
+	b, err := v.EncodeToBytes()
 	if err != nil {
 		return temp, errors.Wrap(err, "could not encode RLP value")
 	}
 
-	// Convert the string to bytes
-	input := strings.Replace(strings.ToLower(encoded), "0x", "", 1)
-	b, err := hex.DecodeString(input)
-	if err != nil {
-		return temp, errors.Wrap(err, "could not convert encoded to bytes")
-	}
-
 	// And feed the bytes into our hash
 	hash := sha3.NewLegacyKeccak256()
 	hash.Write(b)
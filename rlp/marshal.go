@@ -0,0 +1,204 @@
+package rlp
+
+import (
+	"math/big"
+	"reflect"
+
+	"github.com/pkg/errors"
+)
+
+// Marshaler is implemented by types that can describe themselves as an rlp.Value, which includes most of the
+// types in the eth package.
+type Marshaler interface {
+	RLP() Value
+}
+
+var (
+	bigIntType    = reflect.TypeOf(big.Int{})
+	valueType     = reflect.TypeOf(Value{})
+	marshalerType = reflect.TypeOf((*Marshaler)(nil)).Elem()
+)
+
+// Marshal returns the RLP encoding of v.
+//
+// Marshal traverses v recursively and encodes:
+//
+//   - unsigned integers and big.Int values as big-endian strings with no leading zeros (zero is the empty string)
+//   - booleans as 0x01 for true and the empty string for false
+//   - strings, byte slices and byte arrays as RLP strings
+//   - all other slices and arrays as RLP lists
+//   - structs as RLP lists of their exported fields, honoring `rlp:"..."` struct tags
+//   - pointers as the value they point to, or the empty value of the pointed to type if nil
+//   - rlp.Value as is, and any type implementing Marshaler as the result of its RLP method
+//
+// Signed integers, floats, maps, channels and functions are not supported.
+func Marshal(v interface{}) ([]byte, error) {
+	value, err := marshal(reflect.ValueOf(v))
+	if err != nil {
+		return nil, err
+	}
+
+	encoded, err := value.EncodeToBytes()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not encode RLP value")
+	}
+
+	return encoded, nil
+}
+
+// marshal converts a reflect.Value into an rlp.Value
+func marshal(rv reflect.Value) (Value, error) {
+	if !rv.IsValid() {
+		// a nil interface{} is encoded as the empty list
+		return Value{}, nil
+	}
+
+	typ := rv.Type()
+	if typ == valueType {
+		return rv.Interface().(Value), nil
+	}
+
+	if rv.Kind() != reflect.Ptr || !rv.IsNil() {
+		if typ.Implements(marshalerType) {
+			return rv.Interface().(Marshaler).RLP(), nil
+		}
+
+		if reflect.PtrTo(typ).Implements(marshalerType) {
+			return addressable(rv).Addr().Interface().(Marshaler).RLP(), nil
+		}
+	}
+
+	switch rv.Kind() {
+	case reflect.Interface:
+		return marshal(rv.Elem())
+	case reflect.Ptr:
+		if rv.IsNil() {
+			// nil pointers to lists are encoded as the empty list, and all others as the zero value of their type
+			if isListType(typ.Elem()) {
+				return Value{}, nil
+			}
+			return marshal(reflect.New(typ.Elem()).Elem())
+		}
+		return marshal(rv.Elem())
+	case reflect.Bool:
+		if rv.Bool() {
+			return bytesValue([]byte{0x01}), nil
+		}
+		return bytesValue(nil), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return bytesValue(big.NewInt(0).SetUint64(rv.Uint()).Bytes()), nil
+	case reflect.String:
+		return bytesValue([]byte(rv.String())), nil
+	case reflect.Slice:
+		if typ.Elem().Kind() == reflect.Uint8 {
+			return bytesValue(rv.Bytes()), nil
+		}
+		return marshalList(rv)
+	case reflect.Array:
+		if typ.Elem().Kind() == reflect.Uint8 {
+			b := make([]byte, rv.Len())
+			reflect.Copy(reflect.ValueOf(b), rv)
+			return bytesValue(b), nil
+		}
+		return marshalList(rv)
+	case reflect.Struct:
+		if typ == bigIntType {
+			i := addressable(rv).Addr().Interface().(*big.Int)
+			if i.Sign() < 0 {
+				return Value{}, errors.New("cannot encode negative big.Int")
+			}
+			return bytesValue(i.Bytes()), nil
+		}
+		return marshalStruct(rv)
+	}
+
+	return Value{}, errors.Errorf("unsupported encode type %s", typ)
+}
+
+// marshalList converts a slice or array into an RLP list
+func marshalList(rv reflect.Value) (Value, error) {
+	list := make([]Value, rv.Len())
+	for i := range list {
+		item, err := marshal(rv.Index(i))
+		if err != nil {
+			return Value{}, errors.Wrapf(err, "could not encode list item %d", i)
+		}
+		list[i] = item
+	}
+
+	return Value{List: list}, nil
+}
+
+// marshalStruct converts a struct into an RLP list of its fields
+func marshalStruct(rv reflect.Value) (Value, error) {
+	typ := rv.Type()
+	fields, err := structFields(typ)
+	if err != nil {
+		return Value{}, err
+	}
+
+	// Trailing optional fields with zero values are omitted entirely
+	last := len(fields)
+	for last > 0 && fields[last-1].optional && rv.Field(fields[last-1].index).IsZero() {
+		last--
+	}
+
+	list := make([]Value, 0, last)
+	for _, f := range fields[:last] {
+		fv := rv.Field(f.index)
+		if f.tail {
+			for i := 0; i < fv.Len(); i++ {
+				item, err := marshal(fv.Index(i))
+				if err != nil {
+					return Value{}, errors.Wrapf(err, "could not encode field %s.%s item %d", typ, f.name, i)
+				}
+				list = append(list, item)
+			}
+			continue
+		}
+
+		item, err := marshal(fv)
+		if err != nil {
+			return Value{}, errors.Wrapf(err, "could not encode field %s.%s", typ, f.name)
+		}
+		list = append(list, item)
+	}
+
+	return Value{List: list}, nil
+}
+
+// bytesValue returns a string Value holding b, making sure that Bytes is never nil so it isn't mistaken for a list
+func bytesValue(b []byte) Value {
+	if b == nil {
+		b = []byte{}
+	}
+	return Value{Bytes: b}
+}
+
+// isListType returns true if values of the type are always encoded as RLP lists
+func isListType(typ reflect.Type) bool {
+	if typ.Implements(marshalerType) || reflect.PtrTo(typ).Implements(marshalerType) {
+		return false
+	}
+
+	switch typ.Kind() {
+	case reflect.Struct:
+		return typ != bigIntType && typ != valueType
+	case reflect.Slice, reflect.Array:
+		return typ.Elem().Kind() != reflect.Uint8
+	}
+
+	return false
+}
+
+// addressable returns rv if it is addressable, or an addressable copy of it otherwise, so that pointer receiver
+// methods can be called on it.
+func addressable(rv reflect.Value) reflect.Value {
+	if rv.CanAddr() {
+		return rv
+	}
+
+	ptr := reflect.New(rv.Type())
+	ptr.Elem().Set(rv)
+	return ptr.Elem()
+}
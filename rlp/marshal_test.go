@@ -0,0 +1,116 @@
+package rlp_test
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/INFURA/go-ethlibs/rlp"
+)
+
+func TestMarshal(t *testing.T) {
+	encode := func(v interface{}) string {
+		b, err := rlp.Marshal(v)
+		require.NoError(t, err)
+		return "0x" + hex.EncodeToString(b)
+	}
+
+	// From: https://github.com/ethereum/wiki/wiki/RLP#examples
+	require.Equal(t, "0x83646f67", encode("dog"))
+	require.Equal(t, "0xc88363617483646f67", encode([]string{"cat", "dog"}))
+	require.Equal(t, "0x80", encode(""))
+	require.Equal(t, "0xc0", encode([]string{}))
+	require.Equal(t, "0x80", encode(uint64(0)))
+	require.Equal(t, "0x0f", encode(uint8(15)))
+	require.Equal(t, "0x820400", encode(uint16(1024)))
+	require.Equal(t, "0xc7c0c1c0c3c0c1c0", encode([]interface{}{
+		[]interface{}{},
+		[]interface{}{[]interface{}{}},
+		[]interface{}{[]interface{}{}, []interface{}{[]interface{}{}}},
+	}))
+
+	// Other supported types
+	require.Equal(t, "0x01", encode(true))
+	require.Equal(t, "0x80", encode(false))
+	require.Equal(t, "0x83010203", encode([]byte{1, 2, 3}))
+	require.Equal(t, "0x83010203", encode([3]byte{1, 2, 3}))
+	require.Equal(t, "0xa1010000000000000000000000000000000000000000000000000000000000000000", encode(new(big.Int).Lsh(big.NewInt(1), 256)))
+	require.Equal(t, "0x80", encode((*big.Int)(nil)))
+	require.Equal(t, "0xc0", encode(nil))
+	require.Equal(t, "0x83646f67", encode(rlp.Value{String: "0x646f67"}))
+
+	_, err := rlp.Marshal(big.NewInt(-1))
+	require.Error(t, err)
+
+	_, err = rlp.Marshal(int64(1))
+	require.Error(t, err)
+}
+
+type marshalSimple struct {
+	A uint64
+	B string
+	C []byte
+	d uint64 // unexported fields are ignored
+}
+
+type marshalTags struct {
+	A uint64
+	B *big.Int
+	C uint64         `rlp:"-"`
+	D *marshalSimple `rlp:"nil"`
+	E uint64         `rlp:"optional"`
+	F []byte         `rlp:"optional"`
+}
+
+type marshalTail struct {
+	A    uint64
+	Rest []uint64 `rlp:"tail"`
+}
+
+type marshalInvalidOptional struct {
+	A uint64 `rlp:"optional"`
+	B uint64
+}
+
+type marshalInvalidTail struct {
+	A []uint64 `rlp:"tail"`
+	B uint64
+}
+
+type marshalValue struct {
+	Name string
+}
+
+func (m marshalValue) RLP() rlp.Value {
+	return rlp.Value{List: []rlp.Value{{String: "0x" + hex.EncodeToString([]byte(m.Name))}}}
+}
+
+func TestMarshal_Structs(t *testing.T) {
+	encode := func(v interface{}) string {
+		b, err := rlp.Marshal(v)
+		require.NoError(t, err)
+		return "0x" + hex.EncodeToString(b)
+	}
+
+	require.Equal(t, "0xc9018363617483010203", encode(marshalSimple{A: 1, B: "cat", C: []byte{1, 2, 3}, d: 4}))
+	require.Equal(t, "0xc9018363617483010203", encode(&marshalSimple{A: 1, B: "cat", C: []byte{1, 2, 3}}))
+
+	// trailing zero optional fields are omitted, nil pointers are empty values of their type
+	require.Equal(t, "0xc30180c0", encode(marshalTags{A: 1, C: 5}))
+	require.Equal(t, "0xc50102c08080", encode(marshalTags{A: 1, B: big.NewInt(2), F: []byte{}}))
+	require.Equal(t, "0xc70102c00282ffff", encode(marshalTags{A: 1, B: big.NewInt(2), E: 2, F: []byte{0xff, 0xff}}))
+
+	require.Equal(t, "0xc401020304", encode(marshalTail{A: 1, Rest: []uint64{2, 3, 4}}))
+	require.Equal(t, "0xc101", encode(marshalTail{A: 1}))
+
+	require.Equal(t, "0xc483646f67", encode(marshalValue{Name: "dog"}))
+	require.Equal(t, "0xc983636174c483646f67", encode([]interface{}{"cat", marshalValue{Name: "dog"}}))
+
+	_, err := rlp.Marshal(marshalInvalidOptional{})
+	require.Error(t, err)
+
+	_, err = rlp.Marshal(marshalInvalidTail{})
+	require.Error(t, err)
+}
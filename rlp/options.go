@@ -0,0 +1,71 @@
+package rlp
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// Options configures the validation performed by FromWithOptions, FromBytesWithOptions and UnmarshalWithOptions.
+// The zero value accepts everything that From accepts.
+type Options struct {
+	// Strict rejects encodings that are valid RLP but not canonical, matching the encodings refused by geth:
+	// sizes that aren't in their shortest form and single bytes below 0x80 wrapped in a string header.  Empty input,
+	// which From decodes as an empty string, is rejected as well.
+	// FromWithOptions can't tell which strings are integers, so integers with leading zero bytes are rejected by the
+	// decoders which can: UnmarshalWithOptions always rejects them, and eth.Transaction.FromRawWithOptions does so
+	// when Strict is set.
+	Strict bool
+
+	// MaxDepth limits how deeply lists may be nested, a top level list has a depth of 1.  0 means unlimited.
+	MaxDepth int
+
+	// MaxSize limits the total size of the input in bytes.  0 means unlimited.
+	MaxSize int
+
+	// MaxElements limits the total number of strings and lists in the input.  0 means unlimited.
+	MaxElements int
+}
+
+var (
+	// ErrCanonSize is the rule broken by a size that is not in its shortest form, either because a long string or
+	// list header is used for 55 bytes or less, or because the size has leading zero bytes.
+	ErrCanonSize = errors.New("non-canonical size information")
+
+	// ErrCanonByte is the rule broken by a single byte below 0x80 being encoded as a string rather than as itself.
+	ErrCanonByte = errors.New("non-canonical single byte encoded as a string")
+
+	// ErrCanonInt is the rule broken by an integer with leading zero bytes.
+	ErrCanonInt = errors.New("non-canonical integer has leading zero bytes")
+
+	// ErrEmptyInput is the rule broken by input that doesn't hold any value.
+	ErrEmptyInput = errors.New("empty input")
+
+	// ErrMaxDepth is the rule broken by lists nested deeper than Options.MaxDepth.
+	ErrMaxDepth = errors.New("maximum list depth exceeded")
+
+	// ErrMaxSize is the rule broken by input larger than Options.MaxSize.
+	ErrMaxSize = errors.New("maximum input size exceeded")
+
+	// ErrMaxElements is the rule broken by input with more values than Options.MaxElements.
+	ErrMaxElements = errors.New("maximum number of elements exceeded")
+)
+
+// DecodeError is returned when the input breaks one of the rules enforced by Options.  Rule is one of the ErrCanon*,
+// ErrEmptyInput or ErrMax* errors, which is also returned by errors.Cause and errors.Unwrap.
+type DecodeError struct {
+	Rule   error
+	Offset int
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("%s at offset %d", e.Rule, e.Offset)
+}
+
+func (e *DecodeError) Cause() error {
+	return e.Rule
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Rule
+}
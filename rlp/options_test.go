@@ -0,0 +1,133 @@
+package rlp_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/INFURA/go-ethlibs/rlp"
+)
+
+func TestFromWithOptions(t *testing.T) {
+	strict := rlp.Options{Strict: true}
+
+	tests := []struct {
+		name   string
+		input  string
+		rule   error
+		offset int
+	}{
+		{"single byte wrapped in a string", "0x8105", rlp.ErrCanonByte, 0},
+		{"zero byte wrapped in a string", "0x8100", rlp.ErrCanonByte, 0},
+		{"nested single byte wrapped in a string", "0xc3808105", rlp.ErrCanonByte, 2},
+		{"long string for short size", "0xb803646f67", rlp.ErrCanonSize, 0},
+		{"long string size with leading zero", "0xb900" + "38" + strings.Repeat("61", 56), rlp.ErrCanonSize, 0},
+		{"long list for short size", "0xf803c20102", rlp.ErrCanonSize, 0},
+		{"long list size with leading zero", "0xf90001c0", rlp.ErrCanonSize, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// these are all valid without strict mode
+			_, err := rlp.From(tt.input)
+			require.NoError(t, err)
+
+			_, err = rlp.FromWithOptions(tt.input, strict)
+			require.Error(t, err)
+			require.Equal(t, tt.rule, errors.Cause(err))
+
+			decodeErr, ok := err.(*rlp.DecodeError)
+			require.True(t, ok)
+			require.Equal(t, tt.offset, decodeErr.Offset)
+		})
+	}
+
+	{
+		// canonical input is unchanged by strict mode
+		input := "0xf86d820144843b9aca0082520894b78777860637d56543da23312c7865024833f7d188016345785d8a0000802ba0e2539a5d9f056d7095bd19d6b77b850910eeafb71534ebd45159915fab202e91a007484420f3968697974413fc55d1142dc76285d30b1b9231ccb71ed1e720faae"
+		expected, err := rlp.From(input)
+		require.NoError(t, err)
+		actual, err := rlp.FromWithOptions(input, strict)
+		require.NoError(t, err)
+		require.Equal(t, expected, actual)
+
+		for _, input := range []string{"0x00", "0x7f", "0x8180", "0xb838" + strings.Repeat("61", 56), "0xc0"} {
+			_, err := rlp.FromWithOptions(input, strict)
+			require.NoError(t, err, input)
+		}
+	}
+
+	{
+		// empty input is an empty string, but not a canonical encoding of one
+		value, err := rlp.From("0x")
+		require.NoError(t, err)
+		require.Equal(t, "0x", value.String)
+
+		_, err = rlp.FromWithOptions("0x", strict)
+		require.Equal(t, &rlp.DecodeError{Rule: rlp.ErrEmptyInput, Offset: 0}, err)
+
+		_, err = rlp.FromBytesWithOptions(nil, strict)
+		require.Equal(t, rlp.ErrEmptyInput, errors.Cause(err))
+	}
+}
+
+func TestFromBytesWithOptions_Limits(t *testing.T) {
+	{
+		// [ [ [] ] ] has a depth of 3
+		input := []byte{0xc2, 0xc1, 0xc0}
+		_, err := rlp.FromBytesWithOptions(input, rlp.Options{MaxDepth: 3})
+		require.NoError(t, err)
+
+		_, err = rlp.FromBytesWithOptions(input, rlp.Options{MaxDepth: 2})
+		require.Equal(t, rlp.ErrMaxDepth, errors.Cause(err))
+	}
+
+	{
+		input := []byte{0x83, 0x64, 0x6f, 0x67}
+		_, err := rlp.FromBytesWithOptions(input, rlp.Options{MaxSize: 4})
+		require.NoError(t, err)
+
+		_, err = rlp.FromBytesWithOptions(input, rlp.Options{MaxSize: 3})
+		require.Equal(t, rlp.ErrMaxSize, errors.Cause(err))
+	}
+
+	{
+		// [ "zw", [ 4 ], 1 ] has 5 elements
+		input := []byte{0xc6, 0x82, 0x7a, 0x77, 0xc1, 0x04, 0x01}
+		_, err := rlp.FromBytesWithOptions(input, rlp.Options{MaxElements: 5})
+		require.NoError(t, err)
+
+		_, err = rlp.FromBytesWithOptions(input, rlp.Options{MaxElements: 4})
+		require.Equal(t, rlp.ErrMaxElements, errors.Cause(err))
+	}
+}
+
+func TestUnmarshalWithOptions(t *testing.T) {
+	type item struct {
+		A uint64
+		B []byte
+	}
+
+	{
+		var v item
+		err := rlp.UnmarshalWithOptions([]byte{0xc4, 0x05, 0x82, 0x61, 0x62}, &v, rlp.Options{Strict: true})
+		require.NoError(t, err)
+		require.Equal(t, item{A: 5, B: []byte("ab")}, v)
+	}
+
+	{
+		// the integer 5 as a string
+		var v item
+		err := rlp.UnmarshalWithOptions([]byte{0xc5, 0x81, 0x05, 0x82, 0x61, 0x62}, &v, rlp.Options{Strict: true})
+		require.Equal(t, rlp.ErrCanonByte, errors.Cause(err))
+	}
+
+	{
+		// integers with leading zeros are always rejected
+		var v item
+		err := rlp.Unmarshal([]byte{0xc4, 0x82, 0x00, 0x05, 0x80}, &v)
+		require.Equal(t, rlp.ErrCanonInt, errors.Cause(err))
+	}
+}
@@ -0,0 +1,343 @@
+package rlp
+
+import (
+	"bufio"
+	"io"
+	"reflect"
+
+	"github.com/pkg/errors"
+)
+
+// Kind identifies the type of the next value in a Stream.
+type Kind int
+
+const (
+	KindByte   Kind = iota // KindByte is a single byte in the [0x00, 0x7f] range, which is its own encoding
+	KindString             // KindString is an RLP string, which may be empty
+	KindList               // KindList is an RLP list, which may be empty
+)
+
+var (
+	// ErrEOL is returned when attempting to read past the end of the current list.
+	ErrEOL = errors.New("end of list")
+
+	// ErrExpectedString is returned when a string was requested but the next value is a list.
+	ErrExpectedString = errors.New("expected string or byte")
+
+	// ErrExpectedList is returned when a list was requested but the next value is a string.
+	ErrExpectedList = errors.New("expected list")
+
+	// ErrElemTooLarge is returned when a value is larger than the remaining size of its enclosing list.
+	ErrElemTooLarge = errors.New("element is larger than containing list")
+
+	// ErrInputLimit is returned when reading a value would exceed the Stream's input limit.
+	ErrInputLimit = errors.New("value exceeds input limit")
+
+	// ErrNotAtEOL is returned by ListEnd when the current list has unread values.
+	ErrNotAtEOL = errors.New("list has unread values")
+)
+
+// readChunkSize bounds how much memory is allocated for a value before its contents arrive, so that a corrupt header
+// claiming a huge size on an unlimited Stream ends in io.ErrUnexpectedEOF rather than in a huge allocation.
+const readChunkSize = 64 * 1024
+
+// Stream decodes RLP values incrementally from an io.Reader, which allows for processing inputs that are too large
+// to be held in memory as a whole, such as chain exports that are a concatenation of RLP encoded blocks.
+//
+// The next value is inspected with Kind, strings are read with Bytes or Uint64, and lists are entered with List and
+// exited with ListEnd.  Raw, Value and Decode read the next value as a whole.  Once the end of the current list is
+// reached the reading methods return ErrEOL, and at the end of the input at the top level they return io.EOF.
+type Stream struct {
+	r byteReader
+
+	// remaining is how many more bytes of input may be read when limited is true
+	limited   bool
+	remaining uint64
+
+	// stack holds the remaining size of every list that has been entered
+	stack []uint64
+
+	// the header of the next value once it has been read by Kind
+	pending bool
+	kind    Kind
+	size    uint64
+	header  []byte
+}
+
+// byteReader reads the headers of values a byte at a time and their payloads in chunks
+type byteReader interface {
+	io.Reader
+	io.ByteReader
+}
+
+// NewStream creates a Stream reading from r that refuses to read more than inputLimit bytes in total, which protects
+// against inputs claiming very large sizes.  An inputLimit of 0 means the input is unlimited.
+func NewStream(r io.Reader, inputLimit uint64) *Stream {
+	s := Stream{
+		limited:   inputLimit > 0,
+		remaining: inputLimit,
+		header:    make([]byte, 0, 9),
+	}
+
+	if br, ok := r.(byteReader); ok {
+		s.r = br
+	} else {
+		s.r = bufio.NewReader(r)
+	}
+
+	return &s
+}
+
+// Kind returns the kind of the next value and the size of its payload, which is 1 for KindByte, without consuming it.
+func (s *Stream) Kind() (Kind, uint64, error) {
+	if s.pending {
+		return s.kind, s.size, nil
+	}
+
+	if n := len(s.stack); n > 0 && s.stack[n-1] == 0 {
+		return 0, 0, ErrEOL
+	}
+
+	s.header = s.header[:0]
+	prefix, err := s.readByte()
+	if err != nil {
+		if err == io.EOF && len(s.stack) > 0 {
+			return 0, 0, io.ErrUnexpectedEOF
+		}
+		return 0, 0, err
+	}
+	s.header = append(s.header, prefix)
+
+	switch {
+	case prefix <= 0x7f:
+		s.kind, s.size = KindByte, 1
+	case prefix <= 0xb7:
+		s.kind, s.size = KindString, uint64(prefix-0x80)
+	case prefix <= 0xbf:
+		s.kind = KindString
+		s.size, err = s.readLongSize(int(prefix - 0xb7))
+	case prefix <= 0xf7:
+		s.kind, s.size = KindList, uint64(prefix-0xc0)
+	default:
+		s.kind = KindList
+		s.size, err = s.readLongSize(int(prefix - 0xf7))
+	}
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if s.kind != KindByte {
+		if n := len(s.stack); n > 0 && s.size > s.stack[n-1] {
+			return 0, 0, ErrElemTooLarge
+		}
+		if s.limited && s.size > s.remaining {
+			return 0, 0, ErrInputLimit
+		}
+	}
+
+	s.pending = true
+	return s.kind, s.size, nil
+}
+
+// Bytes reads the next value, which must be a string or single byte, and returns its contents.
+func (s *Stream) Bytes() ([]byte, error) {
+	kind, size, err := s.Kind()
+	if err != nil {
+		return nil, err
+	}
+
+	switch kind {
+	case KindByte:
+		s.pending = false
+		return []byte{s.header[0]}, nil
+	case KindString:
+		s.pending = false
+		return s.read(size)
+	default:
+		return nil, ErrExpectedString
+	}
+}
+
+// Uint64 reads the next value, which must be a canonically encoded integer of at most 8 bytes.
+func (s *Stream) Uint64() (uint64, error) {
+	kind, size, err := s.Kind()
+	if err != nil {
+		return 0, err
+	}
+
+	if kind == KindString && size > 8 {
+		return 0, errors.Errorf("integer of %d bytes overflows uint64", size)
+	}
+
+	b, err := s.Bytes()
+	if err != nil {
+		return 0, err
+	}
+
+	if len(b) > 0 && b[0] == 0 {
+		return 0, ErrCanonInt
+	}
+
+	return readSize(b), nil
+}
+
+// List enters the next value, which must be a list, and returns the size of its payload.  The values in the list
+// can then be read until ErrEOL is returned, after which ListEnd must be called.
+func (s *Stream) List() (uint64, error) {
+	kind, size, err := s.Kind()
+	if err != nil {
+		return 0, err
+	}
+
+	if kind != KindList {
+		return 0, ErrExpectedList
+	}
+
+	if n := len(s.stack); n > 0 {
+		s.stack[n-1] -= size
+	}
+
+	s.pending = false
+	s.stack = append(s.stack, size)
+	return size, nil
+}
+
+// ListEnd returns to the enclosing list, and returns an error if the current list has values that weren't read.
+func (s *Stream) ListEnd() error {
+	n := len(s.stack)
+	if n == 0 {
+		return errors.New("not in a list")
+	}
+
+	if s.pending || s.stack[n-1] != 0 {
+		return ErrNotAtEOL
+	}
+
+	s.stack = s.stack[:n-1]
+	return nil
+}
+
+// Raw reads the next value and returns its complete RLP encoding, including its header.
+func (s *Stream) Raw() ([]byte, error) {
+	kind, size, err := s.Kind()
+	if err != nil {
+		return nil, err
+	}
+
+	s.pending = false
+	if kind == KindByte {
+		return []byte{s.header[0]}, nil
+	}
+
+	header := append([]byte(nil), s.header...)
+	payload, err := s.read(size)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(header, payload...), nil
+}
+
+// Value reads the next value and returns it as an rlp.Value whose strings are held in the Bytes field.
+func (s *Stream) Value() (*Value, error) {
+	raw, err := s.Raw()
+	if err != nil {
+		return nil, err
+	}
+
+	return FromBytes(raw)
+}
+
+// Decode reads the next value and stores it in v, as described by Unmarshal.
+func (s *Stream) Decode(v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return errors.Errorf("decode receiver must be a non-nil pointer, got %s", reflect.TypeOf(v))
+	}
+
+	value, err := s.Value()
+	if err != nil {
+		return err
+	}
+
+	return unmarshal(*value, rv.Elem(), false)
+}
+
+// readLongSize reads the big-endian size of a long string or list into the header
+func (s *Stream) readLongSize(sizeSize int) (uint64, error) {
+	for i := 0; i < sizeSize; i++ {
+		c, err := s.readByte()
+		if err != nil {
+			return 0, unexpectedEOF(err)
+		}
+		s.header = append(s.header, c)
+	}
+
+	return readSize(s.header[1:]), nil
+}
+
+// readByte reads a single byte of input, accounting for it against the input limit and the current list
+func (s *Stream) readByte() (byte, error) {
+	c, err := s.r.ReadByte()
+	if err != nil {
+		return 0, err
+	}
+
+	return c, s.consume(1)
+}
+
+// read reads size bytes of input, accounting for them against the input limit and the current list
+func (s *Stream) read(size uint64) ([]byte, error) {
+	if err := s.consume(size); err != nil {
+		return nil, err
+	}
+
+	// the buffer grows a chunk at a time as the input arrives rather than trusting the size from the header
+	capacity := size
+	if capacity > readChunkSize {
+		capacity = readChunkSize
+	}
+
+	b := make([]byte, 0, capacity)
+	for uint64(len(b)) < size {
+		chunk := size - uint64(len(b))
+		if chunk > readChunkSize {
+			chunk = readChunkSize
+		}
+
+		start := len(b)
+		b = append(b, make([]byte, chunk)...)
+		if _, err := io.ReadFull(s.r, b[start:]); err != nil {
+			return nil, unexpectedEOF(err)
+		}
+	}
+
+	return b, nil
+}
+
+// consume deducts size bytes from the input limit and the remaining size of the current list
+func (s *Stream) consume(size uint64) error {
+	if n := len(s.stack); n > 0 {
+		if size > s.stack[n-1] {
+			return ErrElemTooLarge
+		}
+		s.stack[n-1] -= size
+	}
+
+	if s.limited {
+		if size > s.remaining {
+			return ErrInputLimit
+		}
+		s.remaining -= size
+	}
+
+	return nil
+}
+
+// unexpectedEOF converts io.EOF into io.ErrUnexpectedEOF for reads in the middle of a value
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
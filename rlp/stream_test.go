@@ -0,0 +1,234 @@
+package rlp_test
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/INFURA/go-ethlibs/rlp"
+)
+
+func TestStream(t *testing.T) {
+	{
+		// multilist: [ "zw", [ 4 ], 1 ]
+		s := rlp.NewStream(bytes.NewReader([]byte{0xc6, 0x82, 0x7a, 0x77, 0xc1, 0x04, 0x01}), 0)
+
+		kind, size, err := s.Kind()
+		require.NoError(t, err)
+		require.Equal(t, rlp.KindList, kind)
+		require.Equal(t, uint64(6), size)
+
+		size, err = s.List()
+		require.NoError(t, err)
+		require.Equal(t, uint64(6), size)
+
+		b, err := s.Bytes()
+		require.NoError(t, err)
+		require.Equal(t, []byte("zw"), b)
+
+		_, err = s.List()
+		require.NoError(t, err)
+		i, err := s.Uint64()
+		require.NoError(t, err)
+		require.Equal(t, uint64(4), i)
+		_, _, err = s.Kind()
+		require.Equal(t, rlp.ErrEOL, err)
+		require.NoError(t, s.ListEnd())
+
+		kind, size, err = s.Kind()
+		require.NoError(t, err)
+		require.Equal(t, rlp.KindByte, kind)
+		require.Equal(t, uint64(1), size)
+
+		// calling ListEnd before reading every value is an error
+		require.Equal(t, rlp.ErrNotAtEOL, s.ListEnd())
+
+		i, err = s.Uint64()
+		require.NoError(t, err)
+		require.Equal(t, uint64(1), i)
+
+		_, err = s.Bytes()
+		require.Equal(t, rlp.ErrEOL, err)
+		require.NoError(t, s.ListEnd())
+
+		_, _, err = s.Kind()
+		require.Equal(t, io.EOF, err)
+	}
+
+	{
+		// wrong kinds
+		s := rlp.NewStream(bytes.NewReader([]byte{0xc0, 0x80}), 0)
+		_, err := s.Bytes()
+		require.Equal(t, rlp.ErrExpectedString, err)
+		_, err = s.List()
+		require.NoError(t, err)
+		require.NoError(t, s.ListEnd())
+
+		_, err = s.List()
+		require.Equal(t, rlp.ErrExpectedList, err)
+		b, err := s.Bytes()
+		require.NoError(t, err)
+		require.Equal(t, []byte{}, b)
+	}
+
+	{
+		// integers must be canonical and fit in a uint64
+		for _, input := range [][]byte{
+			{0x00},
+			{0x82, 0x00, 0x01},
+			{0x89, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00},
+		} {
+			_, err := rlp.NewStream(bytes.NewReader(input), 0).Uint64()
+			require.Error(t, err, "input %x", input)
+		}
+
+		i, err := rlp.NewStream(bytes.NewReader([]byte{0x88, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}), 0).Uint64()
+		require.NoError(t, err)
+		require.Equal(t, uint64(0xffffffffffffffff), i)
+	}
+}
+
+func TestStream_Limits(t *testing.T) {
+	{
+		// payloads spanning several chunks are read whole, even from a reader returning short reads
+		payload := bytes.Repeat([]byte("0123456789abcdef"), 20000)
+		encoded, err := rlp.Value{Bytes: payload}.EncodeToBytes()
+		require.NoError(t, err)
+
+		s := rlp.NewStream(iotest.HalfReader(bytes.NewReader(encoded)), 0)
+		b, err := s.Bytes()
+		require.NoError(t, err)
+		require.Equal(t, payload, b)
+
+		s = rlp.NewStream(bytes.NewReader(encoded[:len(encoded)-1]), 0)
+		_, err = s.Raw()
+		require.Equal(t, io.ErrUnexpectedEOF, err)
+	}
+
+	{
+		// a string claiming to be larger than the input limit is rejected before it is read
+		s := rlp.NewStream(bytes.NewReader([]byte{0xbb, 0x7f, 0xff, 0xff, 0xff}), 1024)
+		_, err := s.Bytes()
+		require.Equal(t, rlp.ErrInputLimit, err)
+	}
+
+	{
+		// the limit applies to the total input, not to each value
+		s := rlp.NewStream(bytes.NewReader([]byte{0x83, 0x64, 0x6f, 0x67, 0x83, 0x63, 0x61, 0x74}), 6)
+		b, err := s.Bytes()
+		require.NoError(t, err)
+		require.Equal(t, []byte("dog"), b)
+		_, err = s.Bytes()
+		require.Equal(t, rlp.ErrInputLimit, err)
+	}
+
+	{
+		// reading exactly up to the limit ends cleanly
+		s := rlp.NewStream(bytes.NewReader([]byte{0x83, 0x64, 0x6f, 0x67}), 4)
+		_, err := s.Bytes()
+		require.NoError(t, err)
+		_, _, err = s.Kind()
+		require.Equal(t, io.EOF, err)
+	}
+
+	{
+		// values larger than their enclosing list
+		s := rlp.NewStream(bytes.NewReader([]byte{0xc2, 0x83, 0x64, 0x6f, 0x67}), 0)
+		_, err := s.List()
+		require.NoError(t, err)
+		_, err = s.Bytes()
+		require.Equal(t, rlp.ErrElemTooLarge, err)
+	}
+
+	{
+		// truncated input
+		s := rlp.NewStream(bytes.NewReader([]byte{0xc8, 0x83, 0x63, 0x61, 0x74}), 0)
+		_, err := s.List()
+		require.NoError(t, err)
+		_, err = s.Bytes()
+		require.NoError(t, err)
+		_, err = s.Bytes()
+		require.Equal(t, io.ErrUnexpectedEOF, err)
+
+		_, err = rlp.NewStream(bytes.NewReader([]byte{0x83, 0x64, 0x6f}), 0).Raw()
+		require.Equal(t, io.ErrUnexpectedEOF, err)
+	}
+
+	{
+		// corrupt headers claiming huge sizes on an unlimited stream end with the input rather than being allocated
+		huge := []byte{0xbf, 0x10, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x64, 0x6f, 0x67}
+		_, err := rlp.NewStream(bytes.NewReader(huge), 0).Bytes()
+		require.Equal(t, io.ErrUnexpectedEOF, err)
+
+		_, err = rlp.NewStream(bytes.NewReader(huge), 0).Raw()
+		require.Equal(t, io.ErrUnexpectedEOF, err)
+
+		_, err = rlp.NewStream(bytes.NewReader([]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xc0}), 0).Value()
+		require.Equal(t, io.ErrUnexpectedEOF, err)
+	}
+}
+
+func TestStream_Concatenated(t *testing.T) {
+	type item struct {
+		Name   string
+		Number uint64
+		Tags   []string
+	}
+
+	items := []item{
+		{Name: "one", Number: 1, Tags: []string{"a"}},
+		{Name: "two", Number: 2},
+		{Name: "three", Number: 300, Tags: []string{"b", "c"}},
+	}
+
+	// a chain export is a concatenation of values with no enclosing list
+	var input []byte
+	for i := range items {
+		encoded, err := rlp.Marshal(items[i])
+		require.NoError(t, err)
+		input = append(input, encoded...)
+	}
+
+	{
+		s := rlp.NewStream(bytes.NewReader(input), uint64(len(input)))
+		decoded := make([]item, 0)
+		for {
+			var it item
+			err := s.Decode(&it)
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+			decoded = append(decoded, it)
+		}
+
+		items[1].Tags = []string{}
+		require.Equal(t, items, decoded)
+	}
+
+	{
+		s := rlp.NewStream(bytes.NewReader(input), 0)
+		offset := 0
+		for {
+			raw, err := s.Raw()
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+			require.Equal(t, input[offset:offset+len(raw)], raw)
+			offset += len(raw)
+		}
+		require.Equal(t, len(input), offset)
+	}
+
+	{
+		s := rlp.NewStream(bytes.NewReader(input), 0)
+		value, err := s.Value()
+		require.NoError(t, err)
+		require.Len(t, value.List, 3)
+		require.Equal(t, []byte("one"), value.List[0].Bytes)
+	}
+}
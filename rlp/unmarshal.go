@@ -0,0 +1,277 @@
+package rlp
+
+import (
+	"math/big"
+	"reflect"
+
+	"github.com/pkg/errors"
+)
+
+// Unmarshaler is implemented by types that can populate themselves from a decoded rlp.Value.
+type Unmarshaler interface {
+	UnmarshalRLP(Value) error
+}
+
+var unmarshalerType = reflect.TypeOf((*Unmarshaler)(nil)).Elem()
+
+// Unmarshal parses the RLP encoded data and stores the result in the value pointed to by v, which must be a
+// non-nil pointer.  Unmarshal supports the same types as Marshal, with a few additions:
+//
+//   - integers must be canonical, that is they must not have leading zero bytes, and must fit in the receiver
+//   - byte arrays must receive a string of exactly the same length
+//   - a nil pointer is allocated before decoding into it, unless the field is tagged with `rlp:"nil"`
+//     in which case an empty string or list leaves the pointer nil
+//   - an empty interface{} receives a []byte for strings and []interface{} for lists
+//   - an rlp.Value receives the decoded value as is, whose Bytes reference the input data
+func Unmarshal(data []byte, v interface{}) error {
+	return UnmarshalWithOptions(data, v, Options{})
+}
+
+// UnmarshalWithOptions is like Unmarshal, but validates the input according to opts.
+func UnmarshalWithOptions(data []byte, v interface{}, opts Options) error {
+	if len(data) == 0 {
+		return errors.New("no input to decode")
+	}
+
+	decoded, err := FromBytesWithOptions(data, opts)
+	if err != nil {
+		return err
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return errors.Errorf("decode receiver must be a non-nil pointer, got %s", reflect.TypeOf(v))
+	}
+
+	return unmarshal(*decoded, rv.Elem(), false)
+}
+
+// unmarshal stores the contents of an rlp.Value into rv, which must be settable.
+func unmarshal(value Value, rv reflect.Value, nilOK bool) error {
+	typ := rv.Type()
+	if typ == valueType {
+		rv.Set(reflect.ValueOf(value))
+		return nil
+	}
+
+	if reflect.PtrTo(typ).Implements(unmarshalerType) {
+		return rv.Addr().Interface().(Unmarshaler).UnmarshalRLP(value)
+	}
+
+	switch rv.Kind() {
+	case reflect.Ptr:
+		if nilOK && isEmpty(value) {
+			rv.Set(reflect.Zero(typ))
+			return nil
+		}
+		if rv.IsNil() {
+			rv.Set(reflect.New(typ.Elem()))
+		}
+		return unmarshal(value, rv.Elem(), false)
+	case reflect.Interface:
+		if typ.NumMethod() != 0 {
+			return errors.Errorf("unsupported decode receiver %s", typ)
+		}
+		generic, err := unmarshalGeneric(value)
+		if err != nil {
+			return err
+		}
+		rv.Set(reflect.ValueOf(generic))
+		return nil
+	case reflect.Bool:
+		b, err := stringBytes(value, typ)
+		if err != nil {
+			return err
+		}
+		switch {
+		case len(b) == 0:
+			rv.SetBool(false)
+		case len(b) == 1 && b[0] == 0x01:
+			rv.SetBool(true)
+		default:
+			return errors.Errorf("invalid boolean value %s", value.AsHex())
+		}
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		b, err := integerBytes(value, typ)
+		if err != nil {
+			return err
+		}
+		if len(b) > int(typ.Size()) {
+			return errors.Errorf("integer %s overflows %s", value.AsHex(), typ)
+		}
+		i := uint64(0)
+		for _, c := range b {
+			i = i<<8 | uint64(c)
+		}
+		rv.SetUint(i)
+		return nil
+	case reflect.String:
+		b, err := stringBytes(value, typ)
+		if err != nil {
+			return err
+		}
+		rv.SetString(string(b))
+		return nil
+	case reflect.Slice:
+		if typ.Elem().Kind() == reflect.Uint8 {
+			b, err := stringBytes(value, typ)
+			if err != nil {
+				return err
+			}
+			// copy the bytes since the decoded value references the input data
+			rv.SetBytes(append([]byte{}, b...))
+			return nil
+		}
+		if !value.IsList() {
+			return errors.Errorf("cannot decode string into %s", typ)
+		}
+		slice := reflect.MakeSlice(typ, len(value.List), len(value.List))
+		for i := range value.List {
+			if err := unmarshal(value.List[i], slice.Index(i), false); err != nil {
+				return errors.Wrapf(err, "could not decode list item %d", i)
+			}
+		}
+		rv.Set(slice)
+		return nil
+	case reflect.Array:
+		if typ.Elem().Kind() == reflect.Uint8 {
+			b, err := stringBytes(value, typ)
+			if err != nil {
+				return err
+			}
+			if len(b) != rv.Len() {
+				return errors.Errorf("cannot decode %d bytes into %s", len(b), typ)
+			}
+			reflect.Copy(rv, reflect.ValueOf(b))
+			return nil
+		}
+		if !value.IsList() {
+			return errors.Errorf("cannot decode string into %s", typ)
+		}
+		if len(value.List) != rv.Len() {
+			return errors.Errorf("cannot decode list of %d items into %s", len(value.List), typ)
+		}
+		for i := range value.List {
+			if err := unmarshal(value.List[i], rv.Index(i), false); err != nil {
+				return errors.Wrapf(err, "could not decode list item %d", i)
+			}
+		}
+		return nil
+	case reflect.Struct:
+		if typ == bigIntType {
+			b, err := integerBytes(value, typ)
+			if err != nil {
+				return err
+			}
+			rv.Addr().Interface().(*big.Int).SetBytes(b)
+			return nil
+		}
+		return unmarshalStruct(value, rv)
+	}
+
+	return errors.Errorf("unsupported decode receiver %s", typ)
+}
+
+// unmarshalStruct decodes an RLP list into the fields of a struct
+func unmarshalStruct(value Value, rv reflect.Value) error {
+	typ := rv.Type()
+	if !value.IsList() {
+		return errors.Errorf("cannot decode string into %s", typ)
+	}
+
+	fields, err := structFields(typ)
+	if err != nil {
+		return err
+	}
+
+	items := value.List
+	for _, f := range fields {
+		fv := rv.Field(f.index)
+		if f.tail {
+			slice := reflect.MakeSlice(fv.Type(), len(items), len(items))
+			for i := range items {
+				if err := unmarshal(items[i], slice.Index(i), false); err != nil {
+					return errors.Wrapf(err, "could not decode field %s.%s item %d", typ, f.name, i)
+				}
+			}
+			fv.Set(slice)
+			items = nil
+			continue
+		}
+
+		if len(items) == 0 {
+			if !f.optional {
+				return errors.Errorf("too few list items to decode %s, missing %s", typ, f.name)
+			}
+			// missing optional fields are reset to their zero value
+			fv.Set(reflect.Zero(fv.Type()))
+			continue
+		}
+
+		if err := unmarshal(items[0], fv, f.nilOK); err != nil {
+			return errors.Wrapf(err, "could not decode field %s.%s", typ, f.name)
+		}
+		items = items[1:]
+	}
+
+	if len(items) > 0 {
+		return errors.Errorf("too many list items to decode %s", typ)
+	}
+
+	return nil
+}
+
+// unmarshalGeneric decodes an rlp.Value into []byte strings and []interface{} lists
+func unmarshalGeneric(value Value) (interface{}, error) {
+	if value.IsString() {
+		b, err := value.AsBytes()
+		if err != nil {
+			return nil, err
+		}
+		return append([]byte{}, b...), nil
+	}
+
+	list := make([]interface{}, len(value.List))
+	for i := range value.List {
+		item, err := unmarshalGeneric(value.List[i])
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not decode list item %d", i)
+		}
+		list[i] = item
+	}
+
+	return list, nil
+}
+
+// stringBytes returns the bytes of an RLP string, or an error if the value is a list
+func stringBytes(value Value, typ reflect.Type) ([]byte, error) {
+	if value.IsList() {
+		return nil, errors.Errorf("cannot decode list into %s", typ)
+	}
+
+	return value.AsBytes()
+}
+
+// integerBytes returns the bytes of an RLP string that represents a canonical integer,
+// since the yellow paper requires integers to be encoded without any leading zeroes.
+func integerBytes(value Value, typ reflect.Type) ([]byte, error) {
+	b, err := stringBytes(value, typ)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(b) > 0 && b[0] == 0 {
+		return nil, errors.Wrapf(ErrCanonInt, "could not decode integer %s", value.AsHex())
+	}
+
+	return b, nil
+}
+
+// isEmpty returns true for the empty string and the empty list
+func isEmpty(value Value) bool {
+	if value.IsList() {
+		return len(value.List) == 0
+	}
+	return value.String == "0x" || (value.Bytes != nil && len(value.Bytes) == 0)
+}
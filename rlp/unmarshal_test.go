@@ -0,0 +1,146 @@
+package rlp_test
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/INFURA/go-ethlibs/rlp"
+)
+
+func mustDecodeHex(t *testing.T, s string) []byte {
+	b, err := hex.DecodeString(s[2:])
+	require.NoError(t, err)
+	return b
+}
+
+func TestUnmarshal(t *testing.T) {
+	{
+		var s string
+		require.NoError(t, rlp.Unmarshal(mustDecodeHex(t, "0x83646f67"), &s))
+		require.Equal(t, "dog", s)
+	}
+
+	{
+		var l []string
+		require.NoError(t, rlp.Unmarshal(mustDecodeHex(t, "0xc88363617483646f67"), &l))
+		require.Equal(t, []string{"cat", "dog"}, l)
+	}
+
+	{
+		var i uint16
+		require.NoError(t, rlp.Unmarshal(mustDecodeHex(t, "0x820400"), &i))
+		require.Equal(t, uint16(1024), i)
+
+		require.NoError(t, rlp.Unmarshal(mustDecodeHex(t, "0x80"), &i))
+		require.Equal(t, uint16(0), i)
+
+		// too large for the receiver
+		var small uint8
+		require.Error(t, rlp.Unmarshal(mustDecodeHex(t, "0x820400"), &small))
+
+		// leading zeroes are not canonical
+		require.Error(t, rlp.Unmarshal(mustDecodeHex(t, "0x820004"), &i))
+		require.Error(t, rlp.Unmarshal(mustDecodeHex(t, "0x00"), &i))
+
+		// lists can't be decoded to integers
+		require.Error(t, rlp.Unmarshal(mustDecodeHex(t, "0xc0"), &i))
+	}
+
+	{
+		b := new(big.Int)
+		require.NoError(t, rlp.Unmarshal(mustDecodeHex(t, "0xa1010000000000000000000000000000000000000000000000000000000000000000"), b))
+		require.Equal(t, new(big.Int).Lsh(big.NewInt(1), 256), b)
+	}
+
+	{
+		var b bool
+		require.NoError(t, rlp.Unmarshal(mustDecodeHex(t, "0x01"), &b))
+		require.True(t, b)
+		require.NoError(t, rlp.Unmarshal(mustDecodeHex(t, "0x80"), &b))
+		require.False(t, b)
+		require.Error(t, rlp.Unmarshal(mustDecodeHex(t, "0x02"), &b))
+	}
+
+	{
+		var a [3]byte
+		require.NoError(t, rlp.Unmarshal(mustDecodeHex(t, "0x83010203"), &a))
+		require.Equal(t, [3]byte{1, 2, 3}, a)
+
+		var short [4]byte
+		require.Error(t, rlp.Unmarshal(mustDecodeHex(t, "0x83010203"), &short))
+	}
+
+	{
+		var generic interface{}
+		require.NoError(t, rlp.Unmarshal(mustDecodeHex(t, "0xc6827a77c10401"), &generic))
+		require.Equal(t, []interface{}{[]byte("zw"), []interface{}{[]byte{4}}, []byte{1}}, generic)
+	}
+
+	{
+		var v rlp.Value
+		require.NoError(t, rlp.Unmarshal(mustDecodeHex(t, "0xc6827a77c10401"), &v))
+		require.Equal(t, "0x7a77", v.List[0].AsHex())
+	}
+
+	{
+		var s string
+		require.Error(t, rlp.Unmarshal(nil, &s))
+		require.Error(t, rlp.Unmarshal(mustDecodeHex(t, "0x83646f67"), s))
+		require.Error(t, rlp.Unmarshal(mustDecodeHex(t, "0x83646f"), &s))
+	}
+}
+
+func TestUnmarshal_Structs(t *testing.T) {
+	{
+		var s marshalSimple
+		require.NoError(t, rlp.Unmarshal(mustDecodeHex(t, "0xc9018363617483010203"), &s))
+		require.Equal(t, marshalSimple{A: 1, B: "cat", C: []byte{1, 2, 3}}, s)
+
+		// too few and too many items
+		require.Error(t, rlp.Unmarshal(mustDecodeHex(t, "0xc50183636174"), &s))
+		require.Error(t, rlp.Unmarshal(mustDecodeHex(t, "0xca01836361748301020301"), &s))
+	}
+
+	{
+		// missing optional fields, and an empty nil-able pointer
+		s := marshalTags{E: 5, F: []byte{1}}
+		require.NoError(t, rlp.Unmarshal(mustDecodeHex(t, "0xc30180c0"), &s))
+		require.Equal(t, marshalTags{A: 1, B: big.NewInt(0)}, s)
+
+		s = marshalTags{}
+		require.NoError(t, rlp.Unmarshal(mustDecodeHex(t, "0xd00102c90583636174830102030282ffff"), &s))
+		require.Equal(t, marshalTags{
+			A: 1,
+			B: big.NewInt(2),
+			D: &marshalSimple{A: 5, B: "cat", C: []byte{1, 2, 3}},
+			E: 2,
+			F: []byte{0xff, 0xff},
+		}, s)
+	}
+
+	{
+		var s marshalTail
+		require.NoError(t, rlp.Unmarshal(mustDecodeHex(t, "0xc401020304"), &s))
+		require.Equal(t, marshalTail{A: 1, Rest: []uint64{2, 3, 4}}, s)
+
+		require.NoError(t, rlp.Unmarshal(mustDecodeHex(t, "0xc101"), &s))
+		require.Equal(t, marshalTail{A: 1, Rest: []uint64{}}, s)
+	}
+
+	{
+		// round trip
+		src := []marshalTags{
+			{A: 1, B: big.NewInt(1000), D: &marshalSimple{A: 2, B: "dog", C: []byte{}}, E: 3},
+			{A: 0, B: big.NewInt(0)},
+		}
+		encoded, err := rlp.Marshal(src)
+		require.NoError(t, err)
+
+		var decoded []marshalTags
+		require.NoError(t, rlp.Unmarshal(encoded, &decoded))
+		require.Equal(t, src, decoded)
+	}
+}
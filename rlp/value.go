@@ -1,16 +1,58 @@
 package rlp
 
-// Value represents a decoded RLP value, which is either a String or List.
+import (
+	"encoding/hex"
+
+	"github.com/pkg/errors"
+)
+
+// Value represents a decoded RLP value, which is either a string or a List.
 type Value struct {
-	// Only one of String or List is valid.  If String is "" then List is assumed valid.
+	// Only one of String, Bytes or List is valid.  String holds an RLP string as 0x prefixed hex, while Bytes holds
+	// the raw bytes of an RLP string and is what FromBytes produces.  If String is "" and Bytes is nil then List is
+	// assumed valid.
 	String string
+	Bytes  []byte
 	List   []Value
 }
 
 func (v *Value) IsList() bool {
-	return v.String == ""
+	return v.String == "" && v.Bytes == nil
 }
 
 func (v *Value) IsString() bool {
-	return v.String != ""
+	return !v.IsList()
+}
+
+// AsBytes returns the contents of a string Value as a byte slice, regardless of whether the value is held in String
+// or Bytes.  Values holding Bytes are returned as is without copying.
+func (v *Value) AsBytes() ([]byte, error) {
+	if v.Bytes != nil {
+		return v.Bytes, nil
+	}
+
+	if v.IsList() {
+		return nil, errors.New("cannot convert RLP list to bytes")
+	}
+
+	if len(v.String) < 2 || v.String[0:2] != "0x" {
+		return nil, errors.New("invalid string value")
+	}
+
+	b, err := hex.DecodeString(v.String[2:])
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decode string value")
+	}
+
+	return b, nil
+}
+
+// AsHex returns the contents of a string Value as a 0x prefixed hex string, regardless of whether the value is
+// held in String or Bytes.  For lists the empty string is returned.
+func (v *Value) AsHex() string {
+	if v.Bytes != nil {
+		return "0x" + hex.EncodeToString(v.Bytes)
+	}
+
+	return v.String
 }
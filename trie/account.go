@@ -0,0 +1,98 @@
+package trie
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/INFURA/go-ethlibs/eth"
+)
+
+// EmptyCodeHash is the code hash of accounts without code, which is the keccak256 hash of no bytes.
+const EmptyCodeHash = eth.Hash("0xc5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470")
+
+const zeroHash = eth.Hash("0x0000000000000000000000000000000000000000000000000000000000000000")
+
+// VerifyAccountProof checks an eth_getProof result against the state root of the block it was requested for,
+// including every storage proof it contains.  An account that doesn't exist must be reported as empty.
+func VerifyAccountProof(stateRoot eth.Hash, proof *eth.AccountProof) error {
+	value, err := VerifyProof(stateRoot, keccak256(proof.Address.Bytes()), proof.AccountProof)
+	if err != nil {
+		return errors.Wrapf(err, "invalid account proof for %s", proof.Address)
+	}
+
+	if value == nil {
+		if !isEmptyAccount(proof) {
+			return errors.Errorf("account %s does not exist but was reported with a non-empty state", proof.Address)
+		}
+	} else {
+		expected, err := proof.RLP().EncodeToBytes()
+		if err != nil {
+			return errors.Wrap(err, "could not encode account")
+		}
+
+		if !bytes.Equal(value, expected) {
+			return errors.Errorf("account %s does not match the state proven by its account proof", proof.Address)
+		}
+	}
+
+	for i := range proof.StorageProof {
+		if err := VerifyStorageProof(proof.StorageHash, &proof.StorageProof[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// VerifyStorageProof checks a single storage slot proof against the storage root of its account.
+func VerifyStorageProof(storageRoot eth.Hash, proof *eth.StorageProof) error {
+	slot, err := proof.Slot()
+	if err != nil {
+		return err
+	}
+
+	if isZeroHash(storageRoot) {
+		// some clients report the storage root of accounts that don't exist as zero rather than the empty root
+		storageRoot = EmptyRoot
+	}
+
+	value, err := VerifyProof(storageRoot, keccak256(slot.Bytes()), proof.Proof)
+	if err != nil {
+		return errors.Wrapf(err, "invalid storage proof for slot %s", slot)
+	}
+
+	// storage values are stored as RLP encoded integers, and zero values are removed from the trie
+	expected := []byte(nil)
+	if proof.Value.Big().Sign() != 0 {
+		expected, err = proof.Value.RLP().EncodeToBytes()
+		if err != nil {
+			return errors.Wrap(err, "could not encode storage value")
+		}
+	}
+
+	if !bytes.Equal(value, expected) {
+		return errors.Errorf("storage slot %s does not match the value proven by its storage proof", slot)
+	}
+
+	return nil
+}
+
+// isEmptyAccount returns true if the proof reports the state of an account that doesn't exist, for which clients
+// report either zero or empty hashes.
+func isEmptyAccount(proof *eth.AccountProof) bool {
+	if proof.Nonce.Big().Sign() != 0 || proof.Balance.Big().Sign() != 0 {
+		return false
+	}
+
+	if !strings.EqualFold(proof.StorageHash.String(), EmptyRoot.String()) && !isZeroHash(proof.StorageHash) {
+		return false
+	}
+
+	return strings.EqualFold(proof.CodeHash.String(), EmptyCodeHash.String()) || isZeroHash(proof.CodeHash)
+}
+
+func isZeroHash(h eth.Hash) bool {
+	return strings.EqualFold(h.String(), zeroHash.String())
+}
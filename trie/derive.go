@@ -0,0 +1,91 @@
+package trie
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/INFURA/go-ethlibs/eth"
+)
+
+// DerivableList is a list of items that can be stored in a trie keyed by the RLP encoding of their index, which is
+// how the transactions, receipts and withdrawals roots of a block are computed.
+type DerivableList interface {
+	Len() int
+	EncodeIndex(i int) ([]byte, error)
+}
+
+// DeriveSha returns the root hash of a trie containing every item of list keyed by its RLP encoded index.
+func DeriveSha(list DerivableList) (*eth.Hash, error) {
+	t := New()
+	for i := 0; i < list.Len(); i++ {
+		value, err := list.EncodeIndex(i)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not encode item %d", i)
+		}
+
+		t.Insert(indexKey(i), value)
+	}
+
+	h := t.Hash()
+	return &h, nil
+}
+
+// DeriveTransactionsRoot returns the transactions root of a block containing txs.
+func DeriveTransactionsRoot(txs []eth.Transaction) (*eth.Hash, error) {
+	return DeriveSha(transactions(txs))
+}
+
+// DeriveReceiptsRoot returns the receipts root of a block whose transactions produced receipts.
+func DeriveReceiptsRoot(receipts []eth.TransactionReceipt) (*eth.Hash, error) {
+	return DeriveSha(transactionReceipts(receipts))
+}
+
+// DeriveWithdrawalsRoot returns the EIP-4895 withdrawals root of a block containing withdrawals.
+func DeriveWithdrawalsRoot(withdrawals []eth.Withdrawal) (*eth.Hash, error) {
+	return DeriveSha(withdrawalList(withdrawals))
+}
+
+// DeriveBlockTransactionsRoot returns the transactions root of a block, which must have been fetched with its
+// transactions populated.
+func DeriveBlockTransactionsRoot(block *eth.Block) (*eth.Hash, error) {
+	txs := make([]eth.Transaction, len(block.Transactions))
+	for i := range block.Transactions {
+		if !block.Transactions[i].Populated {
+			return nil, errors.New("block transactions must be populated to derive the transactions root")
+		}
+		txs[i] = block.Transactions[i].Transaction
+	}
+
+	return DeriveTransactionsRoot(txs)
+}
+
+type transactions []eth.Transaction
+
+func (t transactions) Len() int { return len(t) }
+
+func (t transactions) EncodeIndex(i int) ([]byte, error) {
+	raw, err := t[i].RawRepresentation()
+	if err != nil {
+		return nil, err
+	}
+	return raw.Bytes(), nil
+}
+
+type transactionReceipts []eth.TransactionReceipt
+
+func (r transactionReceipts) Len() int { return len(r) }
+
+func (r transactionReceipts) EncodeIndex(i int) ([]byte, error) {
+	raw, err := r[i].RawRepresentation()
+	if err != nil {
+		return nil, err
+	}
+	return raw.Bytes(), nil
+}
+
+type withdrawalList []eth.Withdrawal
+
+func (w withdrawalList) Len() int { return len(w) }
+
+func (w withdrawalList) EncodeIndex(i int) ([]byte, error) {
+	return w[i].RLP().EncodeToBytes()
+}
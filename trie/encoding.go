@@ -0,0 +1,75 @@
+package trie
+
+// keyToNibbles splits every byte of key into two nibbles, high nibble first, and appends the terminator.
+func keyToNibbles(key []byte) []byte {
+	nibbles := make([]byte, len(key)*2+1)
+	for i, b := range key {
+		nibbles[i*2] = b >> 4
+		nibbles[i*2+1] = b & 0x0f
+	}
+	nibbles[len(nibbles)-1] = terminator
+	return nibbles
+}
+
+// compactKey returns the hex-prefix encoding of a nibble key, where the high nibble of the first byte flags
+// whether the key belongs to a leaf node (2) and whether it has an odd number of nibbles (1).
+func compactKey(nibbles []byte) []byte {
+	flags := byte(0)
+	if hasTerminator(nibbles) {
+		flags = 2
+		nibbles = nibbles[:len(nibbles)-1]
+	}
+
+	compact := make([]byte, len(nibbles)/2+1)
+	if len(nibbles)%2 == 1 {
+		flags |= 1
+		compact[0] = nibbles[0]
+		nibbles = nibbles[1:]
+	}
+	compact[0] |= flags << 4
+
+	for i := 0; i < len(nibbles); i += 2 {
+		compact[i/2+1] = nibbles[i]<<4 | nibbles[i+1]
+	}
+
+	return compact
+}
+
+// compactToNibbles reverses compactKey
+func compactToNibbles(compact []byte) []byte {
+	if len(compact) == 0 {
+		return nil
+	}
+
+	nibbles := keyToNibbles(compact)
+	flags := nibbles[0]
+	if flags&2 == 0 {
+		// extension nodes don't have the terminator
+		nibbles = nibbles[:len(nibbles)-1]
+	}
+
+	// drop the flags nibble, and the padding nibble of even length keys
+	if flags&1 == 1 {
+		return nibbles[1:]
+	}
+	return nibbles[2:]
+}
+
+func hasTerminator(nibbles []byte) bool {
+	return len(nibbles) > 0 && nibbles[len(nibbles)-1] == terminator
+}
+
+// prefixLength returns the length of the common prefix of a and b
+func prefixLength(a, b []byte) int {
+	i := 0
+	for i < len(a) && i < len(b) && a[i] == b[i] {
+		i++
+	}
+	return i
+}
+
+// concat returns a new slice containing a followed by b
+func concat(a []byte, b ...byte) []byte {
+	out := make([]byte, 0, len(a)+len(b))
+	return append(append(out, a...), b...)
+}
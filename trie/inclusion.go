@@ -0,0 +1,91 @@
+package trie
+
+import (
+	"encoding/hex"
+
+	"github.com/pkg/errors"
+
+	"github.com/INFURA/go-ethlibs/eth"
+	"github.com/INFURA/go-ethlibs/rlp"
+)
+
+// ProveIndex returns a proof for the item at index i of list against the root returned by DeriveSha.
+func ProveIndex(list DerivableList, i int) ([]eth.Data, error) {
+	if i < 0 || i >= list.Len() {
+		return nil, errors.Errorf("index %d is out of range for a list of %d items", i, list.Len())
+	}
+
+	t := New()
+	for j := 0; j < list.Len(); j++ {
+		value, err := list.EncodeIndex(j)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not encode item %d", j)
+		}
+
+		t.Insert(indexKey(j), value)
+	}
+
+	return t.Prove(indexKey(i)), nil
+}
+
+// VerifyIndex checks a proof created by ProveIndex against root, and returns the encoded item at index i.
+func VerifyIndex(root eth.Hash, i int, proof []eth.Data) ([]byte, error) {
+	value, err := VerifyProof(root, indexKey(i), proof)
+	if err != nil {
+		return nil, err
+	}
+
+	if value == nil {
+		return nil, errors.Errorf("proof shows there is no item at index %d", i)
+	}
+
+	return value, nil
+}
+
+// ProveTransaction returns a proof that the transaction at index i is included in the transactions root of a block
+// containing txs.
+func ProveTransaction(txs []eth.Transaction, i int) ([]eth.Data, error) {
+	return ProveIndex(transactions(txs), i)
+}
+
+// VerifyTransactionProof checks a proof created by ProveTransaction against the transactions root of a block, and
+// returns the transaction at index i.
+func VerifyTransactionProof(transactionsRoot eth.Hash, i int, proof []eth.Data) (*eth.Transaction, error) {
+	raw, err := VerifyIndex(transactionsRoot, i, proof)
+	if err != nil {
+		return nil, err
+	}
+
+	tx := eth.Transaction{}
+	if err := tx.FromRaw("0x" + hex.EncodeToString(raw)); err != nil {
+		return nil, errors.Wrap(err, "could not decode proven transaction")
+	}
+
+	return &tx, nil
+}
+
+// ProveReceipt returns a proof that the receipt at index i is included in the receipts root of a block whose
+// transactions produced receipts.
+func ProveReceipt(receipts []eth.TransactionReceipt, i int) ([]eth.Data, error) {
+	return ProveIndex(transactionReceipts(receipts), i)
+}
+
+// VerifyReceiptProof checks a proof created by ProveReceipt against the receipts root of a block, and returns the
+// raw representation of the receipt at index i.
+func VerifyReceiptProof(receiptsRoot eth.Hash, i int, proof []eth.Data) (*eth.Data, error) {
+	raw, err := VerifyIndex(receiptsRoot, i, proof)
+	if err != nil {
+		return nil, err
+	}
+
+	return eth.NewData("0x" + hex.EncodeToString(raw))
+}
+
+// indexKey returns the key of the item at index i, which is its RLP encoding
+func indexKey(i int) []byte {
+	key, err := rlp.Marshal(uint64(i))
+	if err != nil {
+		panic(err)
+	}
+	return key
+}
@@ -0,0 +1,185 @@
+package trie
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/INFURA/go-ethlibs/eth"
+	"github.com/INFURA/go-ethlibs/rlp"
+)
+
+// Prove returns a proof for key, which is the RLP encoding of every node on the path from the root towards key that
+// is referenced by its hash.  If key isn't in the trie the proof shows where the path ends instead.
+func (t *Trie) Prove(key []byte) []eth.Data {
+	proof := make([]eth.Data, 0)
+	n := t.root
+	k := keyToNibbles(key)
+	for n != nil {
+		if _, ok := n.(valueNode); ok {
+			break
+		}
+
+		// nodes whose encoding is shorter than 32 bytes are embedded in their parent, except for the root
+		encoded := mustEncode(encodeNode(n))
+		if len(proof) == 0 || len(encoded) >= 32 {
+			proof = append(proof, eth.Data("0x"+hex.EncodeToString(encoded)))
+		}
+
+		switch current := n.(type) {
+		case *shortNode:
+			if !bytes.HasPrefix(k, current.key) {
+				return proof
+			}
+			n, k = current.value, k[len(current.key):]
+		case *branchNode:
+			n, k = current.children[k[0]], k[1:]
+		}
+	}
+
+	return proof
+}
+
+// VerifyProof checks proof against the root hash of a trie, and returns the value stored under key, or nil if the
+// proof shows that the trie doesn't contain key.  The nodes of the proof may be in any order.
+func VerifyProof(root eth.Hash, key []byte, proof []eth.Data) ([]byte, error) {
+	if len(proof) == 0 && strings.EqualFold(root.String(), EmptyRoot.String()) {
+		// nothing is needed to prove that the empty trie doesn't contain key
+		return nil, nil
+	}
+
+	nodes := make(map[string][]byte, len(proof))
+	for i := range proof {
+		b, err := hex.DecodeString(strings.TrimPrefix(proof[i].String(), "0x"))
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not decode proof node %d", i)
+		}
+		nodes[string(keccak256(b))] = b
+	}
+
+	want := root.Bytes()
+	k := keyToNibbles(key)
+	for i := 0; ; i++ {
+		encoded, ok := nodes[string(want)]
+		if !ok {
+			return nil, errors.Errorf("proof node %d with hash 0x%x is missing", i, want)
+		}
+
+		decoded, err := rlp.FromBytes(encoded)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not decode proof node %d", i)
+		}
+
+		n, err := decodeNode(*decoded)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid proof node %d", i)
+		}
+
+		var child node
+		k, child = walk(n, k)
+		switch child := child.(type) {
+		case nil:
+			return nil, nil
+		case valueNode:
+			return child, nil
+		case hashNode:
+			want = child
+		}
+	}
+}
+
+// walk follows key through n and any nodes embedded in it, returning the remainder of the key along with the
+// value, hash reference or nil where the path ends
+func walk(n node, key []byte) ([]byte, node) {
+	for {
+		switch current := n.(type) {
+		case nil, valueNode:
+			if len(key) != 0 {
+				return nil, nil
+			}
+			return nil, current
+		case hashNode:
+			return key, current
+		case *shortNode:
+			if !bytes.HasPrefix(key, current.key) {
+				return nil, nil
+			}
+			n, key = current.value, key[len(current.key):]
+		case *branchNode:
+			n, key = current.children[key[0]], key[1:]
+		}
+	}
+}
+
+// decodeNode converts the RLP structure of a node back into a node
+func decodeNode(value rlp.Value) (node, error) {
+	if !value.IsList() {
+		return nil, errors.New("trie node must be a list")
+	}
+
+	switch len(value.List) {
+	case 2:
+		compact, err := value.List[0].AsBytes()
+		if err != nil || value.List[0].IsList() || len(compact) == 0 {
+			return nil, errors.New("invalid short node key")
+		}
+
+		key := compactToNibbles(compact)
+		if hasTerminator(key) {
+			v, err := value.List[1].AsBytes()
+			if err != nil || value.List[1].IsList() {
+				return nil, errors.New("invalid leaf node value")
+			}
+			return &shortNode{key: key, value: valueNode(v)}, nil
+		}
+
+		child, err := decodeReference(value.List[1])
+		if err != nil {
+			return nil, err
+		}
+		return &shortNode{key: key, value: child}, nil
+	case 17:
+		branch := &branchNode{}
+		for i := 0; i < 16; i++ {
+			child, err := decodeReference(value.List[i])
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid branch node child %d", i)
+			}
+			branch.children[i] = child
+		}
+
+		v, err := value.List[terminator].AsBytes()
+		if err != nil || value.List[terminator].IsList() {
+			return nil, errors.New("invalid branch node value")
+		}
+		if len(v) > 0 {
+			branch.children[terminator] = valueNode(v)
+		}
+		return branch, nil
+	}
+
+	return nil, errors.Errorf("trie node must have 2 or 17 items, not %d", len(value.List))
+}
+
+// decodeReference decodes a child reference, which is either empty, a hash or an embedded node
+func decodeReference(value rlp.Value) (node, error) {
+	if value.IsList() {
+		return decodeNode(value)
+	}
+
+	b, err := value.AsBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	switch len(b) {
+	case 0:
+		return nil, nil
+	case 32:
+		return hashNode(b), nil
+	}
+
+	return nil, errors.Errorf("invalid node reference of %d bytes", len(b))
+}
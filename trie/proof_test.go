@@ -0,0 +1,251 @@
+package trie_test
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/sha3"
+
+	"github.com/INFURA/go-ethlibs/eth"
+	"github.com/INFURA/go-ethlibs/trie"
+)
+
+func TestTrie_Prove(t *testing.T) {
+	tr := trie.New()
+	for i := 0; i < 500; i++ {
+		tr.Insert([]byte(fmt.Sprintf("key-%d", i)), []byte(fmt.Sprintf("value-%d", i)))
+	}
+	root := tr.Hash()
+
+	for i := 0; i < 500; i++ {
+		key := []byte(fmt.Sprintf("key-%d", i))
+		proof := tr.Prove(key)
+		value, err := trie.VerifyProof(root, key, proof)
+		require.NoError(t, err)
+		require.Equal(t, []byte(fmt.Sprintf("value-%d", i)), value)
+	}
+
+	{
+		// proofs of absence
+		for _, key := range []string{"key-500", "key-", "k", "other", "key-1000000"} {
+			proof := tr.Prove([]byte(key))
+			value, err := trie.VerifyProof(root, []byte(key), proof)
+			require.NoError(t, err, key)
+			require.Nil(t, value, key)
+		}
+	}
+
+	{
+		// incomplete proofs
+		key := []byte("key-42")
+		proof := tr.Prove(key)
+		require.True(t, len(proof) > 1)
+
+		_, err := trie.VerifyProof(root, key, proof[:len(proof)-1])
+		require.Error(t, err)
+
+		_, err = trie.VerifyProof(root, key, proof[1:])
+		require.Error(t, err)
+	}
+
+	{
+		// proofs for a different root
+		key := []byte("key-42")
+		proof := tr.Prove(key)
+		tr.Insert(key, []byte("changed"))
+
+		_, err := trie.VerifyProof(tr.Hash(), key, proof)
+		require.Error(t, err)
+
+		value, err := trie.VerifyProof(tr.Hash(), key, tr.Prove(key))
+		require.NoError(t, err)
+		require.Equal(t, []byte("changed"), value)
+	}
+
+	{
+		// small tries whose root node embeds everything
+		small := trie.New()
+		small.Insert([]byte{0x01}, []byte{0x02})
+		proof := small.Prove([]byte{0x01})
+		require.Len(t, proof, 1)
+
+		value, err := trie.VerifyProof(small.Hash(), []byte{0x01}, proof)
+		require.NoError(t, err)
+		require.Equal(t, []byte{0x02}, value)
+	}
+
+	{
+		// nothing is needed to prove absence from the empty trie
+		value, err := trie.VerifyProof(trie.EmptyRoot, []byte("key"), nil)
+		require.NoError(t, err)
+		require.Nil(t, value)
+	}
+}
+
+func TestVerifyAccountProof(t *testing.T) {
+	// build the storage trie of an account with a few slots
+	storage := trie.New()
+	slots := map[string]string{
+		"0x0000000000000000000000000000000000000000000000000000000000000000": "0x2a",
+		"0x0000000000000000000000000000000000000000000000000000000000000001": "0x0123456789abcdef",
+		"0x290decd9548b62a8d60345a988386fc84ba6bc95484008f6362f93160ef3e563": "0x1",
+	}
+	for slot, value := range slots {
+		encoded, err := eth.MustQuantity(value).RLP().EncodeToBytes()
+		require.NoError(t, err)
+		storage.Insert(keccak(eth.MustHash(slot).Bytes()), encoded)
+	}
+
+	account := eth.AccountProof{
+		Address:     *eth.MustAddress("0x7f0d15c7faae65896648c8273b6d7e43f58fa842"),
+		Balance:     *eth.MustQuantity("0xde0b6b3a7640000"),
+		CodeHash:    *eth.MustHash("0x3a8ed6e7dfa4a0b1e5ae2d8a0b7bc4e0f1e6b1e3d6c4f2a1b0c9d8e7f6a5b4c3"),
+		Nonce:       *eth.MustQuantity("0x5"),
+		StorageHash: storage.Hash(),
+	}
+
+	// and the state trie containing it along with some other accounts
+	state := trie.New()
+	for i := 0; i < 100; i++ {
+		other := eth.AccountProof{
+			Nonce:       *eth.MustQuantity(fmt.Sprintf("0x%x", i)),
+			Balance:     *eth.MustQuantity("0x1"),
+			StorageHash: trie.EmptyRoot,
+			CodeHash:    trie.EmptyCodeHash,
+		}
+		encoded, err := other.RLP().EncodeToBytes()
+		require.NoError(t, err)
+		state.Insert(keccak([]byte(fmt.Sprintf("account-%d", i))), encoded)
+	}
+	encoded, err := account.RLP().EncodeToBytes()
+	require.NoError(t, err)
+	state.Insert(keccak(account.Address.Bytes()), encoded)
+	stateRoot := state.Hash()
+
+	account.AccountProof = state.Prove(keccak(account.Address.Bytes()))
+	for _, slot := range []string{"0x0", "0x01", "0x290decd9548b62a8d60345a988386fc84ba6bc95484008f6362f93160ef3e563", "0x2"} {
+		hash, err := (&eth.StorageProof{Key: eth.Data(slot)}).Slot()
+		require.NoError(t, err)
+
+		value := "0x0"
+		if v, ok := slots[hash.String()]; ok {
+			value = v
+		}
+
+		account.StorageProof = append(account.StorageProof, eth.StorageProof{
+			Key:   eth.Data(slot),
+			Value: *eth.MustQuantity(value),
+			Proof: storage.Prove(keccak(hash.Bytes())),
+		})
+	}
+
+	require.NoError(t, trie.VerifyAccountProof(stateRoot, &account))
+
+	{
+		// a different balance than what was proven
+		tampered := account
+		tampered.Balance = *eth.MustQuantity("0xde0b6b3a7640001")
+		require.Error(t, trie.VerifyAccountProof(stateRoot, &tampered))
+	}
+
+	{
+		// a different storage value than what was proven
+		tampered := account
+		tampered.StorageProof = append([]eth.StorageProof{}, account.StorageProof...)
+		tampered.StorageProof[0].Value = *eth.MustQuantity("0x2b")
+		require.Error(t, trie.VerifyAccountProof(stateRoot, &tampered))
+	}
+
+	{
+		// claiming a non-zero value for an empty slot
+		tampered := account
+		tampered.StorageProof = append([]eth.StorageProof{}, account.StorageProof...)
+		tampered.StorageProof[3].Value = *eth.MustQuantity("0x1")
+		require.Error(t, trie.VerifyAccountProof(stateRoot, &tampered))
+	}
+
+	{
+		// accounts that don't exist must be empty, whichever way the client reports empty hashes
+		missing := eth.AccountProof{
+			Address:     *eth.MustAddress("0x0000000000000000000000000000000000000001"),
+			Balance:     *eth.MustQuantity("0x0"),
+			CodeHash:    trie.EmptyCodeHash,
+			Nonce:       *eth.MustQuantity("0x0"),
+			StorageHash: trie.EmptyRoot,
+			StorageProof: []eth.StorageProof{
+				{Key: "0x0", Value: *eth.MustQuantity("0x0"), Proof: []eth.Data{}},
+			},
+		}
+		missing.AccountProof = state.Prove(keccak(missing.Address.Bytes()))
+		require.NoError(t, trie.VerifyAccountProof(stateRoot, &missing))
+
+		missing.CodeHash = *eth.MustHash("0x0000000000000000000000000000000000000000000000000000000000000000")
+		missing.StorageHash = *eth.MustHash("0x0000000000000000000000000000000000000000000000000000000000000000")
+		require.NoError(t, trie.VerifyAccountProof(stateRoot, &missing))
+
+		missing.Balance = *eth.MustQuantity("0x1")
+		require.Error(t, trie.VerifyAccountProof(stateRoot, &missing))
+	}
+}
+
+func TestTransactionProof(t *testing.T) {
+	block := eth.Block{}
+	err := json.Unmarshal([]byte(mainnetBlock7220772), &block)
+	require.NoError(t, err)
+
+	txs := make([]eth.Transaction, len(block.Transactions))
+	for i := range block.Transactions {
+		txs[i] = block.Transactions[i].Transaction
+	}
+
+	for _, i := range []int{0, 7, 28} {
+		proof, err := trie.ProveTransaction(txs, i)
+		require.NoError(t, err)
+
+		tx, err := trie.VerifyTransactionProof(block.TransactionsRoot, i, proof)
+		require.NoError(t, err)
+		require.Equal(t, txs[i].Hash, tx.Hash)
+
+		// the proof is only valid for its index
+		_, err = trie.VerifyTransactionProof(block.TransactionsRoot, i+1, proof)
+		require.Error(t, err)
+	}
+
+	_, err = trie.ProveTransaction(txs, len(txs))
+	require.Error(t, err)
+}
+
+func TestReceiptProof(t *testing.T) {
+	cumulatives := []string{"0x5208", "0xa410", "0xf618"}
+	receipts := make([]eth.TransactionReceipt, 0, 3)
+	for _, cumulative := range cumulatives {
+		receipts = append(receipts, eth.TransactionReceipt{
+			Type:              eth.MustQuantity("0x3"),
+			Status:            eth.MustQuantity("0x1"),
+			CumulativeGasUsed: *eth.MustQuantity(cumulative),
+			LogsBloom:         eth.Data256("0x" + hex.EncodeToString(make([]byte, 256))),
+		})
+	}
+
+	receiptsRoot := eth.Hash("0x9af165447e5b3193e9ac8389418648ee6d6cb1d37459fe65cfc245fc358721bd")
+	for i := range receipts {
+		proof, err := trie.ProveReceipt(receipts, i)
+		require.NoError(t, err)
+
+		raw, err := trie.VerifyReceiptProof(receiptsRoot, i, proof)
+		require.NoError(t, err)
+
+		// the blob transaction type followed by [status, cumulativeGasUsed, logsBloom, logs]
+		expected := "0x03f901080182" + cumulatives[i][2:] + "b90100" + hex.EncodeToString(make([]byte, 256)) + "c0"
+		require.Equal(t, expected, raw.String())
+	}
+}
+
+func keccak(b []byte) []byte {
+	hash := sha3.NewLegacyKeccak256()
+	hash.Write(b)
+	return hash.Sum(nil)
+}
@@ -0,0 +1,247 @@
+// Package trie implements an in-memory Merkle Patricia Trie, as described in the appendix D of the yellow paper,
+// for computing the root hashes found in Ethereum block headers.
+package trie
+
+import (
+	"bytes"
+	"encoding/hex"
+
+	"golang.org/x/crypto/sha3"
+
+	"github.com/INFURA/go-ethlibs/eth"
+	"github.com/INFURA/go-ethlibs/rlp"
+)
+
+// EmptyRoot is the root hash of an empty trie, which is the keccak256 hash of the RLP encoded empty string.
+const EmptyRoot = eth.Hash("0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421")
+
+// terminator is the nibble appended to every key to mark the end of the key, and the index of the value in a branch.
+const terminator = 16
+
+type node interface{}
+
+type (
+	// branchNode has a child for every nibble, and an optional value in the 17th slot
+	branchNode struct {
+		children [17]node
+	}
+
+	// shortNode is either an extension node when its key doesn't end with the terminator, or otherwise a leaf node
+	shortNode struct {
+		key   []byte
+		value node
+	}
+
+	// valueNode holds the value stored under a key
+	valueNode []byte
+
+	// hashNode is a reference to a node by its hash, which only appears in proof nodes decoded by VerifyProof and never
+	// in a Trie
+	hashNode []byte
+)
+
+// Trie is an in-memory Merkle Patricia Trie.  The zero value is an empty trie ready to use.
+type Trie struct {
+	root node
+}
+
+// New returns a new empty Trie.
+func New() *Trie {
+	return &Trie{}
+}
+
+// Get returns the value stored under key, or nil if there isn't one.
+func (t *Trie) Get(key []byte) []byte {
+	n := t.root
+	k := keyToNibbles(key)
+	for {
+		switch current := n.(type) {
+		case nil:
+			return nil
+		case valueNode:
+			if len(k) == 0 {
+				return []byte(current)
+			}
+			return nil
+		case *shortNode:
+			if !bytes.HasPrefix(k, current.key) {
+				return nil
+			}
+			n, k = current.value, k[len(current.key):]
+		case *branchNode:
+			n, k = current.children[k[0]], k[1:]
+		}
+	}
+}
+
+// Insert stores value under key, replacing any existing value.  Inserting an empty value is the same as deleting key,
+// since the trie cannot distinguish an empty value from a missing one.
+func (t *Trie) Insert(key, value []byte) {
+	if len(value) == 0 {
+		t.Delete(key)
+		return
+	}
+
+	t.root = insert(t.root, keyToNibbles(key), valueNode(append([]byte{}, value...)))
+}
+
+// Delete removes the value stored under key, if any.
+func (t *Trie) Delete(key []byte) {
+	t.root = remove(t.root, keyToNibbles(key))
+}
+
+// Hash returns the root hash of the trie.
+func (t *Trie) Hash() eth.Hash {
+	if t.root == nil {
+		return EmptyRoot
+	}
+
+	encoded := mustEncode(encodeNode(t.root))
+	return eth.Hash("0x" + hex.EncodeToString(keccak256(encoded)))
+}
+
+// insert returns n with value stored under the nibbles of key
+func insert(n node, key []byte, value node) node {
+	if len(key) == 0 {
+		return value
+	}
+
+	switch current := n.(type) {
+	case nil:
+		return &shortNode{key: key, value: value}
+	case *shortNode:
+		match := prefixLength(key, current.key)
+		if match == len(current.key) {
+			return &shortNode{key: current.key, value: insert(current.value, key[match:], value)}
+		}
+
+		// the keys diverge, so a branch is needed where they do
+		branch := &branchNode{}
+		branch.children[current.key[match]] = insert(nil, current.key[match+1:], current.value)
+		branch.children[key[match]] = insert(nil, key[match+1:], value)
+		if match == 0 {
+			return branch
+		}
+		return &shortNode{key: key[:match], value: branch}
+	case *branchNode:
+		branch := *current
+		branch.children[key[0]] = insert(branch.children[key[0]], key[1:], value)
+		return &branch
+	}
+
+	panic("unreachable")
+}
+
+// remove returns n without the value stored under the nibbles of key, collapsing any nodes that are no longer needed
+func remove(n node, key []byte) node {
+	switch current := n.(type) {
+	case nil:
+		return nil
+	case valueNode:
+		if len(key) == 0 {
+			return nil
+		}
+		return current
+	case *shortNode:
+		if !bytes.HasPrefix(key, current.key) {
+			return current
+		}
+
+		child := remove(current.value, key[len(current.key):])
+		switch child := child.(type) {
+		case nil:
+			return nil
+		case *shortNode:
+			// merge the two short nodes into one
+			return &shortNode{key: concat(current.key, child.key...), value: child.value}
+		default:
+			return &shortNode{key: current.key, value: child}
+		}
+	case *branchNode:
+		branch := *current
+		branch.children[key[0]] = remove(branch.children[key[0]], key[1:])
+
+		remaining := -1
+		for i := range branch.children {
+			if branch.children[i] != nil {
+				if remaining != -1 {
+					// at least two children remain, so the branch is still needed
+					return &branch
+				}
+				remaining = i
+			}
+		}
+
+		switch {
+		case remaining == -1:
+			return nil
+		case remaining == terminator:
+			return &shortNode{key: []byte{terminator}, value: branch.children[terminator]}
+		}
+
+		// a branch with a single child is replaced by a short node leading to that child
+		if child, ok := branch.children[remaining].(*shortNode); ok {
+			return &shortNode{key: concat([]byte{byte(remaining)}, child.key...), value: child.value}
+		}
+		return &shortNode{key: []byte{byte(remaining)}, value: branch.children[remaining]}
+	}
+
+	panic("unreachable")
+}
+
+// encodeNode returns the RLP structure of a node
+func encodeNode(n node) rlp.Value {
+	switch current := n.(type) {
+	case *shortNode:
+		return rlp.Value{List: []rlp.Value{
+			{Bytes: compactKey(current.key)},
+			reference(current.value),
+		}}
+	case *branchNode:
+		list := make([]rlp.Value, len(current.children))
+		for i := range current.children {
+			list[i] = reference(current.children[i])
+		}
+		return rlp.Value{List: list}
+	case valueNode:
+		return rlp.Value{Bytes: current}
+	}
+
+	return rlp.Value{Bytes: []byte{}}
+}
+
+// reference returns how a node is referenced by its parent, which is the node itself if its encoding is shorter
+// than 32 bytes, or otherwise the keccak256 hash of its encoding.
+func reference(n node) rlp.Value {
+	switch current := n.(type) {
+	case nil:
+		return rlp.Value{Bytes: []byte{}}
+	case valueNode:
+		return encodeNode(n)
+	case hashNode:
+		return rlp.Value{Bytes: current}
+	}
+
+	value := encodeNode(n)
+	encoded := mustEncode(value)
+	if len(encoded) < 32 {
+		return value
+	}
+
+	return rlp.Value{Bytes: keccak256(encoded)}
+}
+
+// mustEncode encodes an rlp.Value built by encodeNode, which only fails for invalid String values that it never uses
+func mustEncode(value rlp.Value) []byte {
+	encoded, err := value.EncodeToBytes()
+	if err != nil {
+		panic(err)
+	}
+	return encoded
+}
+
+func keccak256(b []byte) []byte {
+	hash := sha3.NewLegacyKeccak256()
+	hash.Write(b)
+	return hash.Sum(nil)
+}
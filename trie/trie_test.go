@@ -0,0 +1,127 @@
+package trie_test
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/INFURA/go-ethlibs/eth"
+	"github.com/INFURA/go-ethlibs/trie"
+)
+
+func TestTrie_Hash(t *testing.T) {
+	{
+		tr := trie.New()
+		require.Equal(t, trie.EmptyRoot, tr.Hash())
+	}
+
+	{
+		tr := trie.New()
+		tr.Insert([]byte("doe"), []byte("reindeer"))
+		tr.Insert([]byte("dog"), []byte("puppy"))
+		tr.Insert([]byte("dogglesworth"), []byte("cat"))
+		require.Equal(t, eth.Hash("0x8aad789dff2f538bca5d8ea56e8abe10f4c7ba3a5dea95fea4cd6e7c3a1168d3"), tr.Hash())
+	}
+
+	{
+		tr := trie.New()
+		tr.Insert([]byte("A"), []byte(strings.Repeat("a", 50)))
+		require.Equal(t, eth.Hash("0xd23786fb4a010da3ce639d66d5e904a11dbc02746d1ce25029e53290cabf28ab"), tr.Hash())
+	}
+}
+
+func TestTrie_Delete(t *testing.T) {
+	updates := []struct {
+		key, value string
+	}{
+		{"do", "verb"},
+		{"ether", "wookiedoo"},
+		{"horse", "stallion"},
+		{"shaman", "horse"},
+		{"doge", "coin"},
+		{"ether", ""},
+		{"dog", "puppy"},
+		{"shaman", ""},
+	}
+
+	{
+		tr := trie.New()
+		for _, u := range updates {
+			if u.value == "" {
+				tr.Delete([]byte(u.key))
+			} else {
+				tr.Insert([]byte(u.key), []byte(u.value))
+			}
+		}
+		require.Equal(t, eth.Hash("0x5991bb8c6514148a29db676a14ac506cd2cd5775ace63c30a4fe457715e9ac84"), tr.Hash())
+		require.Nil(t, tr.Get([]byte("ether")))
+		require.Equal(t, []byte("puppy"), tr.Get([]byte("dog")))
+	}
+
+	{
+		// inserting empty values is the same as deleting
+		tr := trie.New()
+		for _, u := range updates {
+			tr.Insert([]byte(u.key), []byte(u.value))
+		}
+		require.Equal(t, eth.Hash("0x5991bb8c6514148a29db676a14ac506cd2cd5775ace63c30a4fe457715e9ac84"), tr.Hash())
+	}
+}
+
+func TestTrie_Get(t *testing.T) {
+	tr := trie.New()
+	tr.Insert([]byte("doe"), []byte("reindeer"))
+	tr.Insert([]byte("dog"), []byte("puppy"))
+	tr.Insert([]byte("dogglesworth"), []byte("cat"))
+
+	require.Equal(t, []byte("reindeer"), tr.Get([]byte("doe")))
+	require.Equal(t, []byte("puppy"), tr.Get([]byte("dog")))
+	require.Equal(t, []byte("cat"), tr.Get([]byte("dogglesworth")))
+	require.Nil(t, tr.Get([]byte("do")))
+	require.Nil(t, tr.Get([]byte("doggy")))
+	require.Nil(t, tr.Get([]byte("unknown")))
+
+	tr.Insert([]byte("dog"), []byte("hound"))
+	require.Equal(t, []byte("hound"), tr.Get([]byte("dog")))
+}
+
+func TestTrie_InsertionOrder(t *testing.T) {
+	// the root only depends on the contents of the trie, not on the order of the updates that produced it
+	keys := make([][]byte, 200)
+	for i := range keys {
+		keys[i] = []byte(fmt.Sprintf("key-%d", i*7919%1000))
+	}
+
+	expected := trie.New()
+	for _, key := range keys {
+		expected.Insert(key, append([]byte("value-"), key...))
+	}
+
+	rng := rand.New(rand.NewSource(1))
+	for round := 0; round < 10; round++ {
+		tr := trie.New()
+
+		// insert some keys that will be deleted again
+		for i := 0; i < 50; i++ {
+			tr.Insert([]byte(fmt.Sprintf("extra-%d", i)), []byte("x"))
+		}
+
+		for _, i := range rng.Perm(len(keys)) {
+			tr.Insert(keys[i], append([]byte("value-"), keys[i]...))
+		}
+
+		for _, i := range rng.Perm(50) {
+			tr.Delete([]byte(fmt.Sprintf("extra-%d", i)))
+		}
+
+		require.Equal(t, expected.Hash(), tr.Hash(), "round %d", round)
+	}
+
+	for _, key := range keys {
+		expected.Delete(key)
+	}
+	require.Equal(t, trie.EmptyRoot, expected.Hash())
+}